@@ -14,6 +14,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -61,6 +62,9 @@ type app struct {
 	zs         []int
 	hitWidgets []Widget
 
+	touchHitWidgets map[ebiten.TouchID][]Widget
+	tmpTouchIDs     []ebiten.TouchID
+
 	invalidatedRegions image.Rectangle
 
 	invalidatedRegionsForDebug []invalidatedRegionsForDebugItem
@@ -72,10 +76,47 @@ type app struct {
 	lastScreenWidth  float64
 	lastScreenHeight float64
 
-	focusedWidgetState *widgetState
+	focusedWidget Widget
+	focusVisible  bool
+
+	focusNavigationEnabled  bool
+	tmpFocusCandidates      []Widget
+	tmpFocusCandidateBounds []image.Rectangle
+	gamepadIDs              []ebiten.GamepadID
+	gamepadStickTilted      bool
+
+	pointerCapture    Widget
+	hoveredWidgets    []Widget
+	tmpHoveredWidgets []Widget
+	pressedWidgets    [ebiten.MouseButtonMax + 1][]Widget
+
+	undoManager UndoManager
+
+	// widgetInProgress is the widget whose method is being called, used to report a failed widget to an error boundary.
+	widgetInProgress Widget
+
+	overlays        []Widget
+	overlaysCreated bool
+
+	tasks          []func()
+	tmpTasks       []func()
+	postedTasks    []postedTask
+	tmpPostedTasks []postedTask
+	tasksM         sync.Mutex
 
 	offscreen   *ebiten.Image
 	debugScreen *ebiten.Image
+
+	// screenColorPoint is the point requested to sample the screen color at in the next Draw.
+	screenColorPoint     image.Point
+	screenColorRequested bool
+	screenColorSampled   bool
+	screenColor          color.Color
+}
+
+type postedTask struct {
+	widget Widget
+	f      func(context *Context)
 }
 
 type RunOptions struct {
@@ -85,6 +126,10 @@ type RunOptions struct {
 	WindowMaxSize image.Point
 	AppScale      float64
 
+	// FocusNavigationEnabled enables the focus navigation by the arrow keys, Enter, Space, Escape and gamepads.
+	// See also [ActivateHandler] and [BackHandler].
+	FocusNavigationEnabled bool
+
 	RunGameOptions *ebiten.RunGameOptions
 }
 
@@ -130,6 +175,7 @@ func RunWithCustomFunc(root Widget, options *RunOptions, f func(game ebiten.Game
 	if options.AppScale > 0 {
 		a.context.appScaleMinus1 = options.AppScale - 1
 	}
+	a.focusNavigationEnabled = options.FocusNavigationEnabled
 
 	var eop ebiten.RunGameOptions
 	if options.RunGameOptions != nil {
@@ -157,8 +203,8 @@ func (a *app) bounds() image.Rectangle {
 }
 
 func (a *app) Update() error {
-	if a.focusedWidgetState == nil {
-		a.focusedWidgetState = a.root.widgetState()
+	if a.focusedWidget == nil {
+		a.focusedWidget = a.root
 	}
 
 	rootState := a.root.widgetState()
@@ -169,6 +215,9 @@ func (a *app) Update() error {
 		a.requestRedraw(a.bounds())
 	}
 
+	a.runPostedTasks()
+	a.runTasks()
+
 	// Construct the widget tree.
 	a.context.inBuild = true
 	if err := a.build(); err != nil {
@@ -176,6 +225,9 @@ func (a *app) Update() error {
 	}
 	a.context.inBuild = false
 
+	a.validatePointerCapture()
+	a.notifyPointerEvents()
+
 	// Handle user inputs.
 	// TODO: Handle this in Ebitengine's HandleInput in the future (hajimehoshi/ebiten#1704)
 	if r := a.handleInputWidget(handleInputTypePointing); r.widget != nil {
@@ -183,11 +235,8 @@ func (a *app) Update() error {
 			slog.Info("pointing input handled", "widget", fmt.Sprintf("%T", r.widget), "aborted", r.aborted)
 		}
 	}
-	if r := a.handleInputWidget(handleInputTypeButton); r.widget != nil {
-		if theDebugMode.showInputLogs {
-			slog.Info("keyboard input handled", "widget", fmt.Sprintf("%T", r.widget), "aborted", r.aborted)
-		}
-	}
+	a.releasePointerCaptureIfNeeded()
+	a.handleButtonInput()
 
 	// Construct the widget tree again to reflect the latest state.
 	a.context.inBuild = true
@@ -225,6 +274,10 @@ func (a *app) Update() error {
 
 	a.resetPrevWidgets(a.root)
 
+	// Run the tasks enqueued during this frame, e.g. invalidating widgets reading changed observables.
+	a.runTasks()
+	a.widgetInProgress = nil
+
 	// Resolve dirty widgets.
 	_ = traverseWidget(a.root, func(widget Widget) error {
 		if !widget.widgetState().dirty {
@@ -286,8 +339,33 @@ func (a *app) Draw(screen *ebiten.Image) {
 		screen = a.offscreen
 	}
 	a.drawWidget(screen)
+	a.sampleScreenColorIfNeeded(screen)
 	a.drawDebugIfNeeded(origScreen)
 	a.invalidatedRegions = image.Rectangle{}
+	a.widgetInProgress = nil
+}
+
+// sampleScreenColorIfNeeded reads the color requested by RequestScreenColor from the rendered screen.
+// The pixel is read here, as reading pixels is allowed only in Draw and is slow.
+func (a *app) sampleScreenColorIfNeeded(screen *ebiten.Image) {
+	if !a.screenColorRequested {
+		return
+	}
+	a.screenColorRequested = false
+	pt := screenImagePoint(a.screenColorPoint, a.bounds(), screen.Bounds())
+	a.screenColor = screen.At(pt.X, pt.Y)
+	a.screenColorSampled = true
+}
+
+// screenImagePoint converts point in the app bounds to the point on the screen image.
+// The sizes can differ, e.g. when the scaled screen size is rounded.
+func screenImagePoint(point image.Point, appBounds image.Rectangle, screenBounds image.Rectangle) image.Point {
+	if appBounds.Dx() == 0 || appBounds.Dy() == 0 {
+		return screenBounds.Min
+	}
+	return image.Pt(
+		screenBounds.Min.X+(point.X-appBounds.Min.X)*screenBounds.Dx()/appBounds.Dx(),
+		screenBounds.Min.Y+(point.Y-appBounds.Min.Y)*screenBounds.Dy()/appBounds.Dy())
 }
 
 func (a *app) Layout(outsideWidth, outsideHeight int) (int, int) {
@@ -301,6 +379,61 @@ func (a *app) LayoutF(outsideWidth, outsideHeight float64) (float64, float64) {
 	return a.screenWidth, a.screenHeight
 }
 
+// enqueueTask enqueues a task to run on the UI thread. enqueueTask can be called from any goroutine.
+func (a *app) enqueueTask(f func()) {
+	a.tasksM.Lock()
+	a.tasks = append(a.tasks, f)
+	a.tasksM.Unlock()
+
+	// Wake the game loop in case it is throttled.
+	ebiten.ScheduleFrame()
+}
+
+func (a *app) runTasks() {
+	a.tasksM.Lock()
+	a.tmpTasks, a.tasks = a.tasks, a.tmpTasks[:0]
+	a.tasksM.Unlock()
+
+	for _, f := range a.tmpTasks {
+		f()
+	}
+	clear(a.tmpTasks)
+}
+
+func (a *app) post(widget Widget, f func(context *Context)) {
+	a.tasksM.Lock()
+	a.postedTasks = append(a.postedTasks, postedTask{
+		widget: widget,
+		f:      f,
+	})
+	a.tasksM.Unlock()
+
+	ebiten.ScheduleFrame()
+}
+
+func (a *app) runPostedTasks() {
+	a.tasksM.Lock()
+	a.tmpPostedTasks, a.postedTasks = a.postedTasks, a.tmpPostedTasks[:0]
+	a.tasksM.Unlock()
+
+	for _, t := range a.tmpPostedTasks {
+		// Drop the task if its widget has been removed from the tree.
+		if t.widget != nil && !t.widget.widgetState().isInTree() {
+			continue
+		}
+		if t.widget == nil {
+			t.f(&a.context)
+			continue
+		}
+		// A panic in the task is caught by the error boundary of the widget.
+		a.callForWidget(t.widget, func() {
+			t.f(&a.context)
+		})
+	}
+	a.widgetInProgress = nil
+	clear(a.tmpPostedTasks)
+}
+
 func (a *app) requestRedraw(region image.Rectangle) {
 	a.invalidatedRegions = a.invalidatedRegions.Union(region)
 }
@@ -329,33 +462,7 @@ func (a *app) build() error {
 	}
 
 	var appender ChildWidgetAppender
-	if err := traverseWidget(a.root, func(widget Widget) error {
-		widgetState := widget.widgetState()
-
-		// Reset the current children before building.
-		for _, child := range widgetState.children {
-			child.widgetState().parent = nil
-		}
-
-		if parent := widgetState.parent; parent != nil {
-			widgetState.z = parent.widgetState().z + widget.ZDelta()
-		} else {
-			widgetState.z = 0
-		}
-		widgetState.hasVisibleBoundsCache = false
-		widgetState.visibleBoundsCache = image.Rectangle{}
-
-		widgetState.children = slices.Delete(widgetState.children, 0, len(widgetState.children))
-		appender.app = a
-		appender.widget = widget
-		if err := widget.Build(&a.context, &appender); err != nil {
-			return err
-		}
-
-		a.visitedZs[widgetState.z] = struct{}{}
-
-		return nil
-	}); err != nil {
+	if err := a.buildWidget(a.root, &appender); err != nil {
 		return err
 	}
 
@@ -370,9 +477,73 @@ func (a *app) build() error {
 		return b.widgetState().z - a.widgetState().z
 	})
 
+	a.updateTouchHitWidgets()
+
 	return nil
 }
 
+func (a *app) buildWidget(widget Widget, appender *ChildWidgetAppender) error {
+	widgetState := widget.widgetState()
+
+	// Reset the current children before building.
+	for _, child := range widgetState.children {
+		child.widgetState().parent = nil
+	}
+
+	if parent := widgetState.parent; parent != nil {
+		widgetState.z = parent.widgetState().z + widget.ZDelta()
+	} else {
+		widgetState.z = 0
+	}
+	widgetState.hasVisibleBoundsCache = false
+	widgetState.visibleBoundsCache = image.Rectangle{}
+
+	widgetState.children = slices.Delete(widgetState.children, 0, len(widgetState.children))
+	appender.app = a
+	appender.widget = widget
+	a.widgetInProgress = widget
+	if err := widget.Build(&a.context, appender); err != nil {
+		return err
+	}
+	if widgetState.root {
+		a.appendOverlays(appender)
+	}
+
+	a.visitedZs[widgetState.z] = struct{}{}
+
+	if catcher, ok := widget.(errorCatcher); ok {
+		err := callWithRecover(catcher, func() error {
+			return a.buildChildWidgets(widget, appender)
+		})
+		if err == nil {
+			return nil
+		}
+		if !a.catchError(catcher, err) {
+			return err
+		}
+		// Build the error boundary again to replace the failed subtree with its fallback.
+		return a.buildWidget(widget, appender)
+	}
+
+	return a.buildChildWidgets(widget, appender)
+}
+
+func (a *app) buildChildWidgets(widget Widget, appender *ChildWidgetAppender) error {
+	for _, child := range widget.widgetState().children {
+		if err := a.buildWidget(child, appender); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *app) catchError(catcher errorCatcher, err error) bool {
+	path := widgetPath(a.widgetInProgress)
+	// The failed widget is no longer in progress. Attribute the following calls, e.g. the error callback, to the catcher.
+	a.widgetInProgress = catcher
+	return catcher.catchError(&a.context, path, err)
+}
+
 type handleInputType int
 
 const (
@@ -381,6 +552,9 @@ const (
 )
 
 func (a *app) handleInputWidget(typ handleInputType) HandleInputResult {
+	if typ == handleInputTypePointing && a.pointerCapture != nil {
+		return a.handleCapturedPointingInput()
+	}
 	for i := len(a.zs) - 1; i >= 0; i-- {
 		z := a.zs[i]
 		if r := a.doHandleInputWidget(typ, a.root, z); r.shouldRaise() {
@@ -390,6 +564,24 @@ func (a *app) handleInputWidget(typ handleInputType) HandleInputResult {
 	return HandleInputResult{}
 }
 
+// handleButtonInput handles the button inputs by the focused widget and its ancestors.
+// If none of them handles the inputs, the shortcuts and then the focus navigation if enabled are handled.
+func (a *app) handleButtonInput() {
+	if r := a.handleInputWidget(handleInputTypeButton); r.widget != nil {
+		if theDebugMode.showInputLogs {
+			slog.Info("keyboard input handled", "widget", fmt.Sprintf("%T", r.widget), "aborted", r.aborted)
+		}
+		return
+	}
+	if a.handleShortcutInput(a.root) {
+		return
+	}
+	if !a.focusNavigationEnabled {
+		return
+	}
+	a.handleFocusNavigationInput()
+}
+
 func (a *app) doHandleInputWidget(typ handleInputType, widget Widget, zToHandle int) HandleInputResult {
 	if widget.PassThrough() {
 		return HandleInputResult{}
@@ -410,19 +602,28 @@ func (a *app) doHandleInputWidget(typ handleInputType, widget Widget, zToHandle
 		return HandleInputResult{}
 	}
 
-	widgetState := widget.widgetState()
-	// Iterate the children in the reverse order of rendering.
-	for i := len(widgetState.children) - 1; i >= 0; i-- {
-		child := widgetState.children[i]
-		if r := a.doHandleInputWidget(typ, child, zToHandle); r.shouldRaise() {
+	if catcher, ok := widget.(errorCatcher); ok {
+		var r HandleInputResult
+		if err := callWithRecover(catcher, func() error {
+			r = a.handleInputChildWidgets(typ, widget, zToHandle)
+			return nil
+		}); err != nil {
+			// callWithRecover returns an error only when catcher can catch it.
+			a.catchError(catcher, err)
+			return HandleInputResult{}
+		}
+		if r.shouldRaise() {
 			return r
 		}
+	} else if r := a.handleInputChildWidgets(typ, widget, zToHandle); r.shouldRaise() {
+		return r
 	}
 
 	if zToHandle != widget.widgetState().z {
 		return HandleInputResult{}
 	}
 
+	a.widgetInProgress = widget
 	switch typ {
 	case handleInputTypePointing:
 		return widget.HandlePointingInput(&a.context)
@@ -433,7 +634,28 @@ func (a *app) doHandleInputWidget(typ handleInputType, widget Widget, zToHandle
 	}
 }
 
+func (a *app) handleInputChildWidgets(typ handleInputType, widget Widget, zToHandle int) HandleInputResult {
+	widgetState := widget.widgetState()
+	// Iterate the children in the reverse order of rendering.
+	for i := len(widgetState.children) - 1; i >= 0; i-- {
+		child := widgetState.children[i]
+		if r := a.doHandleInputWidget(typ, child, zToHandle); r.shouldRaise() {
+			return r
+		}
+	}
+	return HandleInputResult{}
+}
+
 func (a *app) cursorShape() bool {
+	if a.pointerCapture != nil {
+		shape, ok := a.pointerCapture.CursorShape(&a.context)
+		if !ok {
+			return false
+		}
+		ebiten.SetCursorShape(shape)
+		return true
+	}
+
 	var firstZ int
 	for i, widget := range a.hitWidgets {
 		if i == 0 {
@@ -456,17 +678,31 @@ func (a *app) cursorShape() bool {
 }
 
 func (a *app) updateWidget(widget Widget) error {
-	widgetState := widget.widgetState()
+	a.widgetInProgress = widget
 	if err := widget.Tick(&a.context); err != nil {
 		return err
 	}
 
-	for _, child := range widgetState.children {
+	if catcher, ok := widget.(errorCatcher); ok {
+		if err := callWithRecover(catcher, func() error {
+			return a.updateChildWidgets(widget)
+		}); err != nil {
+			if !a.catchError(catcher, err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return a.updateChildWidgets(widget)
+}
+
+func (a *app) updateChildWidgets(widget Widget) error {
+	for _, child := range widget.widgetState().children {
 		if err := a.updateWidget(child); err != nil {
 			return err
 		}
 	}
-
 	return nil
 }
 
@@ -535,11 +771,20 @@ func (a *app) doDrawWidget(dst *ebiten.Image, widget Widget, zToRender int) {
 			dst = widgetState.ensureOffscreen(dst.Bounds())
 			dst.Clear()
 		}
+		a.widgetInProgress = widget
 		widget.Draw(&a.context, dst.SubImage(vb).(*ebiten.Image))
 	}
 
-	for _, child := range widgetState.children {
-		a.doDrawWidget(dst, child, zToRender)
+	if catcher, ok := widget.(errorCatcher); ok {
+		if err := callWithRecover(catcher, func() error {
+			a.drawChildWidgets(dst, widget, zToRender)
+			return nil
+		}); err != nil {
+			// The fallback is rendered at the next frame.
+			a.catchError(catcher, err)
+		}
+	} else {
+		a.drawChildWidgets(dst, widget, zToRender)
 	}
 
 	if renderCurrent {
@@ -556,6 +801,12 @@ func (a *app) doDrawWidget(dst *ebiten.Image, widget Widget, zToRender int) {
 	}
 }
 
+func (a *app) drawChildWidgets(dst *ebiten.Image, widget Widget, zToRender int) {
+	for _, child := range widget.widgetState().children {
+		a.doDrawWidget(dst, child, zToRender)
+	}
+}
+
 func (a *app) drawDebugIfNeeded(screen *ebiten.Image) {
 	if !theDebugMode.showRenderingRegions {
 		return
@@ -587,12 +838,19 @@ func (a *app) drawDebugIfNeeded(screen *ebiten.Image) {
 }
 
 func (a *app) isWidgetHitAt(widget Widget) bool {
+	return a.isWidgetHitIn(widget, a.hitWidgets)
+}
+
+func (a *app) isWidgetHitIn(widget Widget, hitWidgets []Widget) bool {
 	if !widget.widgetState().isInTree() {
 		return false
 	}
+	if a.pointerCapture != nil {
+		return a.pointerCapture.widgetState() == widget.widgetState()
+	}
 	// hitWidgets are ordered by descending z values.
 	// Always use a fixed set hitWidgets, as the tree might be dynamically changed during Build.
-	for _, w := range a.hitWidgets {
+	for _, w := range hitWidgets {
 		z1 := w.widgetState().z
 		z2 := widget.widgetState().z
 		if z1 > z2 {
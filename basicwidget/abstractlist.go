@@ -13,38 +13,92 @@ type ider[ID comparable] interface {
 
 type abstractList[ID comparable, Item ider[ID]] struct {
 	items           []Item
-	selectedIndices []int
+	selectionMode   ListSelectionMode
+	selectedIndices []int // sorted
+	tmpIndices      []int
 
-	onItemSelected func(index int)
+	// itemCount and itemByIndex provide items lazily instead of items, if itemByIndex is not nil.
+	itemCount   int
+	itemByIndex func(index int) Item
+
+	onItemSelected     func(index int)
+	onSelectionChanged func(indices []int)
 }
 
 func (a *abstractList[ID, Item]) SetOnItemSelected(f func(index int)) {
 	a.onItemSelected = f
 }
 
+func (a *abstractList[ID, Item]) SetOnSelectionChanged(f func(indices []int)) {
+	a.onSelectionChanged = f
+}
+
+// SetSelectionMode sets the selection mode, and reports whether the selection is changed.
+func (a *abstractList[ID, Item]) SetSelectionMode(mode ListSelectionMode) bool {
+	a.selectionMode = mode
+	switch mode {
+	case ListSelectionModeNone:
+		return a.SelectItemsByIndices(nil)
+	case ListSelectionModeSingle:
+		if len(a.selectedIndices) > 1 {
+			return a.SelectItemsByIndices(a.selectedIndices[:1])
+		}
+	}
+	return false
+}
+
+func (a *abstractList[ID, Item]) fireSelectionChanged() {
+	if a.onSelectionChanged != nil {
+		a.onSelectionChanged(slices.Clone(a.selectedIndices))
+	}
+}
+
 func (a *abstractList[ID, Item]) SetItems(items []Item) {
 	a.items = adjustSliceSize(items, len(items))
 	copy(a.items, items)
+	a.itemCount = 0
+	a.itemByIndex = nil
+}
+
+// setItemProvider makes the list provide items lazily by itemByIndex, instead of the items set by SetItems.
+func (a *abstractList[ID, Item]) setItemProvider(count int, itemByIndex func(index int) Item) {
+	a.items = a.items[:0]
+	a.itemCount = count
+	a.itemByIndex = itemByIndex
+	a.selectedIndices = slices.DeleteFunc(a.selectedIndices, func(index int) bool {
+		return index >= count
+	})
+}
+
+func (a *abstractList[ID, Item]) isLazy() bool {
+	return a.itemByIndex != nil
 }
 
 func (a *abstractList[ID, Item]) ItemCount() int {
+	if a.itemByIndex != nil {
+		return a.itemCount
+	}
 	return len(a.items)
 }
 
 func (a *abstractList[ID, Item]) ItemByIndex(index int) (Item, bool) {
-	if index < 0 || index >= len(a.items) {
+	if index < 0 || index >= a.ItemCount() {
 		var item Item
 		return item, false
 	}
+	if a.itemByIndex != nil {
+		return a.itemByIndex(index), true
+	}
 	return a.items[index], true
 }
 
 func (a *abstractList[ID, Item]) SelectItemByIndex(index int, forceFireEvents bool) bool {
-	if index < 0 || index >= len(a.items) {
+	if a.selectionMode == ListSelectionModeNone || index < 0 || index >= a.ItemCount() {
 		if len(a.selectedIndices) == 0 {
 			return false
 		}
 		a.selectedIndices = a.selectedIndices[:0]
+		a.fireSelectionChanged()
 		return true
 	}
 
@@ -53,6 +107,7 @@ func (a *abstractList[ID, Item]) SelectItemByIndex(index int, forceFireEvents bo
 	}
 
 	selected := slices.Contains(a.selectedIndices, index)
+	changed := len(a.selectedIndices) != 1 || a.selectedIndices[0] != index
 	a.selectedIndices = adjustSliceSize(a.selectedIndices, 1)
 	a.selectedIndices[0] = index
 	if !selected || forceFireEvents {
@@ -60,13 +115,65 @@ func (a *abstractList[ID, Item]) SelectItemByIndex(index int, forceFireEvents bo
 			a.onItemSelected(index)
 		}
 	}
+	if changed {
+		a.fireSelectionChanged()
+	}
 	return true
 }
 
+// SelectItemByIndexWithoutEvents is like SelectItemByIndex, but does not call the callbacks, e.g. for a change from a bound value.
+func (a *abstractList[ID, Item]) SelectItemByIndexWithoutEvents(index int) bool {
+	onItemSelected, onSelectionChanged := a.onItemSelected, a.onSelectionChanged
+	a.onItemSelected, a.onSelectionChanged = nil, nil
+	defer func() {
+		a.onItemSelected, a.onSelectionChanged = onItemSelected, onSelectionChanged
+	}()
+	return a.SelectItemByIndex(index, false)
+}
+
+// SelectItemsByIndices replaces the selection with indices, and reports whether the selection is changed.
+// Invalid indices are ignored.
+// In the single selection mode, only the smallest index is selected.
+func (a *abstractList[ID, Item]) SelectItemsByIndices(indices []int) bool {
+	a.tmpIndices = a.tmpIndices[:0]
+	if a.selectionMode != ListSelectionModeNone {
+		for _, index := range indices {
+			if index < 0 || index >= a.ItemCount() {
+				continue
+			}
+			a.tmpIndices = append(a.tmpIndices, index)
+		}
+		slices.Sort(a.tmpIndices)
+		a.tmpIndices = slices.Compact(a.tmpIndices)
+		if a.selectionMode == ListSelectionModeSingle && len(a.tmpIndices) > 1 {
+			a.tmpIndices = a.tmpIndices[:1]
+		}
+	}
+	if slices.Equal(a.selectedIndices, a.tmpIndices) {
+		return false
+	}
+	a.selectedIndices, a.tmpIndices = a.tmpIndices, a.selectedIndices
+	a.fireSelectionChanged()
+	return true
+}
+
+func (a *abstractList[ID, Item]) IsItemSelected(index int) bool {
+	_, ok := slices.BinarySearch(a.selectedIndices, index)
+	return ok
+}
+
+func (a *abstractList[ID, Item]) AppendSelectedItemIndices(indices []int) []int {
+	return append(indices, a.selectedIndices...)
+}
+
 func (a *abstractList[ID, Item]) SelectItemByID(id ID, forceFireEvents bool) bool {
-	idx := slices.IndexFunc(a.items, func(item Item) bool {
-		return item.id() == id
-	})
+	idx := -1
+	for i := range a.ItemCount() {
+		if item, _ := a.ItemByIndex(i); item.id() == id {
+			idx = i
+			break
+		}
+	}
 	return a.SelectItemByIndex(idx, forceFireEvents)
 }
 
@@ -75,9 +182,10 @@ func (a *abstractList[ID, Item]) SelectedItem() (Item, bool) {
 		var item Item
 		return item, false
 	}
-	return a.items[a.selectedIndices[0]], true
+	return a.ItemByIndex(a.selectedIndices[0])
 }
 
+// SelectedItemIndex returns the smallest selected index, or -1 if no item is selected.
 func (a *abstractList[ID, Item]) SelectedItemIndex() int {
 	if len(a.selectedIndices) == 0 {
 		return -1
@@ -7,6 +7,8 @@ import (
 	"math"
 	"math/big"
 	"strings"
+
+	"github.com/hajimehoshi/guigui"
 )
 
 type abstractNumberInput struct {
@@ -22,6 +24,8 @@ type abstractNumberInput struct {
 	onValueChangedBigInt func(value *big.Int)
 	onValueChangedInt64  func(value int64)
 	onValueChangedUint64 func(value uint64)
+
+	int64Binding binding[int64]
 }
 
 func (a *abstractNumberInput) SetOnValueChangedString(f func(value string, force bool)) {
@@ -40,7 +44,26 @@ func (a *abstractNumberInput) SetOnValueChangedUint64(f func(value uint64)) {
 	a.onValueChangedUint64 = f
 }
 
+func (a *abstractNumberInput) bindValueInt64(value *guigui.Observable[int64]) {
+	a.int64Binding.bind(value)
+}
+
+// pullValueFromBinding updates the value with the bound observable's value if it is changed.
+// The callbacks are not called, as the change comes from the model.
+func (a *abstractNumberInput) pullValueFromBinding(context *guigui.Context) {
+	value, ok := a.int64Binding.pull(context)
+	if !ok {
+		return
+	}
+	v := (&big.Int{}).SetInt64(value)
+	a.clamp(v)
+	a.value.Set(v)
+}
+
 func (a *abstractNumberInput) fireValueChangeEvents(force bool) {
+	if a.int64Binding.isBound() {
+		a.int64Binding.push(a.ValueInt64())
+	}
 	if a.onValueChangedString != nil {
 		a.onValueChangedString(a.value.String(), force)
 	}
@@ -23,6 +23,7 @@ type baseButton struct {
 	prevHovered     bool
 	sharpenCorners  draw.SharpenCorners
 	pairedButton    *baseButton
+	tap             tapRecognizer
 
 	onDown   func()
 	onUp     func()
@@ -54,6 +55,8 @@ func (b *baseButton) setPressed(pressed bool) {
 }
 
 func (b *baseButton) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	context.SetFocusable(b, true)
+
 	// TODO: Do not call isHovered in Build (#52).
 	hovered := b.isHovered(context)
 	if b.prevHovered != hovered {
@@ -64,6 +67,12 @@ func (b *baseButton) Build(context *guigui.Context, appender *guigui.ChildWidget
 }
 
 func (b *baseButton) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	// A tap presses and releases the button at once.
+	if _, _, ok := b.tap.handleTouchInput(context, b); ok && !b.keepPressed && context.IsEnabled(b) {
+		context.SetFocused(b, true)
+		b.HandleActivate(context)
+		return guigui.HandleInputByWidget(b)
+	}
 	if b.isHovered(context) && !b.keepPressed {
 		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 			context.SetFocused(b, true)
@@ -99,6 +108,16 @@ func (b *baseButton) HandlePointingInput(context *guigui.Context) guigui.HandleI
 	return guigui.HandleInputResult{}
 }
 
+// HandleActivate implements guigui.ActivateHandler.
+func (b *baseButton) HandleActivate(context *guigui.Context) {
+	if b.onDown != nil {
+		b.onDown()
+	}
+	if b.onUp != nil {
+		b.onUp()
+	}
+}
+
 func (b *baseButton) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
 	if (b.canPress(context) || b.pressed || b.pairedButton != nil && b.pairedButton.pressed) && !b.keepPressed {
 		return ebiten.CursorShapePointer, true
@@ -144,6 +163,10 @@ func (b *baseButton) Draw(context *guigui.Context, dst *ebiten.Image) {
 		clr1, clr2 := draw.BorderColors(context.ColorMode(), borderType, b.useAccentColor && b.isPressed(context) && context.IsEnabled(b))
 		draw.DrawRoundedRectBorderWithSharpenCorners(context, dst, bounds, clr1, clr2, r, float32(1*context.Scale()), borderType, b.sharpenCorners)
 	}
+
+	if context.IsFocusVisible(b) {
+		drawFocusRing(context, dst, bounds, r)
+	}
 }
 
 func (b *baseButton) canPress(context *guigui.Context) bool {
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"image"
 	"image/color"
-	"time"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
@@ -25,11 +28,24 @@ const (
 	ListStyleMenu
 )
 
+type ListSelectionMode int
+
+const (
+	ListSelectionModeSingle ListSelectionMode = iota
+	ListSelectionModeMultiple
+	ListSelectionModeNone
+)
+
 type baseListItem[T comparable] struct {
 	Content    guigui.Widget
 	Selectable bool
 	Movable    bool
+	Checked    bool
+	Radio      bool
 	ID         T
+
+	// Text is used for type-ahead search.
+	Text string
 }
 
 func (b baseListItem[T]) id() T {
@@ -47,7 +63,7 @@ func DefaultDisabledListItemTextColor(context *guigui.Context) color.Color {
 type baseList[T comparable] struct {
 	guigui.DefaultWidget
 
-	checkmark     Image
+	checkmarks    []Image
 	listFrame     listFrame[T]
 	scrollOverlay ScrollOverlay
 
@@ -56,7 +72,7 @@ type baseList[T comparable] struct {
 	style                      ListStyle
 	checkmarkIndexPlus1        int
 	lastHoverredItemIndexPlus1 int
-	lastSelectingItemTime      time.Time // TODO: Use ebiten.Tick.
+	lastSelectingItemTick      int64
 
 	indexToJumpPlus1        int
 	dragSrcIndexPlus1       int
@@ -66,12 +82,68 @@ type baseList[T comparable] struct {
 	startPressingIndexPlus1 int
 	startPressingLeft       bool
 
+	// anchorIndexPlus1 is the start of a range selected with Shift.
+	// leadIndexPlus1 is the other end, moved by the keyboard.
+	anchorIndexPlus1 int
+	leadIndexPlus1   int
+
+	// keyboardHighlightIndexPlus1 is the item highlighted by the keyboard in the menu style.
+	// The highlight follows the cursor again when the cursor moves onto another item.
+	keyboardHighlightIndexPlus1 int
+
+	typeAheadText     string
+	typeAheadLastTick int64
+	tmpInputChars     []rune
+
+	// collapseSelectionOnRelease is true when pressing one of the selected items,
+	// which selects only the item unless the selected items are dragged.
+	collapseSelectionOnRelease bool
+
+	// The rubber-band positions are relative to the top of the first item.
+	rubberBanding         bool
+	rubberBandStartX      int
+	rubberBandStartY      int
+	rubberBandEndX        int
+	rubberBandEndY        int
+	rubberBandBaseIndices []int
+	tmpIndices            []int
+
 	cachedDefaultWidth  int
 	cachedDefaultHeight int
 
+	// itemHeights caches the item heights when the items are provided lazily.
+	// In this case, only the items in [visibleStartIndex, visibleEndIndex) have their content widgets.
+	itemHeights       itemHeightCache
+	visibleStartIndex int
+	visibleEndIndex   int
+
 	onItemsMoved func(from, count, to int)
+
+	// onItemActivated is called when an item is double-clicked, double-tapped or Enter is pressed.
+	onItemActivated     func(index int)
+	lastClickIndexPlus1 int
+	lastClickTick       int64
+
+	tap tapRecognizer
+
+	// onItemsDropped is called with the dragged indices and the destination instead of onItemsMoved, if set.
+	onItemsDropped func(context *guigui.Context, indices []int, to int)
+
+	// contentWidth is the width of the scrollable content. The list scrolls horizontally when this is wider than the list.
+	contentWidth int
+
+	// frameHidden hides the frame, e.g. when the owner draws a frame around the list and other widgets.
+	frameHidden bool
+
+	// dropGuidelineIndent returns the start of the drop guideline at the destination relative to the list, if set.
+	dropGuidelineIndent func(context *guigui.Context, to int) int
+
+	selectedItemIndexBinding binding[int]
 }
 
+// listOverscanItemCount is the number of the items built around the visible items when the items are provided lazily.
+const listOverscanItemCount = 8
+
 func listItemPadding(context *guigui.Context) int {
 	return UnitSize(context) / 4
 }
@@ -96,61 +168,86 @@ func (b *baseList[T]) SetCheckmarkIndex(index int) {
 }
 
 func (b *baseList[T]) contentSize(context *guigui.Context) image.Point {
-	return image.Pt(context.Size(b).X, b.defaultHeight(context))
+	return image.Pt(max(context.Size(b).X, b.contentWidth), b.defaultHeight(context))
+}
+
+func (b *baseList[T]) setContentWidth(width int) {
+	if b.contentWidth == width {
+		return
+	}
+	b.contentWidth = width
+	guigui.RequestRedraw(b)
+}
+
+func (b *baseList[T]) bindSelectedItemIndex(index *guigui.Observable[int]) {
+	b.selectedItemIndexBinding.bind(index)
 }
 
 func (b *baseList[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
-	b.scrollOverlay.SetContentSize(context, b.contentSize(context))
+	context.SetFocusable(b, true)
 
-	if idx := b.indexToJumpPlus1 - 1; idx >= 0 {
-		y := b.itemYFromIndex(context, idx) - RoundedCornerRadius(context)
-		b.scrollOverlay.SetOffset(context, b.contentSize(context), 0, float64(-y))
-		b.indexToJumpPlus1 = 0
+	if index, ok := b.selectedItemIndexBinding.pull(context); ok && b.abstractList.SelectItemByIndexWithoutEvents(index) {
+		guigui.RequestRedraw(b)
 	}
 
+	b.scrollOverlay.SetContentSize(context, b.contentSize(context))
+	b.jumpIfNeeded(context)
+
 	appender.AppendChildWidgetWithBounds(&b.scrollOverlay, context.Bounds(b))
 
 	// TODO: Do not call HoveredItemIndex in Build (#52).
-	hoveredItemIndex := b.hoveredItemIndex(context)
+	highlightedItemIndex := b.highlightedItemIndex(context)
 	p := context.Position(b)
-	_, offsetY := b.scrollOverlay.Offset()
-	p.X += RoundedCornerRadius(context) + listItemPadding(context)
+	offsetX, offsetY := b.scrollOverlay.Offset()
+	p.X += RoundedCornerRadius(context) + listItemPadding(context) + int(offsetX)
 	p.Y += RoundedCornerRadius(context) + int(offsetY)
-	for i := range b.abstractList.ItemCount() {
+	start, end := b.itemRangeToBuild()
+	p.Y += b.itemOffset(context, start)
+	b.checkmarks = adjustSliceSize(b.checkmarks, end-start)
+	hasCheckmarks := b.hasCheckmarks()
+	for i := start; i < end; i++ {
 		item, _ := b.abstractList.ItemByIndex(i)
-		if b.checkmarkIndexPlus1 == i+1 {
+		if item.Content == nil {
+			p.Y += b.itemHeight(context, i)
+			continue
+		}
+		if b.isItemChecked(i) {
 			mode := context.ColorMode()
-			if b.checkmarkIndexPlus1 == hoveredItemIndex+1 {
+			if i == highlightedItemIndex {
 				mode = guigui.ColorModeDark
 			}
-			img, err := theResourceImages.Get("check", mode)
+			name := "check"
+			if item.Radio {
+				name = "radio_dot"
+			}
+			img, err := theResourceImages.Get(name, mode)
 			if err != nil {
 				return err
 			}
-			b.checkmark.SetImage(img)
+			b.checkmarks[i-start].SetImage(img)
 
 			imgSize := listItemCheckmarkSize(context)
 			imgP := p
 			itemH := context.Size(item.Content).Y
 			imgP.Y += (itemH - imgSize) * 3 / 4
 			imgP.Y = b.adjustItemY(context, imgP.Y)
-			appender.AppendChildWidgetWithBounds(&b.checkmark, image.Rectangle{
+			appender.AppendChildWidgetWithBounds(&b.checkmarks[i-start], image.Rectangle{
 				Min: imgP,
 				Max: imgP.Add(image.Pt(imgSize, imgSize)),
 			})
 		}
 
 		itemP := p
-		if b.checkmarkIndexPlus1 > 0 {
+		if hasCheckmarks {
 			itemP.X += listItemCheckmarkSize(context) + listItemTextAndImagePadding(context)
 		}
 		itemP.Y = b.adjustItemY(context, itemP.Y)
 
 		appender.AppendChildWidgetWithPosition(item.Content, itemP)
-		p.Y += context.Size(item.Content).Y
+		p.Y += b.itemHeight(context, i)
 	}
 
-	if b.style != ListStyleSidebar && b.style != ListStyleMenu {
+	if b.style != ListStyleSidebar && b.style != ListStyleMenu && !b.frameHidden {
 		b.listFrame.list = b
 		appender.AppendChildWidgetWithBounds(&b.listFrame, context.Bounds(b))
 	}
@@ -158,8 +255,133 @@ func (b *baseList[T]) Build(context *guigui.Context, appender *guigui.ChildWidge
 	return nil
 }
 
-func (b *baseList[T]) hasMovableItems() bool {
+func (b *baseList[T]) jumpIfNeeded(context *guigui.Context) {
+	if idx := b.indexToJumpPlus1 - 1; idx >= 0 {
+		y := b.itemYFromIndex(context, idx) - RoundedCornerRadius(context)
+		b.scrollOverlay.SetOffset(context, b.contentSize(context), 0, float64(-y))
+		b.indexToJumpPlus1 = 0
+	}
+}
+
+// setItemProvider makes the list provide items lazily by itemByIndex.
+// estimatedItemHeight is used as the height of an item that is not measured yet.
+func (b *baseList[T]) setItemProvider(count int, itemByIndex func(index int) baseListItem[T], estimatedItemHeight int) {
+	b.abstractList.setItemProvider(count, itemByIndex)
+	b.itemHeights.resize(count, estimatedItemHeight)
+	b.cachedDefaultWidth = 0
+	b.cachedDefaultHeight = 0
+}
+
+// updateVisibleItemRange updates and returns the range of the items to build, when the items are provided lazily.
+// The range includes the visible items and some more items around them.
+func (b *baseList[T]) updateVisibleItemRange(context *guigui.Context) (int, int) {
+	count := b.abstractList.ItemCount()
+	b.scrollOverlay.SetContentSize(context, b.contentSize(context))
+	b.jumpIfNeeded(context)
+
+	_, offsetY := b.scrollOverlay.Offset()
+	top := -int(offsetY) - RoundedCornerRadius(context)
+	bottom := top + context.Size(b).Y
+
+	start := 0
+	if i := b.itemHeights.indexAt(top); i >= 0 {
+		start = i
+	} else if top > 0 {
+		start = count
+	}
+	end := count
+	if i := b.itemHeights.indexAt(bottom); i >= 0 {
+		end = i + 1
+	} else if bottom < 0 {
+		end = 0
+	}
+	b.visibleStartIndex = max(start-listOverscanItemCount, 0)
+	b.visibleEndIndex = min(end+listOverscanItemCount, count)
+	return b.visibleStartIndex, b.visibleEndIndex
+}
+
+// setItemHeight records the measured height of a lazily provided item.
+func (b *baseList[T]) setItemHeight(context *guigui.Context, index int, height int) {
+	// Keep the visible items at the same position when an item above them changes its height.
+	_, offsetY := b.scrollOverlay.Offset()
+	above := RoundedCornerRadius(context)+b.itemHeights.offset(index+1) <= -int(offsetY)
+	if diff := b.itemHeights.setHeight(index, height); diff != 0 && above {
+		b.scrollOverlay.shiftOffset(0, float64(-diff))
+	}
+}
+
+// itemRangeToBuild returns the range of the items that have their content widgets.
+func (b *baseList[T]) itemRangeToBuild() (int, int) {
+	if b.abstractList.isLazy() {
+		return b.visibleStartIndex, min(b.visibleEndIndex, b.abstractList.ItemCount())
+	}
+	return 0, b.abstractList.ItemCount()
+}
+
+func (b *baseList[T]) itemHeight(context *guigui.Context, index int) int {
+	if b.abstractList.isLazy() {
+		return b.itemHeights.height(index)
+	}
+	item, ok := b.abstractList.ItemByIndex(index)
+	if !ok {
+		return 0
+	}
+	return context.Size(item.Content).Y
+}
+
+// itemOffset returns the sum of the heights of the items before the index.
+func (b *baseList[T]) itemOffset(context *guigui.Context, index int) int {
+	if b.abstractList.isLazy() {
+		return b.itemHeights.offset(index)
+	}
+	var y int
+	for i := range min(index, b.abstractList.ItemCount()) {
+		y += b.itemHeight(context, i)
+	}
+	return y
+}
+
+// itemIndexAt returns the index of the item at y relative to the top of the first item, or -1 if there is no item.
+func (b *baseList[T]) itemIndexAt(context *guigui.Context, y int) int {
+	if b.abstractList.isLazy() {
+		return b.itemHeights.indexAt(y)
+	}
+	var cy int
 	for i := range b.abstractList.ItemCount() {
+		h := b.itemHeight(context, i)
+		if cy <= y && y < cy+h {
+			return i
+		}
+		cy += h
+	}
+	return -1
+}
+
+func (b *baseList[T]) isItemChecked(index int) bool {
+	if b.checkmarkIndexPlus1 == index+1 {
+		return true
+	}
+	item, ok := b.abstractList.ItemByIndex(index)
+	return ok && item.Checked
+}
+
+// hasCheckmarks reports whether the list reserves the space for checkmarks.
+func (b *baseList[T]) hasCheckmarks() bool {
+	if b.checkmarkIndexPlus1 > 0 {
+		return true
+	}
+	start, end := b.itemRangeToBuild()
+	for i := start; i < end; i++ {
+		if item, ok := b.abstractList.ItemByIndex(i); ok && item.Checked {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *baseList[T]) hasMovableItems() bool {
+	start, end := b.itemRangeToBuild()
+	for i := start; i < end; i++ {
 		item, ok := b.abstractList.ItemByIndex(i)
 		if !ok {
 			continue
@@ -184,22 +406,32 @@ func (b *baseList[T]) hoveredItemIndex(context *guigui.Context) int {
 		return -1
 	}
 	_, y := ebiten.CursorPosition()
-	_, offsetY := b.scrollOverlay.Offset()
-	y -= RoundedCornerRadius(context)
-	y -= context.Position(b).Y
-	y -= int(offsetY)
-	index := -1
-	var cy int
-	for i := range b.abstractList.ItemCount() {
-		item, _ := b.abstractList.ItemByIndex(i)
-		h := context.Size(item.Content).Y
-		if cy <= y && y < cy+h {
-			index = i
-			break
-		}
-		cy += h
+	return b.itemIndexAt(context, b.itemSpaceY(context, y))
+}
+
+// highlightedItemIndex returns the index of the item highlighted by the keyboard, or the hovered item index.
+func (b *baseList[T]) highlightedItemIndex(context *guigui.Context) int {
+	if b.keyboardHighlightIndexPlus1 > 0 {
+		return b.keyboardHighlightIndexPlus1 - 1
+	}
+	return b.hoveredItemIndex(context)
+}
+
+func (b *baseList[T]) setKeyboardHighlightIndex(index int) {
+	if index < 0 || index >= b.abstractList.ItemCount() {
+		index = -1
 	}
-	return index
+	if b.keyboardHighlightIndexPlus1 == index+1 {
+		return
+	}
+	b.keyboardHighlightIndexPlus1 = index + 1
+	guigui.RequestRedraw(b)
+}
+
+// itemSpaceY converts y on the screen to y relative to the top of the first item.
+func (b *baseList[T]) itemSpaceY(context *guigui.Context, y int) int {
+	_, offsetY := b.scrollOverlay.Offset()
+	return y - context.Position(b).Y - int(offsetY) - RoundedCornerRadius(context)
 }
 
 func (b *baseList[T]) SetItems(items []baseListItem[T]) {
@@ -214,14 +446,75 @@ func (b *baseList[T]) SelectItemByIndex(index int) {
 
 func (b *baseList[T]) selectItemByIndex(index int, forceFireEvents bool) {
 	if b.abstractList.SelectItemByIndex(index, forceFireEvents) {
-		guigui.RequestRedraw(b)
+		b.selectionChanged()
 	}
 }
 
 func (b *baseList[T]) SelectItemByID(id T) {
 	if b.abstractList.SelectItemByID(id, false) {
-		guigui.RequestRedraw(b)
+		b.selectionChanged()
+	}
+}
+
+func (b *baseList[T]) SetSelectionMode(mode ListSelectionMode) {
+	if b.abstractList.SetSelectionMode(mode) {
+		b.selectionChanged()
+	}
+}
+
+func (b *baseList[T]) SetOnSelectionChanged(f func(indices []int)) {
+	b.abstractList.SetOnSelectionChanged(f)
+}
+
+func (b *baseList[T]) SelectItemsByIndices(indices []int) {
+	if b.abstractList.SelectItemsByIndices(indices) {
+		b.selectionChanged()
+	}
+}
+
+func (b *baseList[T]) IsItemSelected(index int) bool {
+	return b.abstractList.IsItemSelected(index)
+}
+
+func (b *baseList[T]) AppendSelectedItemIndices(indices []int) []int {
+	return b.abstractList.AppendSelectedItemIndices(indices)
+}
+
+// SelectAll selects all the selectable items in the multiple selection mode.
+func (b *baseList[T]) SelectAll() {
+	if b.abstractList.selectionMode != ListSelectionModeMultiple {
+		return
 	}
+	b.tmpIndices = b.appendSelectableIndices(b.tmpIndices[:0], 0, b.abstractList.ItemCount()-1)
+	b.SelectItemsByIndices(b.tmpIndices)
+}
+
+// appendSelectableIndices appends the indices of the selectable items between from and to inclusive.
+func (b *baseList[T]) appendSelectableIndices(indices []int, from, to int) []int {
+	if from > to {
+		from, to = to, from
+	}
+	for i := from; i <= to; i++ {
+		if item, ok := b.abstractList.ItemByIndex(i); ok && item.Selectable {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func (b *baseList[T]) selectionChanged() {
+	guigui.RequestRedraw(b)
+	if b.selectedItemIndexBinding.isBound() {
+		b.selectedItemIndexBinding.push(b.abstractList.SelectedItemIndex())
+	}
+}
+
+// HandleScrollIntoView implements guigui.ScrollIntoViewHandler.
+func (b *baseList[T]) HandleScrollIntoView(context *guigui.Context, bounds image.Rectangle, alignment guigui.ScrollAlignment, animated bool) image.Point {
+	viewport := context.Bounds(b).Inset(RoundedCornerRadius(context))
+	viewport.Min.X = context.Bounds(b).Min.X
+	viewport.Max.X = context.Bounds(b).Max.X
+	return b.scrollOverlay.scrollIntoView(context, viewport, bounds, alignment, animated)
 }
 
 func (b *baseList[T]) JumpToItemIndex(index int) {
@@ -257,6 +550,20 @@ func (b *baseList[T]) SetStyle(style ListStyle) {
 
 func (b *baseList[T]) calcDropDstIndex(context *guigui.Context) int {
 	_, y := ebiten.CursorPosition()
+	if b.abstractList.isLazy() {
+		_, offsetY := b.scrollOverlay.Offset()
+		i := b.itemIndexAt(context, y-context.Position(b).Y-int(offsetY)-RoundedCornerRadius(context))
+		if i < 0 {
+			if y < b.itemBounds(context, 0, true).Min.Y {
+				return 0
+			}
+			return b.abstractList.ItemCount()
+		}
+		if bounds := b.itemBounds(context, i, true); y < (bounds.Min.Y+bounds.Max.Y)/2 {
+			return i
+		}
+		return i + 1
+	}
 	for i := range b.abstractList.ItemCount() {
 		if b := b.itemBounds(context, i, true); y < (b.Min.Y+b.Max.Y)/2 {
 			return i
@@ -265,28 +572,63 @@ func (b *baseList[T]) calcDropDstIndex(context *guigui.Context) int {
 	return b.abstractList.ItemCount()
 }
 
+// handleTap selects the tapped item, and activates it by a double-tap.
+// handleTap reports whether a tap is handled.
+func (b *baseList[T]) handleTap(context *guigui.Context) bool {
+	position, doubleTap, ok := b.tap.handleTouchInput(context, b)
+	if !ok {
+		return false
+	}
+	index := b.itemIndexAt(context, b.itemSpaceY(context, position.Y))
+	item, ok := b.abstractList.ItemByIndex(index)
+	if !ok {
+		return false
+	}
+	if !item.Selectable {
+		return true
+	}
+	if item.Content != nil {
+		context.SetFocused(item.Content, true)
+	} else {
+		context.SetFocused(b, true)
+	}
+	if b.abstractList.selectionMode != ListSelectionModeNone {
+		b.selectItemByIndex(index, true)
+		b.lastSelectingItemTick = ebiten.Tick()
+	}
+	b.anchorIndexPlus1 = index + 1
+	b.leadIndexPlus1 = index + 1
+	if doubleTap && b.onItemActivated != nil {
+		b.onItemActivated(index)
+	}
+	return true
+}
+
 func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
 	if b.isHoveringVisible() || b.hasMovableItems() {
 		if hoveredItemIndex := b.hoveredItemIndex(context); b.lastHoverredItemIndexPlus1 != hoveredItemIndex+1 {
 			b.lastHoverredItemIndexPlus1 = hoveredItemIndex + 1
+			b.keyboardHighlightIndexPlus1 = 0
 			guigui.RequestRedraw(b)
 		}
 	}
 
+	// Process rubber-band selection.
+	if b.rubberBanding {
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			b.scrollByDragging(context)
+			b.updateRubberBand(context)
+			return guigui.HandleInputByWidget(b)
+		}
+		b.rubberBanding = false
+		guigui.RequestRedraw(b)
+		return guigui.HandleInputByWidget(b)
+	}
+
 	// Process dragging.
 	if b.dragSrcIndexPlus1 > 0 {
 		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-			_, y := ebiten.CursorPosition()
-			p := context.Position(b)
-			h := context.Size(b).Y
-			var dy float64
-			if upperY := p.Y + UnitSize(context); y < upperY {
-				dy = float64(upperY-y) / 4
-			}
-			if lowerY := p.Y + h - UnitSize(context); y >= lowerY {
-				dy = float64(lowerY-y) / 4
-			}
-			b.scrollOverlay.SetOffsetByDelta(context, b.contentSize(context), 0, dy)
+			b.scrollByDragging(context)
 			if i := b.calcDropDstIndex(context); b.dragDstIndexPlus1-1 != i {
 				b.dragDstIndexPlus1 = i + 1
 				guigui.RequestRedraw(b)
@@ -294,10 +636,7 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 			return guigui.HandleInputByWidget(b)
 		}
 		if b.dragDstIndexPlus1 > 0 {
-			if b.onItemsMoved != nil {
-				// TODO: Implement multiple items drop.
-				b.onItemsMoved(b.dragSrcIndexPlus1-1, 1, b.dragDstIndexPlus1-1)
-			}
+			b.moveDraggedItems(context, b.dragDstIndexPlus1-1)
 			b.dragDstIndexPlus1 = 0
 		}
 		b.dragSrcIndexPlus1 = 0
@@ -305,6 +644,10 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 		return guigui.HandleInputByWidget(b)
 	}
 
+	if b.handleTap(context) {
+		return guigui.HandleInputByWidget(b)
+	}
+
 	index := b.hoveredItemIndex(context)
 	if index >= 0 && index < b.abstractList.ItemCount() {
 		x, y := ebiten.CursorPosition()
@@ -319,17 +662,62 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 			}
 
 			wasFocused := context.IsFocusedOrHasFocusedChild(b)
-			if item, ok := b.abstractList.ItemByIndex(index); ok {
+			if item, ok := b.abstractList.ItemByIndex(index); ok && item.Content != nil {
 				context.SetFocused(item.Content, true)
 			} else {
 				context.SetFocused(b, true)
 			}
-			if b.SelectedItemIndex() != index || !wasFocused || b.style == ListStyleMenu {
-				b.selectItemByIndex(index, true)
-				b.lastSelectingItemTime = time.Now()
+			multiple := b.abstractList.selectionMode == ListSelectionModeMultiple
+			b.collapseSelectionOnRelease = false
+			switch {
+			case b.abstractList.selectionMode == ListSelectionModeNone:
+			case multiple && left && ebiten.IsKeyPressed(ebiten.KeyShift):
+				anchor := b.anchorIndexPlus1 - 1
+				if anchor < 0 || anchor >= b.abstractList.ItemCount() {
+					anchor = index
+					b.anchorIndexPlus1 = index + 1
+				}
+				b.tmpIndices = b.tmpIndices[:0]
+				if isCommandKeyPressed() {
+					b.tmpIndices = b.abstractList.AppendSelectedItemIndices(b.tmpIndices)
+				}
+				b.tmpIndices = b.appendSelectableIndices(b.tmpIndices, anchor, index)
+				b.SelectItemsByIndices(b.tmpIndices)
+				b.leadIndexPlus1 = index + 1
+			case multiple && left && isCommandKeyPressed():
+				b.tmpIndices = b.abstractList.AppendSelectedItemIndices(b.tmpIndices[:0])
+				if i, ok := slices.BinarySearch(b.tmpIndices, index); ok {
+					b.tmpIndices = slices.Delete(b.tmpIndices, i, i+1)
+				} else {
+					b.tmpIndices = slices.Insert(b.tmpIndices, i, index)
+				}
+				b.SelectItemsByIndices(b.tmpIndices)
+				b.anchorIndexPlus1 = index + 1
+				b.leadIndexPlus1 = index + 1
+			case multiple && b.IsItemSelected(index) && len(b.abstractList.selectedIndices) > 1:
+				// Keep the selection so that the selected items can be dragged together.
+				b.collapseSelectionOnRelease = left
+				b.anchorIndexPlus1 = index + 1
+				b.leadIndexPlus1 = index + 1
+			default:
+				if b.SelectedItemIndex() != index || !wasFocused || b.style == ListStyleMenu {
+					b.selectItemByIndex(index, true)
+					b.lastSelectingItemTick = ebiten.Tick()
+				}
+				b.anchorIndexPlus1 = index + 1
+				b.leadIndexPlus1 = index + 1
 			}
 			b.pressStartX = x
 			b.pressStartY = y
+			if left && b.onItemActivated != nil {
+				if b.lastClickIndexPlus1 == index+1 && ebiten.Tick()-b.lastClickTick < int64(doubleClickLimitInTicks()) {
+					b.lastClickIndexPlus1 = 0
+					b.onItemActivated(index)
+				} else {
+					b.lastClickIndexPlus1 = index + 1
+					b.lastClickTick = ebiten.Tick()
+				}
+			}
 			if right {
 				/*if l.callback != nil && l.callback.OnContextMenu != nil {
 					x, y := ebiten.CursorPosition()
@@ -341,12 +729,23 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 
 		case ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft):
 			item, _ := b.abstractList.ItemByIndex(index)
-			if item.Movable && b.SelectedItemIndex() == index && b.startPressingIndexPlus1-1 == index && (b.pressStartX != x || b.pressStartY != y) {
+			if item.Movable && b.IsItemSelected(index) && b.startPressingIndexPlus1-1 == index && (b.pressStartX != x || b.pressStartY != y) {
 				b.dragSrcIndexPlus1 = index + 1
+				b.collapseSelectionOnRelease = false
+			} else if !item.Movable && b.abstractList.selectionMode == ListSelectionModeMultiple && b.startPressingIndexPlus1 > 0 && b.startPressingLeft &&
+				max(abs(b.pressStartX-x), abs(b.pressStartY-y)) >= UnitSize(context)/4 {
+				b.startRubberBand(context, b.pressStartX, b.pressStartY, true)
+				b.startPressingIndexPlus1 = 0
+				b.collapseSelectionOnRelease = false
 			}
 
 		case inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
-			if b.SelectedItemIndex() == index && b.startPressingLeft && time.Since(b.lastSelectingItemTime) > 400*time.Millisecond {
+			if b.collapseSelectionOnRelease {
+				b.collapseSelectionOnRelease = false
+				b.selectItemByIndex(index, true)
+				b.lastSelectingItemTick = ebiten.Tick()
+			}
+			if b.SelectedItemIndex() == index && b.startPressingLeft && ebiten.Tick()-b.lastSelectingItemTick > int64(ebiten.TPS()*2/5) {
 				/*if l.callback != nil && l.callback.OnItemEditStarted != nil {
 					l.callback.OnItemEditStarted(index)
 				}*/
@@ -364,18 +763,361 @@ func (b *baseList[T]) HandlePointingInput(context *guigui.Context) guigui.Handle
 	b.pressStartX = 0
 	b.pressStartY = 0
 
+	// Start rubber-band selection from an empty space.
+	if b.abstractList.selectionMode == ListSelectionModeMultiple && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && context.IsWidgetHitAtCursor(b) {
+		context.SetFocused(b, true)
+		x, y := ebiten.CursorPosition()
+		b.startRubberBand(context, x, y, isCommandKeyPressed() || ebiten.IsKeyPressed(ebiten.KeyShift))
+		return guigui.HandleInputByWidget(b)
+	}
+
 	return guigui.HandleInputResult{}
 }
 
-func (b *baseList[T]) itemYFromIndex(context *guigui.Context, index int) int {
-	y := RoundedCornerRadius(context)
-	for i := range b.abstractList.ItemCount() {
-		if i == index {
-			break
+func (b *baseList[T]) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if b.abstractList.selectionMode == ListSelectionModeMultiple && isCommandKeyPressed() && inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		b.SelectAll()
+		return guigui.HandleInputByWidget(b)
+	}
+	return b.handleKeyboardInput(context)
+}
+
+func listTypeAheadTimeoutInTicks() int {
+	return ebiten.TPS()
+}
+
+// currentItemIndex returns the index of the item that the keyboard operates on.
+func (b *baseList[T]) currentItemIndex(context *guigui.Context) int {
+	if b.style == ListStyleMenu {
+		return b.highlightedItemIndex(context)
+	}
+	if lead := b.leadIndexPlus1 - 1; lead >= 0 && lead < b.abstractList.ItemCount() && (b.IsItemSelected(lead) || b.abstractList.selectionMode == ListSelectionModeNone) {
+		return lead
+	}
+	return b.SelectedItemIndex()
+}
+
+// handleKeyboardInput moves the current item by arrow keys, Home, End, Page Up, Page Down and type-ahead search,
+// and selects the current item by Enter.
+func (b *baseList[T]) handleKeyboardInput(context *guigui.Context) guigui.HandleInputResult {
+	count := b.abstractList.ItemCount()
+	if count == 0 {
+		return guigui.HandleInputResult{}
+	}
+
+	current := b.currentItemIndex(context)
+	if current >= count {
+		current = -1
+	}
+	// Menus wrap around like native menus, while lists let the directional focus navigation go out of them.
+	wrap := b.style == ListStyleMenu
+
+	next := -1
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		if item, ok := b.abstractList.ItemByIndex(current); ok && item.Selectable {
+			if b.onItemActivated != nil && b.style != ListStyleMenu {
+				if !b.IsItemSelected(current) {
+					b.selectItemByIndex(current, true)
+				}
+				b.onItemActivated(current)
+				return guigui.HandleInputByWidget(b)
+			}
+			b.selectItemByIndex(current, true)
+			return guigui.HandleInputByWidget(b)
 		}
-		item, _ := b.abstractList.ItemByIndex(i)
-		y += context.Size(item.Content).Y
+		return guigui.HandleInputResult{}
+	case isKeyRepeating(ebiten.KeyDown):
+		next = b.nextSelectableIndex(current, 1, wrap)
+	case isKeyRepeating(ebiten.KeyUp):
+		if current < 0 {
+			current = count
+		}
+		next = b.nextSelectableIndex(current, -1, wrap)
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		next = b.nextSelectableIndex(-1, 1, false)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		next = b.nextSelectableIndex(count, -1, false)
+	case isKeyRepeating(ebiten.KeyPageDown):
+		next = b.pageItemIndex(context, current, 1)
+	case isKeyRepeating(ebiten.KeyPageUp):
+		next = b.pageItemIndex(context, current, -1)
+	default:
+		index, ok := b.typeAheadItemIndex(current)
+		if !ok {
+			return guigui.HandleInputResult{}
+		}
+		if index >= 0 && index != current {
+			b.moveCurrentItemIndex(context, index, false)
+		}
+		return guigui.HandleInputByWidget(b)
+	}
+
+	if next < 0 || next == current {
+		return guigui.HandleInputResult{}
+	}
+	b.moveCurrentItemIndex(context, next, ebiten.IsKeyPressed(ebiten.KeyShift))
+	return guigui.HandleInputByWidget(b)
+}
+
+// nextSelectableIndex returns the index of the next selectable item from the index in the direction dir, or -1 if not found.
+// Header, disabled and border items are not selectable.
+func (b *baseList[T]) nextSelectableIndex(from int, dir int, wrap bool) int {
+	count := b.abstractList.ItemCount()
+	i := from
+	for range count {
+		i += dir
+		if i < 0 || i >= count {
+			if !wrap {
+				return -1
+			}
+			i = (i + count) % count
+		}
+		if item, ok := b.abstractList.ItemByIndex(i); ok && item.Selectable {
+			return i
+		}
+	}
+	return -1
+}
+
+// pageItemIndex returns the index of the selectable item about one page away from the index in the direction dir.
+func (b *baseList[T]) pageItemIndex(context *guigui.Context, from int, dir int) int {
+	page := max(context.Size(b).Y-2*RoundedCornerRadius(context), 1)
+	return b.pageItemIndexWithPageHeight(context, from, dir, page)
+}
+
+func (b *baseList[T]) pageItemIndexWithPageHeight(context *guigui.Context, from int, dir int, page int) int {
+	count := b.abstractList.ItemCount()
+	from = max(from, 0)
+	total := b.itemOffset(context, count)
+	y := min(max(b.itemOffset(context, from)+dir*page, 0), total-1)
+	index := b.itemIndexAt(context, y)
+	if index < 0 {
+		return -1
+	}
+	if i := b.nextSelectableIndex(index-dir, dir, false); i >= 0 {
+		return i
+	}
+	return b.nextSelectableIndex(index, -dir, false)
+}
+
+// typeAheadItemIndex consumes the input characters, and returns the index of the item whose text starts with the typed text.
+// typeAheadItemIndex returns -1 if no item matches, and returns false if no character is consumed.
+func (b *baseList[T]) typeAheadItemIndex(current int) (int, bool) {
+	if isCommandKeyPressed() {
+		return -1, false
+	}
+	b.tmpInputChars = ebiten.AppendInputChars(b.tmpInputChars[:0])
+	return b.typeAheadItemIndexWithInput(current, b.tmpInputChars, ebiten.Tick())
+}
+
+// typeAheadItemIndexWithInput is typeAheadItemIndex with the input characters at the tick.
+func (b *baseList[T]) typeAheadItemIndexWithInput(current int, chars []rune, tick int64) (int, bool) {
+	if len(chars) == 0 {
+		return -1, false
+	}
+
+	if tick-b.typeAheadLastTick > int64(listTypeAheadTimeoutInTicks()) {
+		b.typeAheadText = ""
+	}
+	text := b.typeAheadText
+	for _, r := range chars {
+		// A space at the beginning is left for other usages like activating.
+		if text == "" && unicode.IsSpace(r) {
+			continue
+		}
+		text += string(r)
+	}
+	if text == "" {
+		return -1, false
+	}
+	b.typeAheadText = text
+	b.typeAheadLastTick = tick
+
+	// Typing the same character repeatedly cycles the items starting with the character.
+	start := current
+	if r, _ := utf8.DecodeRuneInString(text); strings.Count(text, string(r)) == utf8.RuneCountInString(text) {
+		text = string(r)
+		start++
+	}
+	start = max(start, 0)
+	text = strings.ToLower(text)
+	count := b.abstractList.ItemCount()
+	for k := range count {
+		i := (start + k) % count
+		item, ok := b.abstractList.ItemByIndex(i)
+		if !ok || !item.Selectable {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(item.Text), text) {
+			return i, true
+		}
+	}
+	return -1, true
+}
+
+// moveCurrentItemIndex makes the item at the index current.
+// In the menu style, the item is highlighted. Otherwise, the item is selected.
+// If extend is true in the multiple selection mode, the items from the anchor to the index are selected.
+func (b *baseList[T]) moveCurrentItemIndex(context *guigui.Context, index int, extend bool) {
+	switch {
+	case b.style == ListStyleMenu:
+		b.setKeyboardHighlightIndex(index)
+	case b.abstractList.selectionMode == ListSelectionModeMultiple && extend:
+		anchor := b.anchorIndexPlus1 - 1
+		if anchor < 0 || anchor >= b.abstractList.ItemCount() {
+			anchor = max(b.currentItemIndex(context), 0)
+			b.anchorIndexPlus1 = anchor + 1
+		}
+		b.tmpIndices = b.appendSelectableIndices(b.tmpIndices[:0], anchor, index)
+		b.SelectItemsByIndices(b.tmpIndices)
+	default:
+		b.selectItemByIndex(index, false)
+		b.anchorIndexPlus1 = index + 1
+	}
+	b.leadIndexPlus1 = index + 1
+	b.HandleScrollIntoView(context, b.itemBounds(context, index, true), guigui.ScrollAlignmentNearest, false)
+}
+
+// scrollByDragging scrolls the list when the cursor is around the top or bottom edge while dragging.
+func (b *baseList[T]) scrollByDragging(context *guigui.Context) {
+	_, y := ebiten.CursorPosition()
+	p := context.Position(b)
+	h := context.Size(b).Y
+	var dy float64
+	if upperY := p.Y + UnitSize(context); y < upperY {
+		dy = float64(upperY-y) / 4
+	}
+	if lowerY := p.Y + h - UnitSize(context); y >= lowerY {
+		dy = float64(lowerY-y) / 4
+	}
+	b.scrollOverlay.SetOffsetByDelta(context, b.contentSize(context), 0, dy)
+}
+
+// startRubberBand starts rubber-band selection at the position on the screen.
+// If keepSelection is true, the current selection is kept and the items in the rubber band are added to it.
+func (b *baseList[T]) startRubberBand(context *guigui.Context, x, y int, keepSelection bool) {
+	b.rubberBanding = true
+	b.rubberBandStartX = x - context.Position(b).X
+	b.rubberBandStartY = b.itemSpaceY(context, y)
+	b.rubberBandBaseIndices = b.rubberBandBaseIndices[:0]
+	if keepSelection {
+		b.rubberBandBaseIndices = b.abstractList.AppendSelectedItemIndices(b.rubberBandBaseIndices)
+	}
+	b.updateRubberBand(context)
+}
+
+func (b *baseList[T]) updateRubberBand(context *guigui.Context) {
+	x, y := ebiten.CursorPosition()
+	b.rubberBandEndX = x - context.Position(b).X
+	b.rubberBandEndY = b.itemSpaceY(context, y)
+	guigui.RequestRedraw(b)
+
+	b.tmpIndices = append(b.tmpIndices[:0], b.rubberBandBaseIndices...)
+	y0 := min(b.rubberBandStartY, b.rubberBandEndY)
+	y1 := max(b.rubberBandStartY, b.rubberBandEndY)
+	if total := b.itemOffset(context, b.abstractList.ItemCount()); y1 >= 0 && y0 < total {
+		i0 := b.itemIndexAt(context, max(y0, 0))
+		i1 := b.itemIndexAt(context, min(y1, total-1))
+		b.tmpIndices = b.appendSelectableIndices(b.tmpIndices, i0, i1)
+	}
+	b.SelectItemsByIndices(b.tmpIndices)
+}
+
+// moveDraggedItems moves the dragged items to the index by onItemsMoved.
+// When the dragged item is selected, all the selected movable items are moved together.
+func (b *baseList[T]) moveDraggedItems(context *guigui.Context, to int) {
+	src := b.dragSrcIndexPlus1 - 1
+	b.tmpIndices = b.tmpIndices[:0]
+	if b.IsItemSelected(src) {
+		for _, i := range b.abstractList.selectedIndices {
+			if item, ok := b.abstractList.ItemByIndex(i); ok && item.Movable {
+				b.tmpIndices = append(b.tmpIndices, i)
+			}
+		}
+	} else {
+		b.tmpIndices = append(b.tmpIndices, src)
+	}
+	if b.onItemsDropped != nil {
+		b.onItemsDropped(context, b.tmpIndices, to)
+		return
+	}
+	if b.onItemsMoved == nil {
+		return
+	}
+
+	n := len(b.tmpIndices)
+	start := moveItemRuns(b.tmpIndices, to, b.onItemsMoved)
+	if n > 1 {
+		// Keep the moved items selected.
+		b.tmpIndices = b.tmpIndices[:0]
+		for i := range n {
+			b.tmpIndices = append(b.tmpIndices, start+i)
+		}
+		b.SelectItemsByIndices(b.tmpIndices)
+	}
+}
+
+// moveItemRuns moves the items at the sorted indices to the position to, by calling move for each contiguous run of the indices.
+// The moved items are placed together in the same order.
+// moveItemRuns returns the new index of the first moved item.
+func moveItemRuns(indices []int, to int, move func(from, count, to int)) int {
+	if len(indices) == 0 {
+		return to
+	}
+
+	type run struct {
+		from  int
+		count int
+	}
+	var runs []run
+	for i := 0; i < len(indices); {
+		j := i + 1
+		for j < len(indices) && indices[j] == indices[j-1]+1 {
+			j++
+		}
+		runs = append(runs, run{from: indices[i], count: j - i})
+		i = j
 	}
+
+	// If a run contains the destination, the run stays and the other runs are gathered around it.
+	lo, hi := to, to
+	for _, r := range runs {
+		if r.from < to && to < r.from+r.count {
+			lo, hi = r.from, r.from+r.count
+		}
+	}
+
+	// Move the runs before the destination, from the nearest one.
+	start := lo
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		if r.from+r.count > lo {
+			continue
+		}
+		if r.from+r.count != start {
+			move(r.from, r.count, start)
+		}
+		start -= r.count
+	}
+
+	// Move the runs after the destination, from the nearest one.
+	end := hi
+	for _, r := range runs {
+		if r.from < hi {
+			continue
+		}
+		if r.from != end {
+			move(r.from, r.count, end)
+		}
+		end += r.count
+	}
+
+	return start
+}
+
+func (b *baseList[T]) itemYFromIndex(context *guigui.Context, index int) int {
+	y := RoundedCornerRadius(context) + b.itemOffset(context, index)
 	y = b.adjustItemY(context, y)
 	return y
 }
@@ -401,14 +1143,14 @@ func (b *baseList[T]) itemBounds(context *guigui.Context, index int, fullWidth b
 	}
 	bounds.Min.Y += b.itemYFromIndex(context, index)
 	bounds.Min.Y += int(offsetY)
-	if item, ok := b.abstractList.ItemByIndex(index); ok {
-		bounds.Max.Y = bounds.Min.Y + context.Size(item.Content).Y
+	if index >= 0 && index < b.abstractList.ItemCount() {
+		bounds.Max.Y = bounds.Min.Y + b.itemHeight(context, index)
 	}
 	return bounds
 }
 
 func (b *baseList[T]) selectedItemColor(context *guigui.Context) color.Color {
-	if b.SelectedItemIndex() < 0 || b.SelectedItemIndex() >= b.abstractList.ItemCount() {
+	if len(b.abstractList.selectedIndices) == 0 {
 		return nil
 	}
 	if b.style == ListStyleMenu {
@@ -451,8 +1193,8 @@ func (b *baseList[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
 
 	if b.stripeVisible && b.abstractList.ItemCount() > 0 {
 		// Draw item stripes.
-		// TODO: Get indices of items that are visible.
-		for i := range b.abstractList.ItemCount() {
+		start, end := b.itemRangeToBuild()
+		for i := start; i < end; i++ {
 			if i%2 == 0 {
 				continue
 			}
@@ -485,10 +1227,21 @@ func (b *baseList[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
 		}
 	}
 
-	// Draw the selected item background.
-	if clr := b.selectedItemColor(context); clr != nil && b.SelectedItemIndex() >= 0 && b.SelectedItemIndex() < b.abstractList.ItemCount() {
-		bounds := b.itemBounds(context, b.SelectedItemIndex(), b.stripeVisible)
-		if bounds.Overlaps(vb) {
+	// Draw the selected item backgrounds.
+	if clr := b.selectedItemColor(context); clr != nil {
+		start, end := b.itemRangeToBuild()
+		i0, _ := slices.BinarySearch(b.abstractList.selectedIndices, start)
+		for _, index := range b.abstractList.selectedIndices[i0:] {
+			if index >= end {
+				break
+			}
+			bounds := b.itemBounds(context, index, b.stripeVisible)
+			if bounds.Min.Y > vb.Max.Y {
+				break
+			}
+			if !bounds.Overlaps(vb) {
+				continue
+			}
 			if b.stripeVisible {
 				r := RoundedCornerRadius(context)
 				if !draw.OverlapsWithRoundedCorner(context.Bounds(b), r, bounds) {
@@ -505,9 +1258,10 @@ func (b *baseList[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
 	}
 
 	hoveredItemIndex := b.hoveredItemIndex(context)
-	hoveredItem, ok := b.abstractList.ItemByIndex(hoveredItemIndex)
-	if ok && b.isHoveringVisible() && hoveredItemIndex >= 0 && hoveredItemIndex < b.abstractList.ItemCount() && hoveredItem.Selectable {
-		bounds := b.itemBounds(context, hoveredItemIndex, false)
+	highlightedItemIndex := b.highlightedItemIndex(context)
+	highlightedItem, ok := b.abstractList.ItemByIndex(highlightedItemIndex)
+	if ok && b.isHoveringVisible() && highlightedItem.Selectable {
+		bounds := b.itemBounds(context, highlightedItemIndex, false)
 		bounds.Min.X -= RoundedCornerRadius(context)
 		bounds.Max.X += RoundedCornerRadius(context)
 		if bounds.Overlaps(vb) {
@@ -545,12 +1299,27 @@ func (b *baseList[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
 			x0 += float32(listItemPadding(context))
 			x1 -= float32(listItemPadding(context))
 		}
+		if b.dropGuidelineIndent != nil {
+			x0 = float32(p.X + b.dropGuidelineIndent(context, b.dragDstIndexPlus1-1))
+		}
 		y := float32(p.Y)
 		y += float32(b.itemYFromIndex(context, b.dragDstIndexPlus1-1))
 		_, offsetY := b.scrollOverlay.Offset()
 		y += float32(offsetY)
 		vector.StrokeLine(dst, x0, y, x1, y, 2*float32(context.Scale()), draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5), false)
 	}
+
+	// Draw a rubber band.
+	if b.rubberBanding {
+		p := context.Position(b)
+		_, offsetY := b.scrollOverlay.Offset()
+		p.Y += int(offsetY) + RoundedCornerRadius(context)
+		bounds := image.Rect(b.rubberBandStartX, b.rubberBandStartY, b.rubberBandEndX, b.rubberBandEndY).Add(p).Intersect(context.Bounds(b))
+		clr := draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5)
+		x, y, w, h := float32(bounds.Min.X), float32(bounds.Min.Y), float32(bounds.Dx()), float32(bounds.Dy())
+		vector.DrawFilledRect(dst, x, y, w, h, draw.ScaleAlpha(clr, 0.2), false)
+		vector.StrokeRect(dst, x, y, w, h, float32(context.Scale()), clr, false)
+	}
 }
 
 func (b *baseList[T]) defaultWidth(context *guigui.Context) int {
@@ -558,26 +1327,34 @@ func (b *baseList[T]) defaultWidth(context *guigui.Context) int {
 		return b.cachedDefaultWidth
 	}
 	var w int
-	for i := range b.abstractList.ItemCount() {
+	start, end := b.itemRangeToBuild()
+	for i := start; i < end; i++ {
 		item, _ := b.abstractList.ItemByIndex(i)
-		w = max(w, context.Size(item.Content).X)
+		if item.Content == nil {
+			continue
+		}
+		// Use the default size, as the item might be widened to the list.
+		w = max(w, item.Content.DefaultSize(context).X)
 	}
 	w += 2*RoundedCornerRadius(context) + 2*listItemPadding(context)
-	b.cachedDefaultWidth = w
+	// The width of lazily provided items depends on the visible items.
+	if !b.abstractList.isLazy() {
+		b.cachedDefaultWidth = w
+	}
 	return w
 }
 
 func (b *baseList[T]) defaultHeight(context *guigui.Context) int {
+	if b.abstractList.isLazy() {
+		return b.itemHeights.total() + 2*RoundedCornerRadius(context)
+	}
 	if b.cachedDefaultHeight > 0 {
 		return b.cachedDefaultHeight
 	}
 
 	var h int
 	h += RoundedCornerRadius(context)
-	for i := range b.abstractList.ItemCount() {
-		item, _ := b.abstractList.ItemByIndex(i)
-		h += context.Size(item.Content).Y
-	}
+	h += b.itemOffset(context, b.abstractList.ItemCount())
 	h += RoundedCornerRadius(context)
 	b.cachedDefaultHeight = h
 	return h
@@ -585,7 +1362,7 @@ func (b *baseList[T]) defaultHeight(context *guigui.Context) int {
 
 func (b *baseList[T]) DefaultSize(context *guigui.Context) image.Point {
 	w := b.defaultWidth(context)
-	if b.checkmarkIndexPlus1 > 0 {
+	if b.hasCheckmarks() {
 		w += listItemCheckmarkSize(context) + listItemTextAndImagePadding(context)
 	}
 	h := b.defaultHeight(context)
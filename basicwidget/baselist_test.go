@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMoveItemRuns(t *testing.T) {
+	testCases := []struct {
+		indices   []int
+		to        int
+		want      []int
+		wantStart int
+	}{
+		{indices: []int{2}, to: 0, want: []int{2, 0, 1, 3, 4, 5, 6, 7}, wantStart: 0},
+		{indices: []int{2}, to: 5, want: []int{0, 1, 3, 4, 2, 5, 6, 7}, wantStart: 4},
+		{indices: []int{2}, to: 3, want: []int{0, 1, 2, 3, 4, 5, 6, 7}, wantStart: 2},
+		{indices: []int{2, 3}, to: 8, want: []int{0, 1, 4, 5, 6, 7, 2, 3}, wantStart: 6},
+		{indices: []int{1, 4, 6}, to: 0, want: []int{1, 4, 6, 0, 2, 3, 5, 7}, wantStart: 0},
+		{indices: []int{1, 4, 6}, to: 8, want: []int{0, 2, 3, 5, 7, 1, 4, 6}, wantStart: 5},
+		{indices: []int{1, 4, 6}, to: 3, want: []int{0, 2, 1, 4, 6, 3, 5, 7}, wantStart: 2},
+		{indices: []int{1, 2, 5, 6}, to: 6, want: []int{0, 3, 4, 1, 2, 5, 6, 7}, wantStart: 3},
+		{indices: []int{0, 3, 4, 5, 7}, to: 4, want: []int{1, 2, 0, 3, 4, 5, 7, 6}, wantStart: 2},
+	}
+	for _, tc := range testCases {
+		items := []int{0, 1, 2, 3, 4, 5, 6, 7}
+		gotStart := moveItemRuns(tc.indices, tc.to, func(from, count, to int) {
+			MoveItemsInSlice(items, from, count, to)
+		})
+		if !slices.Equal(items, tc.want) || gotStart != tc.wantStart {
+			t.Errorf("moveItemRuns(%v, %d): got: %v (start: %d), want: %v (start: %d)", tc.indices, tc.to, items, gotStart, tc.want, tc.wantStart)
+		}
+	}
+}
+
+// testFruitItems are the items for the keyboard navigation tests. The header and the border are not selectable.
+var testFruitItems = []baseListItem[int]{
+	{Text: "Header"},
+	{Text: "Apple", Selectable: true},
+	{Text: "Avocado", Selectable: true},
+	{Text: "Banana", Selectable: true},
+	{},
+	{Text: "Blueberry", Selectable: true},
+	{Text: "Cherry", Selectable: true},
+	{Text: "Apricot", Selectable: true},
+}
+
+func TestBaseListNextSelectableIndex(t *testing.T) {
+	var l baseList[int]
+	l.setItemProvider(len(testFruitItems), func(index int) baseListItem[int] {
+		return testFruitItems[index]
+	}, 10)
+	testCases := []struct {
+		from int
+		dir  int
+		wrap bool
+		want int
+	}{
+		{from: -1, dir: 1, wrap: false, want: 1},
+		{from: 8, dir: -1, wrap: false, want: 7},
+		{from: 3, dir: 1, wrap: false, want: 5},
+		{from: 5, dir: -1, wrap: false, want: 3},
+		{from: 1, dir: -1, wrap: false, want: -1},
+		{from: 1, dir: -1, wrap: true, want: 7},
+		{from: 7, dir: 1, wrap: false, want: -1},
+		{from: 7, dir: 1, wrap: true, want: 1},
+	}
+	for _, tc := range testCases {
+		if got := l.nextSelectableIndex(tc.from, tc.dir, tc.wrap); got != tc.want {
+			t.Errorf("nextSelectableIndex(%d, %d, %t): got: %d, want: %d", tc.from, tc.dir, tc.wrap, got, tc.want)
+		}
+	}
+
+	var empty baseList[int]
+	empty.setItemProvider(2, func(index int) baseListItem[int] {
+		return baseListItem[int]{Text: "A"}
+	}, 10)
+	if got := empty.nextSelectableIndex(0, 1, true); got != -1 {
+		t.Errorf("nextSelectableIndex without selectable items: got: %d, want: -1", got)
+	}
+}
+
+func TestBaseListPageItemIndex(t *testing.T) {
+	// Each item is 10 pixels high.
+	var l baseList[int]
+	l.setItemProvider(len(testFruitItems), func(index int) baseListItem[int] {
+		return testFruitItems[index]
+	}, 10)
+	testCases := []struct {
+		from int
+		dir  int
+		page int
+		want int
+	}{
+		// The border item at the destination is skipped forward.
+		{from: 1, dir: 1, page: 30, want: 5},
+		{from: 5, dir: -1, page: 30, want: 2},
+		// The header item at the top is skipped backward.
+		{from: 1, dir: -1, page: 30, want: 1},
+		{from: 3, dir: -1, page: 100, want: 1},
+		{from: 6, dir: 1, page: 100, want: 7},
+		// No current item starts from the top.
+		{from: -1, dir: 1, page: 30, want: 3},
+	}
+	for _, tc := range testCases {
+		if got := l.pageItemIndexWithPageHeight(nil, tc.from, tc.dir, tc.page); got != tc.want {
+			t.Errorf("pageItemIndexWithPageHeight(%d, %d, %d): got: %d, want: %d", tc.from, tc.dir, tc.page, got, tc.want)
+		}
+	}
+}
+
+func TestBaseListTypeAheadItemIndex(t *testing.T) {
+	timeout := int64(listTypeAheadTimeoutInTicks())
+	steps := []struct {
+		current int
+		chars   string
+		tick    int64
+		want    int
+		wantOK  bool
+	}{
+		{current: -1, chars: "", tick: 100, want: -1, wantOK: false},
+		// A space at the beginning is not consumed.
+		{current: -1, chars: " ", tick: 100, want: -1, wantOK: false},
+		// The same letter cycles the items starting with the letter, wrapping around.
+		{current: -1, chars: "b", tick: 100, want: 3, wantOK: true},
+		{current: 3, chars: "b", tick: 101, want: 5, wantOK: true},
+		{current: 5, chars: "b", tick: 102, want: 3, wantOK: true},
+		// The typed text is reset after the timeout.
+		{current: 3, chars: "A", tick: 103 + timeout, want: 7, wantOK: true},
+		{current: 7, chars: "v", tick: 104 + timeout, want: 2, wantOK: true},
+		{current: 2, chars: "x", tick: 105 + timeout, want: -1, wantOK: true},
+		// The header item is not selectable.
+		{current: 2, chars: "h", tick: 106 + 2*timeout, want: -1, wantOK: true},
+		{current: 2, chars: "ch", tick: 107 + 3*timeout, want: 6, wantOK: true},
+	}
+	var l baseList[int]
+	l.setItemProvider(len(testFruitItems), func(index int) baseListItem[int] {
+		return testFruitItems[index]
+	}, 10)
+	for i, s := range steps {
+		got, gotOK := l.typeAheadItemIndexWithInput(s.current, []rune(s.chars), s.tick)
+		if got != s.want || gotOK != s.wantOK {
+			t.Errorf("step %d: typeAheadItemIndexWithInput(%d, %q, %d): got: %d, %t, want: %d, %t", i, s.current, s.chars, s.tick, got, gotOK, s.want, s.wantOK)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"github.com/hajimehoshi/guigui"
+)
+
+// binding is a two-way binding between a widget's value and an observable value.
+type binding[T comparable] struct {
+	observable   *guigui.Observable[T]
+	versionPlus1 uint64
+}
+
+func (b *binding[T]) bind(observable *guigui.Observable[T]) {
+	b.observable = observable
+	b.versionPlus1 = 0
+}
+
+func (b *binding[T]) isBound() bool {
+	return b.observable != nil
+}
+
+// pull returns the observable's value and true if the value has been changed since the last pull.
+//
+// The widget should apply the value without firing its callbacks, as the change comes from the model.
+func (b *binding[T]) pull(context *guigui.Context) (T, bool) {
+	var zero T
+	if b.observable == nil {
+		return zero, false
+	}
+	version := b.observable.Version(context)
+	if b.versionPlus1 == version+1 {
+		return zero, false
+	}
+	b.versionPlus1 = version + 1
+	return b.observable.Value(context), true
+}
+
+// push sets the widget's value to the observable.
+func (b *binding[T]) push(value T) {
+	if b.observable == nil {
+		return
+	}
+	b.observable.SetValue(value)
+}
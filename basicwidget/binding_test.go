@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func TestToggleBinding(t *testing.T) {
+	var value guigui.Observable[bool]
+	var toggle basicwidget.Toggle
+	var changed int
+	toggle.SetOnValueChanged(func(value bool) {
+		changed++
+	})
+	toggle.BindValue(&value)
+
+	// A change from the model is applied without the callback.
+	value.SetValue(true)
+	toggle.PullValueFromBinding()
+	if !toggle.Value() {
+		t.Errorf("Value: got: false, want: true")
+	}
+	if changed != 0 {
+		t.Errorf("changed: got: %d, want: 0", changed)
+	}
+
+	// A change by the user is pushed to the model with the callback.
+	version := value.Version(nil)
+	toggle.SetValue(false)
+	if value.Value(nil) {
+		t.Errorf("bound value: got: true, want: false")
+	}
+	if got, want := value.Version(nil), version+1; got != want {
+		t.Errorf("Version: got: %d, want: %d", got, want)
+	}
+	if got, want := changed, 1; got != want {
+		t.Errorf("changed: got: %d, want: %d", got, want)
+	}
+
+	// Pulling the value the toggle pushed doesn't fire the callback again.
+	toggle.PullValueFromBinding()
+	toggle.PullValueFromBinding()
+	if toggle.Value() {
+		t.Errorf("Value: got: true, want: false")
+	}
+	if got, want := changed, 1; got != want {
+		t.Errorf("changed: got: %d, want: %d", got, want)
+	}
+
+	// Setting the same value to the model doesn't change the version.
+	version = value.Version(nil)
+	value.SetValue(false)
+	if got, want := value.Version(nil), version; got != want {
+		t.Errorf("Version: got: %d, want: %d", got, want)
+	}
+}
+
+func TestNumberInputBinding(t *testing.T) {
+	var value guigui.Observable[int64]
+	var numberInput basicwidget.NumberInput
+	numberInput.SetMaximumValueInt64(10)
+	var changed int
+	numberInput.SetOnValueChangedInt64(func(value int64) {
+		changed++
+	})
+	numberInput.BindValueInt64(&value)
+
+	value.SetValue(5)
+	numberInput.PullValueFromBinding()
+	if got, want := numberInput.ValueInt64(), int64(5); got != want {
+		t.Errorf("ValueInt64: got: %d, want: %d", got, want)
+	}
+	if changed != 0 {
+		t.Errorf("changed: got: %d, want: 0", changed)
+	}
+
+	// The value from the model is clamped.
+	value.SetValue(20)
+	numberInput.PullValueFromBinding()
+	if got, want := numberInput.ValueInt64(), int64(10); got != want {
+		t.Errorf("ValueInt64: got: %d, want: %d", got, want)
+	}
+
+	numberInput.SetValueInt64(3)
+	if got, want := value.Value(nil), int64(3); got != want {
+		t.Errorf("bound value: got: %d, want: %d", got, want)
+	}
+	if got, want := changed, 1; got != want {
+		t.Errorf("changed: got: %d, want: %d", got, want)
+	}
+}
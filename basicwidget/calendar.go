@@ -0,0 +1,521 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"golang.org/x/text/language"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+)
+
+const calendarDayCount = 6 * 7
+
+// Calendar is a month grid to select a date or a range of dates.
+//
+// Dates are represented as time.Time values, and only their years, months and days are used.
+// The dates created by Calendar are at midnight in the local time zone.
+type Calendar struct {
+	guigui.DefaultWidget
+
+	prevButton   Button
+	nextButton   Button
+	title        Text
+	weekdayTexts [7]Text
+	days         [calendarDayCount]calendarDay
+
+	year  int
+	month time.Month
+
+	value          time.Time
+	rangeSelection bool
+	rangeStart     time.Time
+	rangeEnd       time.Time
+
+	// cursor is the date focused by the keyboard.
+	cursor time.Time
+
+	minDate      time.Time
+	maxDate      time.Time
+	dateDisabled func(date time.Time) bool
+
+	tmpLocales []language.Tag
+
+	onValueChanged func(date time.Time)
+	onRangeChanged func(start, end time.Time)
+}
+
+// SetOnValueChanged sets the function called when a date is selected in the single selection mode.
+func (c *Calendar) SetOnValueChanged(f func(date time.Time)) {
+	c.onValueChanged = f
+}
+
+// SetOnRangeChanged sets the function called when the range is changed in the range selection mode.
+// end is zero while the end of the range is not selected yet.
+func (c *Calendar) SetOnRangeChanged(f func(start, end time.Time)) {
+	c.onRangeChanged = f
+}
+
+// Value returns the selected date, or the zero time if no date is selected.
+func (c *Calendar) Value() time.Time {
+	return c.value
+}
+
+// SetValue sets the selected date and shows its month.
+// A zero date clears the selection.
+func (c *Calendar) SetValue(date time.Time) {
+	if !date.IsZero() {
+		date = dateOnly(date)
+	}
+	if date.Equal(c.value) {
+		return
+	}
+	c.value = date
+	if !date.IsZero() {
+		c.cursor = date
+		c.SetDisplayedMonth(date.Year(), date.Month())
+	}
+	guigui.RequestRedraw(c)
+}
+
+// SetRangeSelectionEnabled sets whether a range of dates is selected instead of a single date.
+func (c *Calendar) SetRangeSelectionEnabled(enabled bool) {
+	if c.rangeSelection == enabled {
+		return
+	}
+	c.rangeSelection = enabled
+	guigui.RequestRedraw(c)
+}
+
+// Range returns the selected range in the range selection mode.
+// end is zero while the end of the range is not selected yet.
+func (c *Calendar) Range() (start, end time.Time) {
+	return c.rangeStart, c.rangeEnd
+}
+
+// SetRange sets the selected range in the range selection mode.
+func (c *Calendar) SetRange(start, end time.Time) {
+	if !start.IsZero() {
+		start = dateOnly(start)
+	}
+	if !end.IsZero() {
+		end = dateOnly(end)
+	}
+	if !start.IsZero() && !end.IsZero() && compareDates(start, end) > 0 {
+		start, end = end, start
+	}
+	if start.Equal(c.rangeStart) && end.Equal(c.rangeEnd) {
+		return
+	}
+	c.rangeStart = start
+	c.rangeEnd = end
+	if !start.IsZero() {
+		c.cursor = start
+		c.SetDisplayedMonth(start.Year(), start.Month())
+	}
+	guigui.RequestRedraw(c)
+}
+
+// SetMinimumDate sets the earliest selectable date.
+// A zero date removes the limit.
+func (c *Calendar) SetMinimumDate(date time.Time) {
+	if date.Equal(c.minDate) {
+		return
+	}
+	c.minDate = date
+	guigui.RequestRedraw(c)
+}
+
+// SetMaximumDate sets the latest selectable date.
+// A zero date removes the limit.
+func (c *Calendar) SetMaximumDate(date time.Time) {
+	if date.Equal(c.maxDate) {
+		return
+	}
+	c.maxDate = date
+	guigui.RequestRedraw(c)
+}
+
+// SetDateDisabledFunc sets the function reporting whether a date is disabled, e.g. for holidays.
+func (c *Calendar) SetDateDisabledFunc(f func(date time.Time) bool) {
+	c.dateDisabled = f
+}
+
+// DisplayedMonth returns the month shown in the grid.
+func (c *Calendar) DisplayedMonth() (int, time.Month) {
+	c.ensureDisplayedMonth()
+	return c.year, c.month
+}
+
+func (c *Calendar) SetDisplayedMonth(year int, month time.Month) {
+	d := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	if c.year == d.Year() && c.month == d.Month() {
+		return
+	}
+	c.year = d.Year()
+	c.month = d.Month()
+	guigui.RequestRedraw(c)
+}
+
+func (c *Calendar) ensureDisplayedMonth() {
+	if c.month != 0 {
+		return
+	}
+	d := c.currentDate()
+	c.year = d.Year()
+	c.month = d.Month()
+}
+
+// currentDate returns the date to focus first.
+func (c *Calendar) currentDate() time.Time {
+	switch {
+	case !c.cursor.IsZero():
+		return c.cursor
+	case c.rangeSelection && !c.rangeStart.IsZero():
+		return c.rangeStart
+	case !c.rangeSelection && !c.value.IsZero():
+		return c.value
+	}
+	return c.clampDate(dateOnly(time.Now()))
+}
+
+func (c *Calendar) clampDate(date time.Time) time.Time {
+	if !c.minDate.IsZero() && compareDates(date, c.minDate) < 0 {
+		return dateOnly(c.minDate)
+	}
+	if !c.maxDate.IsZero() && compareDates(date, c.maxDate) > 0 {
+		return dateOnly(c.maxDate)
+	}
+	return date
+}
+
+// IsDateEnabled reports whether date is selectable with the minimum and maximum dates and the disabled-date function.
+func (c *Calendar) IsDateEnabled(date time.Time) bool {
+	if !c.minDate.IsZero() && compareDates(date, c.minDate) < 0 {
+		return false
+	}
+	if !c.maxDate.IsZero() && compareDates(date, c.maxDate) > 0 {
+		return false
+	}
+	if c.dateDisabled != nil && c.dateDisabled(dateOnly(date)) {
+		return false
+	}
+	return true
+}
+
+func (c *Calendar) selectDate(date time.Time) {
+	if !c.IsDateEnabled(date) {
+		return
+	}
+	c.cursor = date
+	c.SetDisplayedMonth(date.Year(), date.Month())
+	guigui.RequestRedraw(c)
+
+	if c.rangeSelection {
+		if c.rangeStart.IsZero() || !c.rangeEnd.IsZero() {
+			c.rangeStart = date
+			c.rangeEnd = time.Time{}
+		} else if compareDates(date, c.rangeStart) < 0 {
+			c.rangeStart, c.rangeEnd = date, c.rangeStart
+		} else {
+			c.rangeEnd = date
+		}
+		if c.onRangeChanged != nil {
+			c.onRangeChanged(c.rangeStart, c.rangeEnd)
+		}
+		return
+	}
+
+	if !c.value.IsZero() && compareDates(c.value, date) == 0 {
+		return
+	}
+	c.value = date
+	if c.onValueChanged != nil {
+		c.onValueChanged(date)
+	}
+}
+
+func (c *Calendar) moveCursor(date time.Time) {
+	date = c.clampDate(date)
+	c.cursor = date
+	c.SetDisplayedMonth(date.Year(), date.Month())
+	guigui.RequestRedraw(c)
+}
+
+func (c *Calendar) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	c.ensureDisplayedMonth()
+	context.SetFocusable(c, true)
+
+	var l calendarLocaleInfo
+	l, c.tmpLocales = calendarLocaleForContext(context, c.tmpLocales)
+
+	u := UnitSize(context)
+	b := context.Bounds(c)
+	cellWidth := b.Dx() / 7
+	headerHeight := u
+	weekdayHeight := u * 3 / 4
+	cellHeight := (b.Dy() - headerHeight - weekdayHeight) / 6
+
+	imgPrev, err := theResourceImages.Get("keyboard_arrow_left", context.ColorMode())
+	if err != nil {
+		return err
+	}
+	imgNext, err := theResourceImages.Get("keyboard_arrow_right", context.ColorMode())
+	if err != nil {
+		return err
+	}
+	c.prevButton.SetIcon(imgPrev)
+	c.prevButton.setOnRepeat(func() {
+		c.SetDisplayedMonth(c.year, c.month-1)
+	})
+	context.SetEnabled(&c.prevButton, c.minDate.IsZero() || compareMonths(c.year, c.month, c.minDate.Year(), c.minDate.Month()) > 0)
+	appender.AppendChildWidgetWithBounds(&c.prevButton, image.Rect(b.Min.X, b.Min.Y, b.Min.X+u, b.Min.Y+headerHeight))
+
+	c.nextButton.SetIcon(imgNext)
+	c.nextButton.setOnRepeat(func() {
+		c.SetDisplayedMonth(c.year, c.month+1)
+	})
+	context.SetEnabled(&c.nextButton, c.maxDate.IsZero() || compareMonths(c.year, c.month, c.maxDate.Year(), c.maxDate.Month()) < 0)
+	appender.AppendChildWidgetWithBounds(&c.nextButton, image.Rect(b.Max.X-u, b.Min.Y, b.Max.X, b.Min.Y+headerHeight))
+
+	c.title.SetValue(l.monthYear(c.year, c.month))
+	c.title.SetBold(true)
+	c.title.SetHorizontalAlign(HorizontalAlignCenter)
+	c.title.SetVerticalAlign(VerticalAlignMiddle)
+	appender.AppendChildWidgetWithBounds(&c.title, image.Rect(b.Min.X+u, b.Min.Y, b.Max.X-u, b.Min.Y+headerHeight))
+
+	y := b.Min.Y + headerHeight
+	for i, wd := range weekdays(l.firstWeekday) {
+		t := &c.weekdayTexts[i]
+		t.SetValue(l.weekdayNames[wd])
+		t.SetHorizontalAlign(HorizontalAlignCenter)
+		t.SetVerticalAlign(VerticalAlignMiddle)
+		t.SetColor(draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.5))
+		x := b.Min.X + i*cellWidth
+		appender.AppendChildWidgetWithBounds(t, image.Rect(x, y, x+cellWidth, y+weekdayHeight))
+	}
+	y += weekdayHeight
+
+	today := dateOnly(time.Now())
+	focused := context.IsFocused(c)
+	start := calendarGridStart(c.year, c.month, l.firstWeekday)
+	for i := range c.days {
+		d := &c.days[i]
+		date := start.AddDate(0, 0, i)
+		d.date = date
+		d.inMonth = date.Month() == c.month
+		d.enabled = c.IsDateEnabled(date)
+		d.today = compareDates(date, today) == 0
+		d.cursor = focused && compareDates(date, c.cursor) == 0
+		d.selected, d.inRange = c.selectionState(date)
+		d.onPressed = func() {
+			context.SetFocused(c, true)
+			c.selectDate(date)
+		}
+		x := b.Min.X + (i%7)*cellWidth
+		dy := y + (i/7)*cellHeight
+		appender.AppendChildWidgetWithBounds(d, image.Rect(x, dy, x+cellWidth, dy+cellHeight))
+	}
+
+	return nil
+}
+
+// selectionState reports whether date is selected, and whether date is inside the selected range.
+func (c *Calendar) selectionState(date time.Time) (selected, inRange bool) {
+	if !c.rangeSelection {
+		return !c.value.IsZero() && compareDates(date, c.value) == 0, false
+	}
+	if c.rangeStart.IsZero() {
+		return false, false
+	}
+	if compareDates(date, c.rangeStart) == 0 {
+		return true, false
+	}
+	if c.rangeEnd.IsZero() {
+		return false, false
+	}
+	if compareDates(date, c.rangeEnd) == 0 {
+		return true, false
+	}
+	return false, compareDates(c.rangeStart, date) < 0 && compareDates(date, c.rangeEnd) < 0
+}
+
+func (c *Calendar) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsFocused(c) {
+		return guigui.HandleInputResult{}
+	}
+
+	l, _ := calendarLocaleForContext(context, c.tmpLocales)
+	cursor := c.currentDate()
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	switch {
+	case isKeyRepeating(ebiten.KeyLeft):
+		c.moveCursor(cursor.AddDate(0, 0, -1))
+	case isKeyRepeating(ebiten.KeyRight):
+		c.moveCursor(cursor.AddDate(0, 0, 1))
+	case isKeyRepeating(ebiten.KeyUp):
+		c.moveCursor(cursor.AddDate(0, 0, -7))
+	case isKeyRepeating(ebiten.KeyDown):
+		c.moveCursor(cursor.AddDate(0, 0, 7))
+	case isKeyRepeating(ebiten.KeyPageUp) && shift:
+		c.moveCursor(addMonthsClamped(cursor, -12))
+	case isKeyRepeating(ebiten.KeyPageDown) && shift:
+		c.moveCursor(addMonthsClamped(cursor, 12))
+	case isKeyRepeating(ebiten.KeyPageUp):
+		c.moveCursor(addMonthsClamped(cursor, -1))
+	case isKeyRepeating(ebiten.KeyPageDown):
+		c.moveCursor(addMonthsClamped(cursor, 1))
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		c.moveCursor(cursor.AddDate(0, 0, -int((cursor.Weekday()-l.firstWeekday+7)%7)))
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		c.moveCursor(cursor.AddDate(0, 0, 6-int((cursor.Weekday()-l.firstWeekday+7)%7)))
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		c.selectDate(cursor)
+	default:
+		return guigui.HandleInputResult{}
+	}
+	return guigui.HandleInputByWidget(c)
+}
+
+func (c *Calendar) DefaultSize(context *guigui.Context) image.Point {
+	u := UnitSize(context)
+	cell := u * 5 / 4
+	return image.Pt(7*cell, u+u*3/4+6*cell)
+}
+
+type calendarDay struct {
+	guigui.DefaultWidget
+
+	text Text
+
+	date     time.Time
+	inMonth  bool
+	enabled  bool
+	today    bool
+	cursor   bool
+	selected bool
+	inRange  bool
+
+	prevHovered bool
+
+	onPressed func()
+}
+
+func (c *calendarDay) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	c.text.SetValue(strconv.Itoa(c.date.Day()))
+	c.text.SetTabular(true)
+	c.text.SetHorizontalAlign(HorizontalAlignCenter)
+	c.text.SetVerticalAlign(VerticalAlignMiddle)
+	c.text.SetBold(c.today)
+	switch {
+	case c.selected:
+		c.text.SetColor(DefaultActiveListItemTextColor(context))
+	case !c.enabled:
+		c.text.SetColor(draw.ScaleAlpha(DefaultDisabledListItemTextColor(context), 0.5))
+	case !c.inMonth:
+		c.text.SetColor(DefaultDisabledListItemTextColor(context))
+	default:
+		c.text.SetColor(draw.TextColor(context.ColorMode(), context.IsEnabled(c)))
+	}
+	appender.AppendChildWidgetWithBounds(&c.text, context.Bounds(c))
+	return nil
+}
+
+func (c *calendarDay) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if hovered := c.isHovered(context); c.prevHovered != hovered {
+		c.prevHovered = hovered
+		guigui.RequestRedraw(c)
+	}
+	if c.isHovered(context) && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if c.onPressed != nil {
+			c.onPressed()
+		}
+		return guigui.HandleInputByWidget(c)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (c *calendarDay) isHovered(context *guigui.Context) bool {
+	return c.enabled && context.IsEnabled(c) && context.IsWidgetHitAtCursor(c)
+}
+
+func (c *calendarDay) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	if c.isHovered(context) {
+		return ebiten.CursorShapePointer, true
+	}
+	return 0, false
+}
+
+func (c *calendarDay) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(c)
+	cm := context.ColorMode()
+	inset := int(2 * context.Scale())
+	cb := b.Inset(inset)
+	r := RoundedCornerRadius(context)
+
+	switch {
+	case c.selected:
+		draw.DrawRoundedRect(context, dst, cb, draw.Color(cm, draw.ColorTypeAccent, 0.5), r)
+	case c.inRange:
+		// Connect the dates in the range horizontally.
+		rb := image.Rect(b.Min.X, cb.Min.Y, b.Max.X, cb.Max.Y)
+		draw.DrawRoundedRect(context, dst, rb, draw.Color2(cm, draw.ColorTypeAccent, 0.9, 0.3), 0)
+	case c.isHovered(context):
+		draw.DrawRoundedRect(context, dst, cb, draw.Color2(cm, draw.ColorTypeBase, 0.9, 0.2), r)
+	}
+
+	if c.today && !c.selected {
+		clr := draw.Color(cm, draw.ColorTypeAccent, 0.5)
+		draw.DrawRoundedRectBorder(context, dst, cb, clr, clr, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeRegular)
+	}
+	if c.cursor {
+		clr := draw.Color2(cm, draw.ColorTypeAccent, 0.8, 0.6)
+		draw.DrawRoundedRectBorder(context, dst, cb, clr, clr, r, float32(textInputFocusBorderWidth(context)), draw.RoundedRectBorderTypeRegular)
+	}
+}
+
+// dateOnly returns the midnight of date in its location.
+func dateOnly(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// compareDates compares the years, months and days of a and b.
+func compareDates(a, b time.Time) int {
+	if c := compareMonths(a.Year(), a.Month(), b.Year(), b.Month()); c != 0 {
+		return c
+	}
+	return a.Day() - b.Day()
+}
+
+func compareMonths(year0 int, month0 time.Month, year1 int, month1 time.Month) int {
+	if year0 != year1 {
+		return year0 - year1
+	}
+	return int(month0) - int(month1)
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// addMonthsClamped adds months to date, clamping the day to the last day of the month.
+// For example, one month after January 31 is February 28 or 29, not March 2 or 3.
+func addMonthsClamped(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	day := min(date.Day(), daysInMonth(first.Year(), first.Month()))
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, date.Location())
+}
+
+// calendarGridStart returns the first date in the grid of the month, where weeks start on firstWeekday.
+func calendarGridStart(year int, month time.Month, firstWeekday time.Weekday) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	offset := (first.Weekday() - firstWeekday + 7) % 7
+	return first.AddDate(0, 0, -int(offset))
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func parseTags(strs ...string) []language.Tag {
+	var tags []language.Tag
+	for _, s := range strs {
+		tags = append(tags, language.MustParse(s))
+	}
+	return tags
+}
+
+func TestCalendarLocale(t *testing.T) {
+	date := time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		Tags         []string
+		FirstWeekday time.Weekday
+		TwelveHour   bool
+		MonthYear    string
+		Date         string
+	}{
+		{Tags: nil, FirstWeekday: time.Sunday, TwelveHour: true, MonthYear: "March 2025", Date: "03/07/2025"},
+		{Tags: []string{"en-US"}, FirstWeekday: time.Sunday, TwelveHour: true, MonthYear: "March 2025", Date: "03/07/2025"},
+		{Tags: []string{"en-GB"}, FirstWeekday: time.Monday, TwelveHour: false, MonthYear: "March 2025", Date: "07/03/2025"},
+		{Tags: []string{"ja"}, FirstWeekday: time.Sunday, TwelveHour: false, MonthYear: "2025年3月", Date: "2025/03/07"},
+		{Tags: []string{"de-DE"}, FirstWeekday: time.Monday, TwelveHour: false, MonthYear: "März 2025", Date: "07.03.2025"},
+		// Unsupported languages fall back to English with the region of the first tag.
+		{Tags: []string{"ar-EG"}, FirstWeekday: time.Saturday, TwelveHour: true, MonthYear: "March 2025", Date: "07/03/2025"},
+		// The first supported language is used.
+		{Tags: []string{"ar-EG", "fr-FR"}, FirstWeekday: time.Monday, TwelveHour: false, MonthYear: "mars 2025", Date: "07/03/2025"},
+	}
+	for _, tc := range testCases {
+		tags := parseTags(tc.Tags...)
+		if got := basicwidget.CalendarFirstWeekday(tags); got != tc.FirstWeekday {
+			t.Errorf("CalendarFirstWeekday(%v): got: %v, want: %v", tc.Tags, got, tc.FirstWeekday)
+		}
+		if got := basicwidget.CalendarTwelveHour(tags); got != tc.TwelveHour {
+			t.Errorf("CalendarTwelveHour(%v): got: %v, want: %v", tc.Tags, got, tc.TwelveHour)
+		}
+		if got := basicwidget.CalendarMonthYear(tags, 2025, time.March); got != tc.MonthYear {
+			t.Errorf("CalendarMonthYear(%v): got: %q, want: %q", tc.Tags, got, tc.MonthYear)
+		}
+		if got := basicwidget.FormatDate(tags, date); got != tc.Date {
+			t.Errorf("FormatDate(%v): got: %q, want: %q", tc.Tags, got, tc.Date)
+		}
+		if got, ok := basicwidget.ParseDate(tags, tc.Date); !ok || !got.Equal(date) {
+			t.Errorf("ParseDate(%v, %q): got: %v, %v, want: %v, true", tc.Tags, tc.Date, got, ok, date)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	testCases := []struct {
+		Tags []string
+		In   string
+		Want time.Time
+		OK   bool
+	}{
+		{Tags: []string{"en-US"}, In: "3/7/2025", Want: time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), OK: true},
+		{Tags: []string{"en-US"}, In: " 03-07-2025 ", Want: time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), OK: true},
+		{Tags: []string{"en-US"}, In: "2025-03-07", Want: time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), OK: true},
+		{Tags: []string{"en-GB"}, In: "7/3/2025", Want: time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), OK: true},
+		{Tags: []string{"ja"}, In: "2025年3月7日", Want: time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), OK: true},
+		{Tags: []string{"en-US"}, In: "2/29/2024", Want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), OK: true},
+		{Tags: []string{"en-US"}, In: "2/29/2025", OK: false},
+		{Tags: []string{"en-US"}, In: "13/1/2025", OK: false},
+		{Tags: []string{"en-US"}, In: "3/7", OK: false},
+		{Tags: []string{"en-US"}, In: "", OK: false},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.ParseDate(parseTags(tc.Tags...), tc.In)
+		if ok != tc.OK {
+			t.Errorf("ParseDate(%v, %q): ok: got: %v, want: %v", tc.Tags, tc.In, ok, tc.OK)
+			continue
+		}
+		if ok && !got.Equal(tc.Want) {
+			t.Errorf("ParseDate(%v, %q): got: %v, want: %v", tc.Tags, tc.In, got, tc.Want)
+		}
+	}
+}
+
+func TestCalendarGridStart(t *testing.T) {
+	// March 1, 2025 is a Saturday.
+	testCases := []struct {
+		FirstWeekday time.Weekday
+		Want         int
+	}{
+		{FirstWeekday: time.Sunday, Want: 23},
+		{FirstWeekday: time.Monday, Want: 24},
+		{FirstWeekday: time.Saturday, Want: 1},
+	}
+	for _, tc := range testCases {
+		got := basicwidget.CalendarGridStart(2025, time.March, tc.FirstWeekday)
+		if got.Weekday() != tc.FirstWeekday {
+			t.Errorf("CalendarGridStart(%v): weekday: got: %v, want: %v", tc.FirstWeekday, got.Weekday(), tc.FirstWeekday)
+		}
+		if got.Day() != tc.Want {
+			t.Errorf("CalendarGridStart(%v): day: got: %d, want: %d", tc.FirstWeekday, got.Day(), tc.Want)
+		}
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	date := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	if got, want := basicwidget.AddMonthsClamped(date, 1), time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := basicwidget.AddMonthsClamped(date, -2), time.Date(2023, time.November, 30, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := basicwidget.AddMonthsClamped(date, 12), time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestCalendarRangeSelection(t *testing.T) {
+	var c basicwidget.Calendar
+	c.SetRangeSelectionEnabled(true)
+	var changed int
+	c.SetOnRangeChanged(func(start, end time.Time) {
+		changed++
+	})
+
+	d := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.Local)
+	}
+
+	c.SelectDate(d(10))
+	if start, end := c.Range(); !start.Equal(d(10)) || !end.IsZero() {
+		t.Errorf("Range after the first selection: got: %v, %v", start, end)
+	}
+	// An earlier end swaps the range.
+	c.SelectDate(d(5))
+	if start, end := c.Range(); !start.Equal(d(5)) || !end.Equal(d(10)) {
+		t.Errorf("Range after the second selection: got: %v, %v", start, end)
+	}
+	// A third selection starts a new range.
+	c.SelectDate(d(20))
+	if start, end := c.Range(); !start.Equal(d(20)) || !end.IsZero() {
+		t.Errorf("Range after the third selection: got: %v, %v", start, end)
+	}
+	if got, want := changed, 3; got != want {
+		t.Errorf("changed: got: %d, want: %d", got, want)
+	}
+	if year, month := c.DisplayedMonth(); year != 2025 || month != time.March {
+		t.Errorf("DisplayedMonth: got: %d %v, want: 2025 March", year, month)
+	}
+}
+
+func TestCalendarDisabledDates(t *testing.T) {
+	var c basicwidget.Calendar
+	var changed int
+	c.SetOnValueChanged(func(date time.Time) {
+		changed++
+	})
+
+	d := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.Local)
+	}
+	c.SetMinimumDate(d(3))
+	c.SetMaximumDate(d(28))
+	c.SetDateDisabledFunc(func(date time.Time) bool {
+		return date.Weekday() == time.Sunday
+	})
+
+	for _, tc := range []struct {
+		Day     int
+		Enabled bool
+	}{
+		{Day: 2, Enabled: false},
+		{Day: 3, Enabled: true},
+		{Day: 9, Enabled: false},
+		{Day: 28, Enabled: true},
+		{Day: 29, Enabled: false},
+	} {
+		if got := c.IsDateEnabled(d(tc.Day)); got != tc.Enabled {
+			t.Errorf("IsDateEnabled(March %d): got: %v, want: %v", tc.Day, got, tc.Enabled)
+		}
+	}
+
+	c.SelectDate(d(9))
+	if !c.Value().IsZero() {
+		t.Errorf("Value after selecting a disabled date: got: %v, want: zero", c.Value())
+	}
+	c.SelectDate(d(10))
+	if !c.Value().Equal(d(10)) {
+		t.Errorf("Value: got: %v, want: %v", c.Value(), d(10))
+	}
+	c.SelectDate(d(10))
+	if got, want := changed, 1; got != want {
+		t.Errorf("changed: got: %d, want: %d", got, want)
+	}
+}
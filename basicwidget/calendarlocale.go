@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/language"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+type dateOrder int
+
+const (
+	dateOrderYMD dateOrder = iota
+	dateOrderMDY
+	dateOrderDMY
+)
+
+// calendarLocale is the locale-dependent data for the calendar widgets.
+// This is a small subset of the Unicode CLDR.
+type calendarLocale struct {
+	monthNames [12]string
+
+	// weekdayNames is the short names of the weekdays, starting from Sunday.
+	weekdayNames [7]string
+
+	// monthYearFormat is the format of a month and a year, where %[1]s is the month name and %[2]d is the year.
+	monthYearFormat string
+
+	dateOrder     dateOrder
+	dateSeparator string
+
+	amPM [2]string
+}
+
+var calendarLocales = map[language.Base]*calendarLocale{
+	language.MustParseBase("en"): {
+		monthNames:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		weekdayNames:    [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		monthYearFormat: "%[1]s %[2]d",
+		dateOrder:       dateOrderDMY,
+		dateSeparator:   "/",
+		amPM:            [2]string{"AM", "PM"},
+	},
+	language.MustParseBase("ja"): {
+		monthNames:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdayNames:    [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		monthYearFormat: "%[2]d年%[1]s",
+		dateOrder:       dateOrderYMD,
+		dateSeparator:   "/",
+		amPM:            [2]string{"午前", "午後"},
+	},
+	language.MustParseBase("zh"): {
+		monthNames:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdayNames:    [7]string{"日", "一", "二", "三", "四", "五", "六"},
+		monthYearFormat: "%[2]d年%[1]s",
+		dateOrder:       dateOrderYMD,
+		dateSeparator:   "/",
+		amPM:            [2]string{"上午", "下午"},
+	},
+	language.MustParseBase("ko"): {
+		monthNames:      [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		weekdayNames:    [7]string{"일", "월", "화", "수", "목", "금", "토"},
+		monthYearFormat: "%[2]d년 %[1]s",
+		dateOrder:       dateOrderYMD,
+		dateSeparator:   "-",
+		amPM:            [2]string{"오전", "오후"},
+	},
+	language.MustParseBase("de"): {
+		monthNames:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		weekdayNames:    [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		monthYearFormat: "%[1]s %[2]d",
+		dateOrder:       dateOrderDMY,
+		dateSeparator:   ".",
+		amPM:            [2]string{"AM", "PM"},
+	},
+	language.MustParseBase("fr"): {
+		monthNames:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		weekdayNames:    [7]string{"di", "lu", "ma", "me", "je", "ve", "sa"},
+		monthYearFormat: "%[1]s %[2]d",
+		dateOrder:       dateOrderDMY,
+		dateSeparator:   "/",
+		amPM:            [2]string{"AM", "PM"},
+	},
+	language.MustParseBase("es"): {
+		monthNames:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		weekdayNames:    [7]string{"do", "lu", "ma", "mi", "ju", "vi", "sá"},
+		monthYearFormat: "%[1]s de %[2]d",
+		dateOrder:       dateOrderDMY,
+		dateSeparator:   "/",
+		amPM:            [2]string{"a. m.", "p. m."},
+	},
+	language.MustParseBase("it"): {
+		monthNames:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		weekdayNames:    [7]string{"do", "lu", "ma", "me", "gi", "ve", "sa"},
+		monthYearFormat: "%[1]s %[2]d",
+		dateOrder:       dateOrderDMY,
+		dateSeparator:   "/",
+		amPM:            [2]string{"AM", "PM"},
+	},
+	language.MustParseBase("pt"): {
+		monthNames:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		weekdayNames:    [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		monthYearFormat: "%[1]s de %[2]d",
+		dateOrder:       dateOrderDMY,
+		dateSeparator:   "/",
+		amPM:            [2]string{"AM", "PM"},
+	},
+	language.MustParseBase("ru"): {
+		monthNames:      [12]string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+		weekdayNames:    [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		monthYearFormat: "%[1]s %[2]d",
+		dateOrder:       dateOrderDMY,
+		dateSeparator:   ".",
+		amPM:            [2]string{"AM", "PM"},
+	},
+	language.MustParseBase("nl"): {
+		monthNames:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		weekdayNames:    [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		monthYearFormat: "%[1]s %[2]d",
+		dateOrder:       dateOrderDMY,
+		dateSeparator:   "-",
+		amPM:            [2]string{"a.m.", "p.m."},
+	},
+}
+
+// Regions by the first day of the week, from the CLDR week data.
+// The other regions start weeks on Monday.
+var (
+	sundayFirstRegions   = parseRegions("AG AS BD BR BS BT BW BZ CA CN CO DM DO ET GT GU HK HN ID IL IN JM JP KE KH KR LA MH MM MO MT MX MZ NI NP PA PE PH PK PR PT PY SA SG SV TH TT TW UM US VE VI WS YE ZA ZW")
+	saturdayFirstRegions = parseRegions("AE AF BH DJ DZ EG IQ IR JO KW LY OM QA SD SY")
+	fridayFirstRegions   = parseRegions("MV")
+)
+
+// Regions preferring the 12-hour clock, from the CLDR time data.
+var twelveHourRegions = parseRegions("AU BD CA CO EG IN KR MX MY NZ PH PK SA TW US")
+
+// Regions writing dates in the month-day-year order.
+var mdyRegions = parseRegions("US PH FM MH PW")
+
+func parseRegions(str string) []language.Region {
+	var regions []language.Region
+	for _, s := range strings.Fields(str) {
+		regions = append(regions, language.MustParseRegion(s))
+	}
+	return regions
+}
+
+// calendarLocaleInfo is the locale data resolved for a language tag.
+type calendarLocaleInfo struct {
+	*calendarLocale
+	firstWeekday time.Weekday
+	twelveHour   bool
+}
+
+// calendarLocaleForTags returns the locale data for the first supported tag in tags.
+// If no tags are supported, English is used with the region of the first tag.
+func calendarLocaleForTags(tags []language.Tag) calendarLocaleInfo {
+	base := language.MustParseBase("en")
+	region := language.MustParseRegion("US")
+	if len(tags) > 0 {
+		region, _ = tags[0].Region()
+	}
+	for _, t := range tags {
+		b, _ := t.Base()
+		if _, ok := calendarLocales[b]; ok {
+			base = b
+			region, _ = t.Region()
+			break
+		}
+	}
+
+	l := calendarLocaleInfo{
+		calendarLocale: calendarLocales[base],
+		firstWeekday:   time.Monday,
+		twelveHour:     slices.Contains(twelveHourRegions, region),
+	}
+	switch {
+	case slices.Contains(sundayFirstRegions, region):
+		l.firstWeekday = time.Sunday
+	case slices.Contains(saturdayFirstRegions, region):
+		l.firstWeekday = time.Saturday
+	case slices.Contains(fridayFirstRegions, region):
+		l.firstWeekday = time.Friday
+	}
+	if base == language.MustParseBase("en") && slices.Contains(mdyRegions, region) {
+		mdy := *l.calendarLocale
+		mdy.dateOrder = dateOrderMDY
+		l.calendarLocale = &mdy
+	}
+	return l
+}
+
+func calendarLocaleForContext(context *guigui.Context, tmpLocales []language.Tag) (calendarLocaleInfo, []language.Tag) {
+	tmpLocales = slices.Delete(tmpLocales, 0, len(tmpLocales))
+	tmpLocales = context.AppendLocales(tmpLocales)
+	return calendarLocaleForTags(tmpLocales), tmpLocales
+}
+
+func (c *calendarLocale) monthYear(year int, month time.Month) string {
+	return fmt.Sprintf(c.monthYearFormat, c.monthNames[month-1], year)
+}
+
+// weekdays returns the weekdays in the order of a week starting from firstWeekday.
+func weekdays(firstWeekday time.Weekday) [7]time.Weekday {
+	var ws [7]time.Weekday
+	for i := range ws {
+		ws[i] = (firstWeekday + time.Weekday(i)) % 7
+	}
+	return ws
+}
+
+// formatDate formats the date in the numeric format of the locale.
+func (c *calendarLocale) formatDate(date time.Time) string {
+	y := fmt.Sprintf("%04d", date.Year())
+	m := fmt.Sprintf("%02d", int(date.Month()))
+	d := fmt.Sprintf("%02d", date.Day())
+	var parts []string
+	switch c.dateOrder {
+	case dateOrderYMD:
+		parts = []string{y, m, d}
+	case dateOrderMDY:
+		parts = []string{m, d, y}
+	case dateOrderDMY:
+		parts = []string{d, m, y}
+	}
+	return strings.Join(parts, c.dateSeparator)
+}
+
+// parseDate parses a date in the numeric format of the locale.
+// Any non-digit characters are accepted as separators.
+// A date starting with a 4-digit year, like the ISO 8601 format, is always accepted.
+func (c *calendarLocale) parseDate(str string, loc *time.Location) (time.Time, bool) {
+	fields := strings.FieldsFunc(str, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	if len(fields) != 3 {
+		return time.Time{}, false
+	}
+	var nums [3]int
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return time.Time{}, false
+		}
+		nums[i] = n
+	}
+
+	var y, m, d int
+	order := c.dateOrder
+	if len(fields[0]) == 4 {
+		order = dateOrderYMD
+	}
+	switch order {
+	case dateOrderYMD:
+		y, m, d = nums[0], nums[1], nums[2]
+	case dateOrderMDY:
+		m, d, y = nums[0], nums[1], nums[2]
+	case dateOrderDMY:
+		d, m, y = nums[0], nums[1], nums[2]
+	}
+	if m < 1 || m > 12 || d < 1 || d > daysInMonth(y, time.Month(m)) {
+		return time.Time{}, false
+	}
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, loc), true
+}
@@ -0,0 +1,872 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/oklab"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+)
+
+// colorPickerMaxChroma is the maximum chroma of the plane.
+// This covers the sRGB gamut.
+const colorPickerMaxChroma = 0.37
+
+const colorPickerMaxRecentColors = 9
+
+var defaultColorPickerSwatches []color.Color
+
+func init() {
+	for i := range colorPickerMaxRecentColors {
+		defaultColorPickerSwatches = append(defaultColorPickerSwatches, oklab.Oklch{
+			L:     0.7,
+			C:     0.15,
+			H:     2 * math.Pi * float64(i) / colorPickerMaxRecentColors,
+			Alpha: 1,
+		})
+	}
+	for i := range colorPickerMaxRecentColors {
+		defaultColorPickerSwatches = append(defaultColorPickerSwatches, oklab.Oklch{
+			L:     1 - float64(i)/(colorPickerMaxRecentColors-1),
+			Alpha: 1,
+		})
+	}
+}
+
+// ColorPicker is a widget to pick a color in the OKLCH color space.
+type ColorPicker struct {
+	guigui.DefaultWidget
+
+	plane            colorPickerArea
+	hueStrip         colorPickerArea
+	alphaStrip       colorPickerArea
+	hueInput         NumberInput
+	alphaInput       NumberInput
+	rgbLabels        [3]Text
+	rgbInputs        [3]NumberInput
+	preview          colorPickerSwatch
+	hexInput         TextInput
+	eyedropperButton Button
+	oklchInput       TextInput
+	recentSwatches   []colorPickerSwatch
+	swatches         []colorPickerSwatch
+	eyedropper       colorPickerEyedropper
+
+	value        oklab.Oklch
+	swatchColors []color.Color
+	swatchesSet  bool
+	recentColors []color.Color
+	picking      bool
+
+	onValueChanged      func(value color.Color)
+	onValueChangedOklch func(value oklab.Oklch)
+}
+
+func (c *ColorPicker) SetOnValueChanged(f func(value color.Color)) {
+	c.onValueChanged = f
+}
+
+func (c *ColorPicker) SetOnValueChangedOklch(f func(value oklab.Oklch)) {
+	c.onValueChangedOklch = f
+}
+
+// Value returns the current color.
+// The returned value's dynamic type is oklab.Oklch.
+func (c *ColorPicker) Value() color.Color {
+	return c.value
+}
+
+func (c *ColorPicker) ValueOklch() oklab.Oklch {
+	return c.value
+}
+
+func (c *ColorPicker) SetValue(clr color.Color) {
+	c.setValue(colorToOklch(clr, c.value.H), false)
+}
+
+// SetValueOklch sets the current color.
+// The hue is in radians.
+func (c *ColorPicker) SetValueOklch(value oklab.Oklch) {
+	c.setValue(value, false)
+}
+
+// SetSwatches sets the colors shown as a palette.
+// If SetSwatches is never called, the default palette is used.
+func (c *ColorPicker) SetSwatches(colors []color.Color) {
+	c.swatchColors = append(c.swatchColors[:0], colors...)
+	c.swatchesSet = true
+}
+
+// AppendRecentColors appends the recently picked colors to colors and returns the result.
+// The most recent color comes first.
+func (c *ColorPicker) AppendRecentColors(colors []color.Color) []color.Color {
+	return append(colors, c.recentColors...)
+}
+
+// SetRecentColors sets the recently picked colors, e.g. to restore them from a previous session.
+func (c *ColorPicker) SetRecentColors(colors []color.Color) {
+	c.recentColors = append(c.recentColors[:0], colors[:min(len(colors), colorPickerMaxRecentColors)]...)
+	guigui.RequestRedraw(c)
+}
+
+// setValue sets the current color.
+// committed reports whether the user finished changing the color, e.g. by releasing the mouse button.
+func (c *ColorPicker) setValue(value oklab.Oklch, committed bool) {
+	value = normalizeOklch(value, c.value.H)
+	if value != c.value {
+		c.value = value
+		guigui.RequestRedraw(c)
+		if c.onValueChanged != nil {
+			c.onValueChanged(c.value)
+		}
+		if c.onValueChangedOklch != nil {
+			c.onValueChangedOklch(c.value)
+		}
+	}
+	if committed {
+		c.addRecentColor(c.value)
+	}
+}
+
+func (c *ColorPicker) addRecentColor(value oklab.Oklch) {
+	clr := oklchToNRGBA(value)
+	for i, rc := range c.recentColors {
+		if draw.EqualColor(rc, clr) {
+			c.recentColors = append(c.recentColors[:i], c.recentColors[i+1:]...)
+			break
+		}
+	}
+	c.recentColors = append([]color.Color{clr}, c.recentColors[:min(len(c.recentColors), colorPickerMaxRecentColors-1)]...)
+	guigui.RequestRedraw(c)
+}
+
+func (c *ColorPicker) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	u := UnitSize(context)
+	gap := u / 4
+	b := context.Bounds(c)
+	inputWidth := 5 * u / 2
+	stripHeight := u * 3 / 4
+	y := b.Min.Y
+
+	onAreaValueChanged := func(value oklab.Oklch, committed bool) {
+		c.setValue(value, committed)
+	}
+
+	c.plane.typ = colorPickerAreaTypePlane
+	c.plane.value = c.value
+	c.plane.onValueChanged = onAreaValueChanged
+	planeHeight := max(b.Dy()-c.controlsHeight(context)-gap, u)
+	appender.AppendChildWidgetWithBounds(&c.plane, image.Rect(b.Min.X, y, b.Max.X, y+planeHeight))
+	y += planeHeight + gap
+
+	c.hueStrip.typ = colorPickerAreaTypeHue
+	c.hueStrip.value = c.value
+	c.hueStrip.onValueChanged = onAreaValueChanged
+	appender.AppendChildWidgetWithBounds(&c.hueStrip, image.Rect(b.Min.X, y+(u-stripHeight)/2, b.Max.X-inputWidth-gap, y+(u+stripHeight)/2))
+
+	c.hueInput.SetMinimumValueInt64(0)
+	c.hueInput.SetMaximumValueInt64(359)
+	c.hueInput.SetOnValueChangedInt64(func(value int64) {
+		if value == hueInDegrees(c.value) {
+			return
+		}
+		v := c.value
+		v.H = float64(value) * math.Pi / 180
+		c.setValue(v, true)
+	})
+	c.hueInput.SetValueInt64(hueInDegrees(c.value))
+	appender.AppendChildWidgetWithBounds(&c.hueInput, image.Rect(b.Max.X-inputWidth, y, b.Max.X, y+u))
+	y += u + gap
+
+	c.alphaStrip.typ = colorPickerAreaTypeAlpha
+	c.alphaStrip.value = c.value
+	c.alphaStrip.onValueChanged = onAreaValueChanged
+	appender.AppendChildWidgetWithBounds(&c.alphaStrip, image.Rect(b.Min.X, y+(u-stripHeight)/2, b.Max.X-inputWidth-gap, y+(u+stripHeight)/2))
+
+	c.alphaInput.SetMinimumValueInt64(0)
+	c.alphaInput.SetMaximumValueInt64(100)
+	c.alphaInput.SetOnValueChangedInt64(func(value int64) {
+		if value == alphaInPercent(c.value) {
+			return
+		}
+		v := c.value
+		v.Alpha = float64(value) / 100
+		c.setValue(v, true)
+	})
+	c.alphaInput.SetValueInt64(alphaInPercent(c.value))
+	appender.AppendChildWidgetWithBounds(&c.alphaInput, image.Rect(b.Max.X-inputWidth, y, b.Max.X, y+u))
+	y += u + gap
+
+	rgb := oklchToNRGBA(c.value)
+	components := [3]uint8{rgb.R, rgb.G, rgb.B}
+	columnWidth := (b.Dx() - 2*gap) / 3
+	for i := range c.rgbInputs {
+		x := b.Min.X + i*(columnWidth+gap)
+		c.rgbLabels[i].SetValue(string("RGB"[i]))
+		c.rgbLabels[i].SetVerticalAlign(VerticalAlignMiddle)
+		c.rgbLabels[i].SetHorizontalAlign(HorizontalAlignCenter)
+		appender.AppendChildWidgetWithBounds(&c.rgbLabels[i], image.Rect(x, y, x+u/2, y+u))
+
+		c.rgbInputs[i].SetMinimumValueInt64(0)
+		c.rgbInputs[i].SetMaximumValueInt64(255)
+		c.rgbInputs[i].SetOnValueChangedInt64(func(value int64) {
+			clr := oklchToNRGBA(c.value)
+			cs := [3]*uint8{&clr.R, &clr.G, &clr.B}
+			if int64(*cs[i]) == value {
+				return
+			}
+			*cs[i] = uint8(value)
+			c.setValue(colorToOklch(clr, c.value.H), true)
+		})
+		c.rgbInputs[i].SetValueInt64(int64(components[i]))
+		appender.AppendChildWidgetWithBounds(&c.rgbInputs[i], image.Rect(x+u/2, y, x+columnWidth, y+u))
+	}
+	y += u + gap
+
+	c.preview.color = c.value
+	c.preview.onPressed = nil
+	appender.AppendChildWidgetWithBounds(&c.preview, image.Rect(b.Min.X, y, b.Min.X+u*3/2, y+u))
+
+	img, err := theResourceImages.Get("colorize", context.ColorMode())
+	if err != nil {
+		return err
+	}
+	c.eyedropperButton.SetIcon(img)
+	c.eyedropperButton.SetOnUp(func() {
+		c.picking = true
+		// Sample the color at the cursor again even if the cursor is not moved.
+		c.eyedropper.color = nil
+		c.eyedropper.sampling = false
+		c.eyedropper.picked = false
+	})
+	appender.AppendChildWidgetWithBounds(&c.eyedropperButton, image.Rect(b.Max.X-u*3/2, y, b.Max.X, y+u))
+
+	c.hexInput.SetTabular(true)
+	c.hexInput.SetOnValueChanged(func(text string, committed bool) {
+		if !committed {
+			return
+		}
+		clr, ok := parseHexColor(text)
+		if !ok || clr == oklchToNRGBA(c.value) {
+			return
+		}
+		c.setValue(colorToOklch(clr, c.value.H), true)
+	})
+	c.hexInput.SetValue(formatHexColor(oklchToNRGBA(c.value)))
+	appender.AppendChildWidgetWithBounds(&c.hexInput, image.Rect(b.Min.X+u*3/2+gap, y, b.Max.X-u*3/2-gap, y+u))
+	y += u + gap
+
+	c.oklchInput.SetTabular(true)
+	c.oklchInput.SetOnValueChanged(func(text string, committed bool) {
+		if !committed {
+			return
+		}
+		v, ok := parseOklchColor(text)
+		if !ok || formatOklchColor(v) == formatOklchColor(c.value) {
+			return
+		}
+		c.setValue(v, true)
+	})
+	c.oklchInput.SetValue(formatOklchColor(c.value))
+	appender.AppendChildWidgetWithBounds(&c.oklchInput, image.Rect(b.Min.X, y, b.Max.X, y+u))
+	y += u + gap
+
+	swatchColors := c.swatchColors
+	if !c.swatchesSet {
+		swatchColors = defaultColorPickerSwatches
+	}
+	c.recentSwatches = adjustSliceSize(c.recentSwatches, len(c.recentColors))
+	c.swatches = adjustSliceSize(c.swatches, len(swatchColors))
+	y = c.appendSwatches(context, appender, c.recentSwatches, c.recentColors, y)
+	if len(c.recentSwatches) > 0 {
+		y += gap
+	}
+	c.appendSwatches(context, appender, c.swatches, swatchColors, y)
+
+	if c.picking {
+		c.eyedropper.onPicked = func(clr color.Color) {
+			c.picking = false
+			context.SetFocused(&c.eyedropperButton, true)
+			if clr == nil {
+				return
+			}
+			c.setValue(colorToOklch(clr, c.value.H), true)
+		}
+		appender.AppendChildWidgetWithBounds(&c.eyedropper, context.AppBounds())
+	}
+
+	return nil
+}
+
+// appendSwatches appends the swatches in rows from y and returns the y position after the last row.
+func (c *ColorPicker) appendSwatches(context *guigui.Context, appender *guigui.ChildWidgetAppender, swatches []colorPickerSwatch, colors []color.Color, y int) int {
+	if len(swatches) == 0 {
+		return y
+	}
+	u := UnitSize(context)
+	gap := u / 4
+	b := context.Bounds(c)
+	size := (b.Dx() - (colorPickerMaxRecentColors-1)*gap) / colorPickerMaxRecentColors
+	for i := range swatches {
+		s := &swatches[i]
+		clr := colors[i]
+		s.color = clr
+		s.onPressed = func() {
+			c.setValue(colorToOklch(clr, c.value.H), true)
+		}
+		x := b.Min.X + (i%colorPickerMaxRecentColors)*(size+gap)
+		sy := y + (i/colorPickerMaxRecentColors)*(size+gap)
+		appender.AppendChildWidgetWithBounds(s, image.Rect(x, sy, x+size, sy+size))
+	}
+	rows := (len(swatches) + colorPickerMaxRecentColors - 1) / colorPickerMaxRecentColors
+	return y + rows*(size+gap) - gap
+}
+
+func (c *ColorPicker) Tick(context *guigui.Context) error {
+	if c.picking && !context.IsFocused(&c.eyedropper) {
+		context.SetFocused(&c.eyedropper, true)
+	}
+	return nil
+}
+
+// controlsHeight returns the height of the widgets below the plane.
+func (c *ColorPicker) controlsHeight(context *guigui.Context) int {
+	u := UnitSize(context)
+	gap := u / 4
+	h := 5*u + 4*gap
+
+	swatchCount := len(defaultColorPickerSwatches)
+	if c.swatchesSet {
+		swatchCount = len(c.swatchColors)
+	}
+	size := (context.Bounds(c).Dx() - (colorPickerMaxRecentColors-1)*gap) / colorPickerMaxRecentColors
+	for _, n := range []int{len(c.recentColors), swatchCount} {
+		if n == 0 {
+			continue
+		}
+		rows := (n + colorPickerMaxRecentColors - 1) / colorPickerMaxRecentColors
+		h += gap + rows*(size+gap) - gap
+	}
+	return h
+}
+
+func (c *ColorPicker) DefaultSize(context *guigui.Context) image.Point {
+	u := UnitSize(context)
+	return image.Pt(12*u, 22*u)
+}
+
+type colorPickerAreaType int
+
+const (
+	colorPickerAreaTypePlane colorPickerAreaType = iota
+	colorPickerAreaTypeHue
+	colorPickerAreaTypeAlpha
+)
+
+// colorPickerArea is the chroma-lightness plane or a hue or alpha strip.
+type colorPickerArea struct {
+	guigui.DefaultWidget
+
+	typ   colorPickerAreaType
+	value oklab.Oklch
+
+	onValueChanged func(value oklab.Oklch, committed bool)
+}
+
+func (c *colorPickerArea) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if context.IsEnabled(c) && context.IsWidgetHitAtCursor(c) && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !context.HasPointerCapture(c) {
+		context.SetFocused(c, true)
+		// Keep receiving the pointing input while dragging, even when the cursor is outside the area.
+		context.CapturePointer(c)
+		c.setValueFromCursor(context, false)
+		return guigui.HandleInputByWidget(c)
+	}
+
+	if !context.HasPointerCapture(c) {
+		return guigui.HandleInputResult{}
+	}
+
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		context.ReleasePointer(c)
+		c.setValueFromCursor(context, true)
+		return guigui.HandleInputResult{}
+	}
+
+	c.setValueFromCursor(context, false)
+	return guigui.HandleInputByWidget(c)
+}
+
+func (c *colorPickerArea) setValueFromCursor(context *guigui.Context, committed bool) {
+	b := context.Bounds(c)
+	if b.Empty() {
+		return
+	}
+	pt := image.Pt(ebiten.CursorPosition())
+	x := float64(pt.X-b.Min.X) / float64(b.Dx())
+	y := float64(pt.Y-b.Min.Y) / float64(b.Dy())
+	c.setValue(colorPickerAreaValue(c.typ, c.value, x, y), committed)
+}
+
+func (c *colorPickerArea) setValue(value oklab.Oklch, committed bool) {
+	c.value = normalizeOklch(value, c.value.H)
+	guigui.RequestRedraw(c)
+	if c.onValueChanged != nil {
+		c.onValueChanged(c.value, committed)
+	}
+}
+
+func (c *colorPickerArea) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsEnabled(c) {
+		return guigui.HandleInputResult{}
+	}
+	v := c.value
+	switch c.typ {
+	case colorPickerAreaTypePlane:
+		switch {
+		case isKeyRepeating(ebiten.KeyLeft):
+			v.C -= colorPickerMaxChroma / 100
+		case isKeyRepeating(ebiten.KeyRight):
+			v.C += colorPickerMaxChroma / 100
+		case isKeyRepeating(ebiten.KeyUp):
+			v.L += 0.01
+		case isKeyRepeating(ebiten.KeyDown):
+			v.L -= 0.01
+		default:
+			return guigui.HandleInputResult{}
+		}
+	case colorPickerAreaTypeHue:
+		switch {
+		case isKeyRepeating(ebiten.KeyLeft):
+			v.H -= math.Pi / 180
+		case isKeyRepeating(ebiten.KeyRight):
+			v.H += math.Pi / 180
+		default:
+			return guigui.HandleInputResult{}
+		}
+	case colorPickerAreaTypeAlpha:
+		switch {
+		case isKeyRepeating(ebiten.KeyLeft):
+			v.Alpha -= 0.01
+		case isKeyRepeating(ebiten.KeyRight):
+			v.Alpha += 0.01
+		default:
+			return guigui.HandleInputResult{}
+		}
+	}
+	c.setValue(v, true)
+	return guigui.HandleInputByWidget(c)
+}
+
+func (c *colorPickerArea) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	if c.typ == colorPickerAreaTypePlane {
+		return ebiten.CursorShapeCrosshair, true
+	}
+	return ebiten.CursorShapePointer, true
+}
+
+func (c *colorPickerArea) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(c)
+	u := UnitSize(context)
+
+	var r int
+	var thumbCenter image.Point
+	var thumbSize int
+	switch c.typ {
+	case colorPickerAreaTypePlane:
+		r = RoundedCornerRadius(context)
+		draw.DrawRoundedRect(context, dst, b, draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.9), r)
+		draw.DrawOklchPlane(context, dst, b, r, c.value.H, colorPickerMaxChroma)
+		x, y := colorPickerAreaRate(c.typ, c.value)
+		thumbCenter = image.Pt(b.Min.X+int(x*float64(b.Dx())), b.Min.Y+int(y*float64(b.Dy())))
+		thumbSize = u / 2
+	case colorPickerAreaTypeHue:
+		r = b.Dy() / 2
+		draw.DrawOklchHueStrip(context, dst, b, r, 0.75, 0.12)
+		x, _ := colorPickerAreaRate(c.typ, c.value)
+		thumbCenter = image.Pt(b.Min.X+int(x*float64(b.Dx())), (b.Min.Y+b.Max.Y)/2)
+		thumbSize = b.Dy()
+	case colorPickerAreaTypeAlpha:
+		r = b.Dy() / 2
+		opaque := c.value
+		opaque.Alpha = 1
+		draw.DrawAlphaStrip(context, dst, b, r, opaque)
+		x, _ := colorPickerAreaRate(c.typ, c.value)
+		thumbCenter = image.Pt(b.Min.X+int(x*float64(b.Dx())), (b.Min.Y+b.Max.Y)/2)
+		thumbSize = b.Dy()
+	}
+	borderClr1, borderClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset, false)
+	draw.DrawRoundedRectBorder(context, dst, b, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
+
+	thumbBounds := image.Rectangle{
+		Min: thumbCenter.Sub(image.Pt(thumbSize/2, thumbSize/2)),
+		Max: thumbCenter.Add(image.Pt(thumbSize-thumbSize/2, thumbSize-thumbSize/2)),
+	}
+	thumbWidth := float32(2 * context.Scale())
+	if context.IsFocused(c) {
+		thumbWidth = float32(3 * context.Scale())
+	}
+	draw.DrawRoundedRectBorder(context, dst, thumbBounds, color.White, color.White, thumbSize/2, thumbWidth, draw.RoundedRectBorderTypeRegular)
+	draw.DrawRoundedRectBorder(context, dst, thumbBounds, color.Black, color.Black, thumbSize/2, float32(1*context.Scale()), draw.RoundedRectBorderTypeRegular)
+}
+
+// colorPickerAreaValue returns value updated with the rates of the position in the area.
+func colorPickerAreaValue(typ colorPickerAreaType, value oklab.Oklch, x, y float64) oklab.Oklch {
+	x = min(max(x, 0), 1)
+	y = min(max(y, 0), 1)
+	switch typ {
+	case colorPickerAreaTypePlane:
+		value.C = x * colorPickerMaxChroma
+		value.L = 1 - y
+	case colorPickerAreaTypeHue:
+		value.H = x * 2 * math.Pi
+	case colorPickerAreaTypeAlpha:
+		value.Alpha = x
+	}
+	return value
+}
+
+// colorPickerAreaRate returns the rates of the position in the area for value.
+func colorPickerAreaRate(typ colorPickerAreaType, value oklab.Oklch) (x, y float64) {
+	switch typ {
+	case colorPickerAreaTypePlane:
+		return value.C / colorPickerMaxChroma, 1 - value.L
+	case colorPickerAreaTypeHue:
+		return value.H / (2 * math.Pi), 0
+	case colorPickerAreaTypeAlpha:
+		return value.Alpha, 0
+	}
+	return 0, 0
+}
+
+type colorPickerSwatch struct {
+	guigui.DefaultWidget
+
+	color       color.Color
+	prevHovered bool
+
+	onPressed func()
+}
+
+func (c *colorPickerSwatch) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if hovered := c.isHovered(context); c.prevHovered != hovered {
+		c.prevHovered = hovered
+		guigui.RequestRedraw(c)
+	}
+	if c.onPressed == nil || !c.isHovered(context) {
+		return guigui.HandleInputResult{}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		c.onPressed()
+		return guigui.HandleInputByWidget(c)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (c *colorPickerSwatch) isHovered(context *guigui.Context) bool {
+	return context.IsEnabled(c) && context.IsWidgetHitAtCursor(c)
+}
+
+func (c *colorPickerSwatch) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	if c.onPressed != nil {
+		return ebiten.CursorShapePointer, true
+	}
+	return 0, false
+}
+
+func (c *colorPickerSwatch) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(c)
+	r := RoundedCornerRadius(context) / 2
+	draw.DrawColorOverCheckerboard(context, dst, b, r, c.color)
+	if c.onPressed != nil && c.isHovered(context) {
+		clr := draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5)
+		draw.DrawRoundedRectBorder(context, dst, b, clr, clr, r, float32(2*context.Scale()), draw.RoundedRectBorderTypeRegular)
+		return
+	}
+	borderClr1, borderClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset, false)
+	draw.DrawRoundedRectBorder(context, dst, b, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
+}
+
+// colorPickerEyedropper covers the app while picking a color from the screen.
+type colorPickerEyedropper struct {
+	guigui.DefaultWidget
+
+	cursor   image.Point
+	color    color.Color
+	sampling bool
+	picked   bool
+
+	// onPicked is called with the picked color, or nil when picking is canceled.
+	onPicked func(clr color.Color)
+}
+
+func (c *colorPickerEyedropper) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		// The color is picked at Tick when the color at the cursor is sampled.
+		c.picked = true
+		return guigui.HandleInputByWidget(c)
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		c.onPicked(nil)
+		return guigui.HandleInputByWidget(c)
+	}
+	// Block the inputs to the widgets under the eyedropper.
+	return guigui.HandleInputByWidget(c)
+}
+
+func (c *colorPickerEyedropper) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		c.onPicked(nil)
+		return guigui.HandleInputByWidget(c)
+	}
+	return guigui.HandleInputResult{}
+}
+
+// Tick requests to sample the color at the cursor only when the cursor is moved, as reading the screen is expensive.
+func (c *colorPickerEyedropper) Tick(context *guigui.Context) error {
+	if cursor := image.Pt(ebiten.CursorPosition()); cursor != c.cursor || c.color == nil && !c.sampling {
+		c.cursor = cursor
+		c.sampling = true
+		context.RequestScreenColor(cursor)
+	}
+	if c.sampling {
+		clr, ok := context.ScreenColor(c.cursor)
+		if !ok {
+			return nil
+		}
+		c.sampling = false
+		c.color = clr
+		guigui.RequestRedraw(c)
+	}
+	if c.picked {
+		c.picked = false
+		c.onPicked(c.color)
+	}
+	return nil
+}
+
+func (c *colorPickerEyedropper) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	return ebiten.CursorShapeCrosshair, true
+}
+
+func (c *colorPickerEyedropper) Draw(context *guigui.Context, dst *ebiten.Image) {
+	if c.color == nil {
+		return
+	}
+	u := UnitSize(context)
+	size := 3 * u / 2
+	// Put the preview apart from the cursor so that the preview itself is not picked.
+	pos := c.cursor.Add(image.Pt(u/2, u/2))
+	ab := context.AppBounds()
+	if pos.X+size > ab.Max.X {
+		pos.X = c.cursor.X - u/2 - size
+	}
+	if pos.Y+size > ab.Max.Y {
+		pos.Y = c.cursor.Y - u/2 - size
+	}
+	b := image.Rectangle{Min: pos, Max: pos.Add(image.Pt(size, size))}
+	r := RoundedCornerRadius(context)
+	shadowBounds := b.Inset(-int(8 * context.Scale()))
+	draw.DrawRoundedShadowRect(context, dst, shadowBounds, draw.ScaleAlpha(color.Black, 0.2), int(8*context.Scale())+r)
+	draw.DrawRoundedRect(context, dst, b, c.color, r)
+	clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset, false)
+	draw.DrawRoundedRectBorder(context, dst, b, clr1, clr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeOutset)
+}
+
+// ZDelta makes the eyedropper above popups so that colors can be picked from them.
+func (c *colorPickerEyedropper) ZDelta() int {
+	return tooltipZ
+}
+
+// normalizeOklch clamps the components of value, and keeps hue when value is achromatic.
+func normalizeOklch(value oklab.Oklch, hue float64) oklab.Oklch {
+	if math.IsNaN(value.H) {
+		value.H = hue
+	}
+	if math.IsNaN(value.H) {
+		value.H = 0
+	}
+	value.H = math.Mod(value.H, 2*math.Pi)
+	if value.H < 0 {
+		value.H += 2 * math.Pi
+	}
+	value.L = min(max(value.L, 0), 1)
+	value.C = min(max(value.C, 0), colorPickerMaxChroma)
+	value.Alpha = min(max(value.Alpha, 0), 1)
+	return value
+}
+
+// colorToOklch converts clr to OKLCH.
+// hue is used when clr is achromatic.
+func colorToOklch(clr color.Color, hue float64) oklab.Oklch {
+	if c, ok := clr.(oklab.Oklch); ok {
+		return normalizeOklch(c, hue)
+	}
+	// Convert the opaque color, as the conversion of a transparent color loses its color components.
+	n := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	alpha := float64(n.A) / 0xff
+	n.A = 0xff
+	v := oklab.OklchModel.Convert(n).(oklab.Oklch)
+	v.Alpha = alpha
+	// Treat a tiny chroma as achromatic, as the hue of a gray is unstable.
+	if v.C < 1e-4 {
+		v.C = 0
+		v.H = math.NaN()
+	}
+	return normalizeOklch(v, hue)
+}
+
+func oklchToNRGBA(value oklab.Oklch) color.NRGBA {
+	opaque := value
+	opaque.Alpha = 1
+	r, g, b, _ := opaque.RGBA()
+	return color.NRGBA{
+		R: uint8((r*0xff + 0x7fff) / 0xffff),
+		G: uint8((g*0xff + 0x7fff) / 0xffff),
+		B: uint8((b*0xff + 0x7fff) / 0xffff),
+		A: uint8(math.Round(min(max(value.Alpha, 0), 1) * 0xff)),
+	}
+}
+
+func hueInDegrees(value oklab.Oklch) int64 {
+	return int64(math.Round(value.H*180/math.Pi)) % 360
+}
+
+func alphaInPercent(value oklab.Oklch) int64 {
+	return int64(math.Round(value.Alpha * 100))
+}
+
+// parseHexColor parses a color in the form of #RGB, #RGBA, #RRGGBB or #RRGGBBAA.
+// The leading # is optional.
+func parseHexColor(str string) (color.NRGBA, bool) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "#")
+	var digits []uint8
+	for _, r := range str {
+		d, err := strconv.ParseUint(string(r), 16, 8)
+		if err != nil {
+			return color.NRGBA{}, false
+		}
+		digits = append(digits, uint8(d))
+	}
+	clr := color.NRGBA{A: 0xff}
+	cs := []*uint8{&clr.R, &clr.G, &clr.B, &clr.A}
+	switch len(digits) {
+	case 3, 4:
+		for i, d := range digits {
+			*cs[i] = d<<4 | d
+		}
+	case 6, 8:
+		for i := 0; i < len(digits); i += 2 {
+			*cs[i/2] = digits[i]<<4 | digits[i+1]
+		}
+	default:
+		return color.NRGBA{}, false
+	}
+	return clr, true
+}
+
+// formatHexColor formats clr as #RRGGBB, or #RRGGBBAA if clr is not opaque.
+func formatHexColor(clr color.NRGBA) string {
+	if clr.A == 0xff {
+		return fmt.Sprintf("#%02X%02X%02X", clr.R, clr.G, clr.B)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", clr.R, clr.G, clr.B, clr.A)
+}
+
+// parseOklchColor parses a color in the CSS oklch() notation, e.g. oklch(62.8% 0.258 29.2 / 50%).
+// The lightness and the alpha can be either a percentage or a number, and the hue is in degrees.
+// The oklch() wrapper is optional.
+func parseOklchColor(str string) (oklab.Oklch, bool) {
+	str = strings.TrimSpace(str)
+	if s, ok := strings.CutPrefix(strings.ToLower(str), "oklch("); ok {
+		s, ok = strings.CutSuffix(s, ")")
+		if !ok {
+			return oklab.Oklch{}, false
+		}
+		str = s
+	}
+
+	alphaStr := "1"
+	if s, a, ok := strings.Cut(str, "/"); ok {
+		str = s
+		alphaStr = strings.TrimSpace(a)
+	}
+	fields := strings.Fields(strings.ReplaceAll(str, ",", " "))
+	if len(fields) != 3 {
+		return oklab.Oklch{}, false
+	}
+
+	l, ok := parseNumberOrPercentage(fields[0], 1)
+	if !ok {
+		return oklab.Oklch{}, false
+	}
+	// In CSS, 100% of the chroma is 0.4.
+	c, ok := parseNumberOrPercentage(fields[1], 0.4)
+	if !ok {
+		return oklab.Oklch{}, false
+	}
+	h, err := strconv.ParseFloat(strings.TrimSuffix(fields[2], "deg"), 64)
+	if err != nil || math.IsNaN(h) || math.IsInf(h, 0) {
+		return oklab.Oklch{}, false
+	}
+	a, ok := parseNumberOrPercentage(alphaStr, 1)
+	if !ok {
+		return oklab.Oklch{}, false
+	}
+	return normalizeOklch(oklab.Oklch{
+		L:     l,
+		C:     c,
+		H:     h * math.Pi / 180,
+		Alpha: a,
+	}, 0), true
+}
+
+// parseNumberOrPercentage parses a number, or a percentage where 100% is hundredPercent.
+func parseNumberOrPercentage(str string, hundredPercent float64) (float64, bool) {
+	scale := 1.0
+	if s, ok := strings.CutSuffix(str, "%"); ok {
+		str = s
+		scale = hundredPercent / 100
+	}
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v * scale, true
+}
+
+// formatOklchColor formats value in the CSS oklch() notation.
+func formatOklchColor(value oklab.Oklch) string {
+	h := math.Mod(value.H*180/math.Pi, 360)
+	if math.IsNaN(h) {
+		h = 0
+	}
+	str := fmt.Sprintf("oklch(%s%% %s %s", formatColorComponent(value.L*100, 1), formatColorComponent(value.C, 3), formatColorComponent(h, 1))
+	if value.Alpha < 1 {
+		str += fmt.Sprintf(" / %s%%", formatColorComponent(value.Alpha*100, 0))
+	}
+	return str + ")"
+}
+
+// formatColorComponent formats v with at most prec digits after the decimal point, without trailing zeros.
+func formatColorComponent(v float64, prec int) string {
+	str := strconv.FormatFloat(v, 'f', prec, 64)
+	if strings.Contains(str, ".") {
+		str = strings.TrimRight(str, "0")
+		str = strings.TrimSuffix(str, ".")
+	}
+	if str == "-0" {
+		str = "0"
+	}
+	return str
+}
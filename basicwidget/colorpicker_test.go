@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/oklab"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func TestParseHexColor(t *testing.T) {
+	testCases := []struct {
+		In   string
+		Want color.NRGBA
+		OK   bool
+	}{
+		{In: "#FF8000", Want: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0xff}, OK: true},
+		{In: "ff8000", Want: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0xff}, OK: true},
+		{In: " #ff800080 ", Want: color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0x80}, OK: true},
+		{In: "#f80", Want: color.NRGBA{R: 0xff, G: 0x88, B: 0x00, A: 0xff}, OK: true},
+		{In: "#f808", Want: color.NRGBA{R: 0xff, G: 0x88, B: 0x00, A: 0x88}, OK: true},
+		{In: "", OK: false},
+		{In: "#ff80", OK: true, Want: color.NRGBA{R: 0xff, G: 0xff, B: 0x88, A: 0x00}},
+		{In: "#ff800", OK: false},
+		{In: "#gg8000", OK: false},
+		{In: "#+f8000", OK: false},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.ParseHexColor(tc.In)
+		if ok != tc.OK {
+			t.Errorf("ParseHexColor(%q): ok: got: %v, want: %v", tc.In, ok, tc.OK)
+			continue
+		}
+		if ok && got != tc.Want {
+			t.Errorf("ParseHexColor(%q): got: %v, want: %v", tc.In, got, tc.Want)
+		}
+	}
+}
+
+func TestFormatHexColor(t *testing.T) {
+	if got, want := basicwidget.FormatHexColor(color.NRGBA{R: 0x12, G: 0xab, B: 0x00, A: 0xff}), "#12AB00"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := basicwidget.FormatHexColor(color.NRGBA{R: 0x12, G: 0xab, B: 0x00, A: 0x80}), "#12AB0080"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestParseOklchColor(t *testing.T) {
+	testCases := []struct {
+		In   string
+		Want oklab.Oklch
+		OK   bool
+	}{
+		{In: "oklch(62.8% 0.258 29.2)", Want: oklab.Oklch{L: 0.628, C: 0.258, H: 29.2 * math.Pi / 180, Alpha: 1}, OK: true},
+		{In: "OKLCH(0.5 0.1 180deg / 50%)", Want: oklab.Oklch{L: 0.5, C: 0.1, H: math.Pi, Alpha: 0.5}, OK: true},
+		{In: "50% 25% 0 / 0.25", Want: oklab.Oklch{L: 0.5, C: 0.1, H: 0, Alpha: 0.25}, OK: true},
+		{In: "oklch(50% 0.1 -90)", Want: oklab.Oklch{L: 0.5, C: 0.1, H: 1.5 * math.Pi, Alpha: 1}, OK: true},
+		{In: "oklch(150% 0.1 0)", Want: oklab.Oklch{L: 1, C: 0.1, H: 0, Alpha: 1}, OK: true},
+		{In: "oklch(50% 0.1)", OK: false},
+		{In: "oklch(50% 0.1 0", OK: false},
+		{In: "oklch(50% abc 0)", OK: false},
+		{In: "oklch(50% 0.1 NaN)", OK: false},
+	}
+	for _, tc := range testCases {
+		got, ok := basicwidget.ParseOklchColor(tc.In)
+		if ok != tc.OK {
+			t.Errorf("ParseOklchColor(%q): ok: got: %v, want: %v", tc.In, ok, tc.OK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		const eps = 1e-9
+		if math.Abs(got.L-tc.Want.L) > eps || math.Abs(got.C-tc.Want.C) > eps || math.Abs(got.H-tc.Want.H) > eps || math.Abs(got.Alpha-tc.Want.Alpha) > eps {
+			t.Errorf("ParseOklchColor(%q): got: %+v, want: %+v", tc.In, got, tc.Want)
+		}
+	}
+}
+
+func TestFormatOklchColor(t *testing.T) {
+	testCases := []struct {
+		In   oklab.Oklch
+		Want string
+	}{
+		{In: oklab.Oklch{L: 0.628, C: 0.2577, H: 29.23 * math.Pi / 180, Alpha: 1}, Want: "oklch(62.8% 0.258 29.2)"},
+		{In: oklab.Oklch{L: 1, C: 0, H: 0, Alpha: 0.5}, Want: "oklch(100% 0 0 / 50%)"},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.FormatOklchColor(tc.In); got != tc.Want {
+			t.Errorf("FormatOklchColor(%+v): got: %q, want: %q", tc.In, got, tc.Want)
+		}
+		if _, ok := basicwidget.ParseOklchColor(tc.Want); !ok {
+			t.Errorf("ParseOklchColor(%q) failed", tc.Want)
+		}
+	}
+}
+
+func TestColorPickerValue(t *testing.T) {
+	var c basicwidget.ColorPicker
+	var changed []color.Color
+	c.SetOnValueChanged(func(value color.Color) {
+		changed = append(changed, value)
+	})
+
+	// Round trips of sRGB colors must be stable, including transparent ones.
+	for _, clr := range []color.NRGBA{
+		{R: 0xff, G: 0x80, B: 0x00, A: 0xff},
+		{R: 0x12, G: 0x34, B: 0x56, A: 0x80},
+		{R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+		{R: 0x00, G: 0xff, B: 0x00, A: 0x00},
+	} {
+		c.SetValue(clr)
+		if got := basicwidget.OklchToNRGBA(c.ValueOklch()); got != clr {
+			t.Errorf("SetValue(%v): got: %v", clr, got)
+		}
+	}
+	if got, want := len(changed), 4; got != want {
+		t.Errorf("len(changed): got: %d, want: %d", got, want)
+	}
+
+	// Setting the same color again must not fire the callback.
+	c.SetValue(color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0x00})
+	if got, want := len(changed), 4; got != want {
+		t.Errorf("len(changed) after setting the same value: got: %d, want: %d", got, want)
+	}
+
+	// The hue is kept for achromatic colors.
+	c.SetValueOklch(oklab.Oklch{L: 0.5, C: 0.1, H: 1, Alpha: 1})
+	c.SetValue(color.Gray{Y: 0x80})
+	if got := c.ValueOklch(); got.C != 0 || got.H != 1 {
+		t.Errorf("SetValue(gray): got: %+v, want the chroma 0 and the hue 1", got)
+	}
+}
+
+func TestColorPickerRecentColors(t *testing.T) {
+	var c basicwidget.ColorPicker
+	var colors []color.Color
+	for i := range 20 {
+		colors = append(colors, color.Gray{Y: uint8(i)})
+	}
+	c.SetRecentColors(colors)
+	got := c.AppendRecentColors(nil)
+	if len(got) == 0 || len(got) >= len(colors) {
+		t.Fatalf("len(AppendRecentColors): got: %d, want: 1 to %d", len(got), len(colors)-1)
+	}
+	for i := range got {
+		if got[i] != colors[i] {
+			t.Errorf("AppendRecentColors()[%d]: got: %v, want: %v", i, got[i], colors[i])
+		}
+	}
+}
@@ -0,0 +1,338 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+type ComboBoxItem[T comparable] struct {
+	Text     string
+	Disabled bool
+	ID       T
+}
+
+// ComboBox is a text input with a list of suggestions.
+//
+// By default, the suggestions are the items set by SetItems containing the text case-insensitively.
+// Use SetOnSuggestionsRequested and SetSuggestions to provide suggestions in other ways, e.g. asynchronously.
+type ComboBox[T comparable] struct {
+	guigui.DefaultWidget
+
+	textInput TextInput
+	popup     Popup
+	list      List[T]
+
+	items       []ComboBoxItem[T]
+	suggestions []ComboBoxItem[T]
+	listItems   []ListItem[T]
+
+	// query is the text for which the suggestions are requested.
+	query string
+
+	// value is the last committed text.
+	value string
+
+	restricted   bool
+	openRequired bool
+
+	onSuggestionsRequested func(query string)
+	onItemSelected         func(item ComboBoxItem[T])
+	onValueChanged         func(text string, committed bool)
+}
+
+// SetItems sets the items used as the suggestions by default.
+// In the restricted mode, the text must be one of the items or the suggestions.
+func (c *ComboBox[T]) SetItems(items []ComboBoxItem[T]) {
+	c.items = adjustSliceSize(c.items, len(items))
+	copy(c.items, items)
+}
+
+// SetRestricted sets whether the text is restricted to the items and the suggestions.
+// In the restricted mode, a committed text not matching any items is reverted to the previous value.
+func (c *ComboBox[T]) SetRestricted(restricted bool) {
+	c.restricted = restricted
+}
+
+// SetOnSuggestionsRequested sets the function called when the suggestions for the text are required.
+// The function should provide the suggestions by SetSuggestions, possibly later.
+// If the function is not set, the items set by SetItems containing the text are suggested.
+func (c *ComboBox[T]) SetOnSuggestionsRequested(f func(query string)) {
+	c.onSuggestionsRequested = f
+}
+
+// SetSuggestions sets the suggestions for query.
+// SetSuggestions does nothing if query is different from the current text, as the suggestions are outdated.
+func (c *ComboBox[T]) SetSuggestions(query string, suggestions []ComboBoxItem[T]) {
+	if query != c.query {
+		return
+	}
+	c.setSuggestions(suggestions)
+}
+
+func (c *ComboBox[T]) SetOnItemSelected(f func(item ComboBoxItem[T])) {
+	c.onItemSelected = f
+}
+
+func (c *ComboBox[T]) SetOnValueChanged(f func(text string, committed bool)) {
+	c.onValueChanged = f
+}
+
+func (c *ComboBox[T]) Value() string {
+	return c.textInput.Value()
+}
+
+func (c *ComboBox[T]) SetValue(text string) {
+	c.value = text
+	c.textInput.SetValue(text)
+}
+
+func (c *ComboBox[T]) IsPopupOpen() bool {
+	return c.popup.IsOpen()
+}
+
+func (c *ComboBox[T]) requestSuggestions(query string) {
+	c.query = query
+	if c.onSuggestionsRequested != nil {
+		c.onSuggestionsRequested(query)
+		return
+	}
+	var suggestions []ComboBoxItem[T]
+	for _, item := range c.items {
+		if _, _, ok := foldedIndex(item.Text, query); ok {
+			suggestions = append(suggestions, item)
+		}
+	}
+	c.setSuggestions(suggestions)
+}
+
+func (c *ComboBox[T]) setSuggestions(suggestions []ComboBoxItem[T]) {
+	c.suggestions = adjustSliceSize(c.suggestions, len(suggestions))
+	copy(c.suggestions, suggestions)
+
+	c.listItems = adjustSliceSize(c.listItems, len(suggestions))
+	for i, s := range suggestions {
+		c.listItems[i] = ListItem[T]{
+			Text:     s.Text,
+			Disabled: s.Disabled,
+			ID:       s.ID,
+		}
+	}
+	c.list.SetItems(c.listItems)
+	for i, s := range suggestions {
+		start, end, _ := foldedIndex(s.Text, c.query)
+		c.list.listItemWidget(i).text.setHighlight(start, end)
+	}
+	c.list.SelectItemByIndex(-1)
+	c.list.list.setKeyboardHighlightIndex(-1)
+
+	// Open the popup at Tick, where the focus is known.
+	c.openRequired = len(suggestions) > 0
+	if len(suggestions) == 0 {
+		c.popup.Close()
+	}
+	guigui.RequestRedraw(c)
+}
+
+func (c *ComboBox[T]) selectSuggestion(context *guigui.Context, index int) {
+	if index < 0 || index >= len(c.suggestions) {
+		return
+	}
+	item := c.suggestions[index]
+	c.textInput.ForceSetValue(item.Text)
+	c.query = item.Text
+	c.value = item.Text
+	c.openRequired = false
+	c.popup.Close()
+	context.SetFocused(&c.textInput, true)
+	if c.onItemSelected != nil {
+		c.onItemSelected(item)
+	}
+	if c.onValueChanged != nil {
+		c.onValueChanged(item.Text, true)
+	}
+}
+
+// matchedText returns the text of the item or the suggestion matching text case-insensitively.
+func (c *ComboBox[T]) matchedText(text string) (string, bool) {
+	for _, items := range [][]ComboBoxItem[T]{c.suggestions, c.items} {
+		for _, item := range items {
+			if !item.Disabled && strings.EqualFold(item.Text, text) {
+				return item.Text, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (c *ComboBox[T]) commit(text string) {
+	if c.restricted {
+		matched, ok := c.matchedText(text)
+		if !ok {
+			// Revert the text.
+			c.textInput.ForceSetValue(c.value)
+			return
+		}
+		if matched != text {
+			c.textInput.ForceSetValue(matched)
+		}
+		text = matched
+	}
+	c.value = text
+	if c.onValueChanged != nil {
+		c.onValueChanged(text, true)
+	}
+}
+
+func (c *ComboBox[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	c.textInput.SetOnValueChanged(func(text string, committed bool) {
+		if committed {
+			c.commit(text)
+			return
+		}
+		c.requestSuggestions(text)
+		if c.onValueChanged != nil {
+			c.onValueChanged(text, false)
+		}
+	})
+	c.textInput.setKeyHandler(func(context *guigui.Context) bool {
+		return c.handleKeyInput(context)
+	})
+	appender.AppendChildWidgetWithBounds(&c.textInput, context.Bounds(c))
+
+	c.list.SetStyle(ListStyleMenu)
+	c.list.SetOnItemSelected(func(index int) {
+		c.selectSuggestion(context, index)
+	})
+	c.popup.modeless = true
+	c.popup.SetContent(&c.list)
+	bounds := c.popupBounds(context)
+	context.SetSize(&c.list, bounds.Size())
+	appender.AppendChildWidgetWithBounds(&c.popup, bounds)
+
+	return nil
+}
+
+// Tick opens the suggestions when they are requested, and closes them when the focus leaves the combo box.
+func (c *ComboBox[T]) Tick(context *guigui.Context) error {
+	focused := context.IsFocusedOrHasFocusedChild(c)
+	if c.openRequired && focused {
+		c.popup.Open(context)
+		c.openRequired = false
+	}
+	if !focused {
+		c.openRequired = false
+		c.popup.Close()
+	}
+	return nil
+}
+
+// popupBounds returns the bounds of the suggestions below the text input, or above it if there is not enough space.
+func (c *ComboBox[T]) popupBounds(context *guigui.Context) image.Rectangle {
+	bounds := context.Bounds(c)
+	s := c.list.DefaultSize(context)
+	s.X = max(s.X, bounds.Dx())
+	s.Y = min(s.Y, 8*UnitSize(context))
+
+	as := context.AppBounds()
+	r := image.Rectangle{
+		Min: image.Pt(bounds.Min.X, bounds.Max.Y),
+		Max: image.Pt(bounds.Min.X+s.X, bounds.Max.Y+s.Y),
+	}
+	if r.Max.Y > as.Max.Y && bounds.Min.Y-s.Y >= as.Min.Y {
+		r.Min.Y = bounds.Min.Y - s.Y
+		r.Max.Y = bounds.Min.Y
+	}
+	if r.Max.X > as.Max.X {
+		r = r.Add(image.Pt(as.Max.X-r.Max.X, 0))
+	}
+	if r.Min.X < as.Min.X {
+		r = r.Add(image.Pt(as.Min.X-r.Min.X, 0))
+	}
+	return r
+}
+
+// handleKeyInput moves the highlight in the suggestions by the up and down keys, and selects the highlighted suggestion by Enter.
+func (c *ComboBox[T]) handleKeyInput(context *guigui.Context) bool {
+	b := &c.list.list
+	switch {
+	case isKeyRepeating(ebiten.KeyDown) || isKeyRepeating(ebiten.KeyUp):
+		if !c.popup.IsOpen() {
+			c.requestSuggestions(c.textInput.Value())
+			return true
+		}
+		current := b.currentItemIndex(context)
+		dir := 1
+		if isKeyRepeating(ebiten.KeyUp) {
+			dir = -1
+			if current < 0 {
+				current = b.abstractList.ItemCount()
+			}
+		}
+		if next := b.nextSelectableIndex(current, dir, true); next >= 0 {
+			b.moveCurrentItemIndex(context, next, false)
+		}
+		return true
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		if !c.popup.IsOpen() {
+			return false
+		}
+		index := b.keyboardHighlightIndexPlus1 - 1
+		if index < 0 {
+			return false
+		}
+		c.selectSuggestion(context, index)
+		return true
+	}
+	return false
+}
+
+// HandleBack implements guigui.BackHandler.
+// HandleBack closes the suggestions.
+func (c *ComboBox[T]) HandleBack(context *guigui.Context) bool {
+	if !c.popup.IsOpen() {
+		return false
+	}
+	c.openRequired = false
+	c.popup.Close()
+	return true
+}
+
+func (c *ComboBox[T]) DefaultSize(context *guigui.Context) image.Point {
+	return c.textInput.DefaultSize(context)
+}
+
+// foldedIndex returns the range of the first substring of s that equals to substr under Unicode case-folding.
+func foldedIndex(s, substr string) (start, end int, ok bool) {
+	if substr == "" {
+		return 0, 0, true
+	}
+	for i := range s {
+		j := i
+		matched := true
+		for _, r := range substr {
+			if j >= len(s) {
+				matched = false
+				break
+			}
+			r2, size := utf8.DecodeRuneInString(s[j:])
+			if r != r2 && !strings.EqualFold(string(r), string(r2)) {
+				matched = false
+				break
+			}
+			j += size
+		}
+		if matched {
+			return i, j, true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func TestFoldedIndex(t *testing.T) {
+	testCases := []struct {
+		s      string
+		substr string
+		start  int
+		end    int
+		ok     bool
+	}{
+		{s: "Apple", substr: "", start: 0, end: 0, ok: true},
+		{s: "Apple", substr: "app", start: 0, end: 3, ok: true},
+		{s: "Pineapple", substr: "APPLE", start: 4, end: 9, ok: true},
+		{s: "Banana", substr: "nan", start: 2, end: 5, ok: true},
+		{s: "Banana", substr: "nab", ok: false},
+		{s: "Ban", substr: "Banana", ok: false},
+		{s: "Straße", substr: "SSE", ok: false},
+		{s: "Ärger", substr: "är", start: 0, end: 3, ok: true},
+		{s: "Καλημέρα", substr: "ΜΈΡΑ", start: 8, end: 16, ok: true},
+	}
+	for _, tc := range testCases {
+		start, end, ok := basicwidget.FoldedIndex(tc.s, tc.substr)
+		if start != tc.start || end != tc.end || ok != tc.ok {
+			t.Errorf("FoldedIndex(%q, %q): got: (%d, %d, %t), want: (%d, %d, %t)", tc.s, tc.substr, start, end, ok, tc.start, tc.end, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+type ContextMenuItem struct {
+	Text     string
+	Icon     *ebiten.Image
+	Disabled bool
+	Checked  bool
+
+	// Radio reports whether the item is one of the exclusive choices.
+	Radio bool
+
+	// Border is a separator line between items.
+	Border bool
+
+	// Shortcut is a hint text of a keyboard shortcut, shown at the end of the item.
+	Shortcut string
+
+	// OnSelected is called when the item is selected.
+	OnSelected func()
+
+	// Submenu is the items of the submenu.
+	Submenu []ContextMenuItem
+}
+
+// ContextMenuProvider is implemented by widgets that have a context menu.
+//
+// AppendContextMenuItems appends the context menu items to items and returns the result.
+// If no items are appended, the context menu of the widget's ancestors is used instead.
+//
+// The menu is opened by clicking the right button on the widget, long-pressing it, or pressing the Menu key or Shift+F10 while it is focused.
+type ContextMenuProvider interface {
+	AppendContextMenuItems(context *guigui.Context, items []ContextMenuItem) []ContextMenuItem
+}
+
+// contextMenuPositioner is implemented by widgets that specify the position of the context menu opened by a keyboard.
+type contextMenuPositioner interface {
+	contextMenuPosition(context *guigui.Context) image.Point
+}
+
+func contextMenuLongPressTicks() int {
+	return ebiten.TPS() / 2
+}
+
+func init() {
+	guigui.RegisterOverlay(func() guigui.Widget {
+		return &contextMenu{}
+	})
+}
+
+// contextMenu opens the context menu of a ContextMenuProvider widget.
+//
+// The context menu is opened at the pointer by clicking the right button or long-pressing a touch,
+// or at the focused widget by the Menu key or Shift+F10.
+// The menu is clamped to the app bounds.
+//
+// contextMenu is hosted by every app as an overlay, so apps don't have to append it.
+type contextMenu struct {
+	guigui.DefaultWidget
+
+	popupMenu PopupMenu[int]
+
+	items    []ContextMenuItem
+	target   guigui.Widget
+	position image.Point
+
+	touchID      ebiten.TouchID
+	touching     bool
+	touchDone    bool
+	touchStart   image.Point
+	touchCount   int
+	tmpTouchIDs  []ebiten.TouchID
+	tmpWidgets   []guigui.Widget
+	tmpMenuItems []PopupMenuItem[int]
+
+	// flatItems is the items including the submenu items. The ID of a menu item is the index in flatItems.
+	flatItems []ContextMenuItem
+}
+
+func (c *contextMenu) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	c.popupMenu.SetOnMenuItemSelected(func(item PopupMenuItem[int]) {
+		index := item.ID
+		if index < 0 || index >= len(c.flatItems) {
+			return
+		}
+		// Give the focus back before running the command, as the command might depend on the focus.
+		if c.target != nil {
+			context.SetFocused(c.target, true)
+		}
+		if f := c.flatItems[index].OnSelected; f != nil {
+			f()
+		}
+	})
+	c.popupMenu.SetOnClosed(func(reason PopupClosedReason) {
+		if reason == PopupClosedReasonReopen {
+			return
+		}
+		if c.target != nil {
+			context.SetFocused(c.target, true)
+		}
+	})
+	appender.AppendChildWidgetWithPosition(&c.popupMenu, c.position)
+	return nil
+}
+
+func (c *contextMenu) open(context *guigui.Context, target guigui.Widget, items []ContextMenuItem, position image.Point) {
+	c.items = items
+	c.target = target
+	c.position = position
+
+	c.flatItems = c.flatItems[:0]
+	c.tmpMenuItems = c.appendMenuItems(c.tmpMenuItems[:0], items)
+	c.popupMenu.SetItems(c.tmpMenuItems)
+	context.SetPosition(&c.popupMenu, position)
+	c.popupMenu.Open(context)
+}
+
+// appendMenuItems appends the menu items converted from items and their submenus to menuItems.
+func (c *contextMenu) appendMenuItems(menuItems []PopupMenuItem[int], items []ContextMenuItem) []PopupMenuItem[int] {
+	for _, item := range items {
+		menuItem := PopupMenuItem[int]{
+			Text:     item.Text,
+			Disabled: item.Disabled,
+			Border:   item.Border,
+			Checked:  item.Checked,
+			Radio:    item.Radio,
+			Icon:     item.Icon,
+			Shortcut: item.Shortcut,
+			ID:       len(c.flatItems),
+		}
+		c.flatItems = append(c.flatItems, item)
+		if len(item.Submenu) > 0 {
+			menuItem.Submenu = c.appendMenuItems(nil, item.Submenu)
+		}
+		menuItems = append(menuItems, menuItem)
+	}
+	return menuItems
+}
+
+// providerAt returns the top-most provider at the point and its items.
+func (c *contextMenu) providerAt(context *guigui.Context, point image.Point) (guigui.Widget, []ContextMenuItem) {
+	c.tmpWidgets = context.AppendWidgetsAt(c.tmpWidgets[:0], point)
+	for _, widget := range c.tmpWidgets {
+		switch widget := widget.(type) {
+		case *popupContent:
+			// The point is on a popup without any providers.
+			return nil, nil
+		case *popupBackground:
+			// A popup closed by clicking outside doesn't block the widgets behind it.
+			if !widget.popup.closeByClickingOutside {
+				return nil, nil
+			}
+			continue
+		}
+		if items := c.itemsOf(context, widget); len(items) > 0 {
+			return widget, items
+		}
+	}
+	return nil, nil
+}
+
+// focusedProvider returns the provider of the focused widget or its nearest ancestor, and its items.
+func (c *contextMenu) focusedProvider(context *guigui.Context) (guigui.Widget, []ContextMenuItem) {
+	for widget := context.FocusedWidget(); widget != nil; widget = context.Parent(widget) {
+		if items := c.itemsOf(context, widget); len(items) > 0 {
+			return widget, items
+		}
+	}
+	return nil, nil
+}
+
+func (c *contextMenu) itemsOf(context *guigui.Context, widget guigui.Widget) []ContextMenuItem {
+	p, ok := widget.(ContextMenuProvider)
+	if !ok {
+		return nil
+	}
+	if !context.IsVisible(widget) || !context.IsEnabled(widget) {
+		return nil
+	}
+	return p.AppendContextMenuItems(context, c.items[:0])
+}
+
+func (c *contextMenu) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		pt := image.Pt(ebiten.CursorPosition())
+		if target, items := c.providerAt(context, pt); target != nil {
+			c.open(context, target, items, pt)
+			return guigui.HandleInputByWidget(c)
+		}
+	}
+
+	if pt, ok := c.detectLongPress(context); ok {
+		if target, items := c.providerAt(context, pt); target != nil {
+			c.open(context, target, items, pt)
+			return guigui.HandleInputByWidget(c)
+		}
+	}
+
+	return guigui.HandleInputResult{}
+}
+
+// detectLongPress reports whether a single touch has been kept pressed without moving, and returns its position.
+func (c *contextMenu) detectLongPress(context *guigui.Context) (image.Point, bool) {
+	c.tmpTouchIDs = ebiten.AppendTouchIDs(c.tmpTouchIDs[:0])
+	if len(c.tmpTouchIDs) != 1 {
+		c.touching = false
+		return image.Point{}, false
+	}
+
+	id := c.tmpTouchIDs[0]
+	pt := image.Pt(ebiten.TouchPosition(id))
+	if !c.touching || c.touchID != id {
+		c.touching = true
+		c.touchDone = false
+		c.touchID = id
+		c.touchStart = pt
+		c.touchCount = 0
+		return image.Point{}, false
+	}
+	if c.touchDone {
+		return image.Point{}, false
+	}
+
+	// Moving a touch is not a long press but a drag.
+	slop := UnitSize(context) / 4
+	if d := pt.Sub(c.touchStart); d.X*d.X+d.Y*d.Y > slop*slop {
+		c.touchDone = true
+		return image.Point{}, false
+	}
+
+	c.touchCount++
+	if c.touchCount < contextMenuLongPressTicks() {
+		return image.Point{}, false
+	}
+	c.touchDone = true
+	return pt, true
+}
+
+func isContextMenuKeyJustPressed() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyContextMenu) ||
+		ebiten.IsKeyPressed(ebiten.KeyShift) && inpututil.IsKeyJustPressed(ebiten.KeyF10)
+}
+
+func (c *contextMenu) Tick(context *guigui.Context) error {
+	if c.popupMenu.IsOpen() || !isContextMenuKeyJustPressed() {
+		return nil
+	}
+	target, items := c.focusedProvider(context)
+	if target == nil {
+		return nil
+	}
+	var pt image.Point
+	if p, ok := target.(contextMenuPositioner); ok {
+		pt = p.contextMenuPosition(context)
+	} else {
+		b := context.VisibleBounds(target)
+		pt = image.Pt(b.Min.X, b.Max.Y)
+	}
+	c.open(context, target, items, pt)
+	return nil
+}
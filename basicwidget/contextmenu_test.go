@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+	"github.com/hajimehoshi/guigui/clipboard"
+)
+
+func contextMenuItem(t *testing.T, text *basicwidget.Text, name string) basicwidget.ContextMenuItem {
+	t.Helper()
+	for _, item := range text.AppendContextMenuItems(nil, nil) {
+		if item.Text == name {
+			return item
+		}
+	}
+	t.Fatalf("context menu item %q not found", name)
+	return basicwidget.ContextMenuItem{}
+}
+
+func TestTextContextMenu(t *testing.T) {
+	defer clipboard.SetBackend(clipboard.SetBackend(&clipboard.MemoryBackend{}))
+
+	var text basicwidget.Text
+	if got := text.AppendContextMenuItems(nil, nil); len(got) != 0 {
+		t.Errorf("len(items) for a non-selectable text: got: %d, want: 0", len(got))
+	}
+
+	text.SetEditable(true)
+	text.ForceSetValue("Hello")
+
+	if !contextMenuItem(t, &text, "Cut").Disabled {
+		t.Errorf("Cut must be disabled without a selection")
+	}
+	if !contextMenuItem(t, &text, "Undo").Disabled {
+		t.Errorf("Undo must be disabled without edits")
+	}
+
+	contextMenuItem(t, &text, "Select All").OnSelected()
+	cut := contextMenuItem(t, &text, "Cut")
+	if cut.Disabled {
+		t.Fatalf("Cut must be enabled with a selection")
+	}
+	cut.OnSelected()
+	if got, want := text.Value(), ""; got != want {
+		t.Errorf("text.Value() after Cut: got: %q, want: %q", got, want)
+	}
+	if got, err := clipboard.ReadText(); err != nil {
+		t.Fatal(err)
+	} else if want := "Hello"; got != want {
+		t.Errorf("clipboard.ReadText() after Cut: got: %q, want: %q", got, want)
+	}
+
+	contextMenuItem(t, &text, "Paste").OnSelected()
+	contextMenuItem(t, &text, "Paste").OnSelected()
+	if got, want := text.Value(), "HelloHello"; got != want {
+		t.Errorf("text.Value() after Paste: got: %q, want: %q", got, want)
+	}
+
+	undo := contextMenuItem(t, &text, "Undo")
+	if undo.Disabled {
+		t.Fatalf("Undo must be enabled after edits")
+	}
+	undo.OnSelected()
+	if got, want := text.Value(), "Hello"; got != want {
+		t.Errorf("text.Value() after Undo: got: %q, want: %q", got, want)
+	}
+
+	text.SetEditable(false)
+	text.SetSelectable(true)
+	for _, item := range text.AppendContextMenuItems(nil, nil) {
+		switch item.Text {
+		case "Cut", "Paste", "Undo", "Redo":
+			t.Errorf("%s must not be in the context menu of a read-only text", item.Text)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/text/language"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+)
+
+// DatePicker is a text input for a date with a popup calendar.
+//
+// The date is written in the numeric format of the locale, and a date in the ISO 8601 format is also accepted.
+// An invalid or disabled date is reverted to the previous value.
+type DatePicker struct {
+	guigui.DefaultWidget
+
+	textInput    TextInput
+	button       Button
+	popup        Popup
+	popupContent datePickerPopupContent
+
+	value time.Time
+
+	// focusRequired reports whether the calendar should be focused after opening the popup.
+	focusRequired bool
+
+	// hasFocus reports whether the focus is in the popup.
+	hasFocus bool
+
+	tmpLocales []language.Tag
+
+	onValueChanged func(date time.Time)
+}
+
+// SetOnValueChanged sets the function called when the date is changed by the user.
+// date is zero when the text is cleared.
+func (d *DatePicker) SetOnValueChanged(f func(date time.Time)) {
+	d.onValueChanged = f
+}
+
+// Value returns the date, or the zero time if no date is set.
+func (d *DatePicker) Value() time.Time {
+	return d.value
+}
+
+// SetValue sets the date. A zero date clears the text.
+func (d *DatePicker) SetValue(date time.Time) {
+	if !date.IsZero() {
+		date = dateOnly(date)
+	}
+	if date.Equal(d.value) {
+		return
+	}
+	d.value = date
+	d.popupContent.calendar.SetValue(date)
+	guigui.RequestRedraw(d)
+}
+
+// SetMinimumDate sets the earliest selectable date.
+// A zero date removes the limit.
+func (d *DatePicker) SetMinimumDate(date time.Time) {
+	d.popupContent.calendar.SetMinimumDate(date)
+}
+
+// SetMaximumDate sets the latest selectable date.
+// A zero date removes the limit.
+func (d *DatePicker) SetMaximumDate(date time.Time) {
+	d.popupContent.calendar.SetMaximumDate(date)
+}
+
+// SetDateDisabledFunc sets the function reporting whether a date is disabled, e.g. for holidays.
+func (d *DatePicker) SetDateDisabledFunc(f func(date time.Time) bool) {
+	d.popupContent.calendar.SetDateDisabledFunc(f)
+}
+
+func (d *DatePicker) IsPopupOpen() bool {
+	return d.popup.IsOpen()
+}
+
+func (d *DatePicker) openPopup(context *guigui.Context) {
+	if d.popup.IsOpen() {
+		return
+	}
+	d.popupContent.calendar.SetValue(d.value)
+	d.popup.Open(context)
+	d.focusRequired = true
+}
+
+func (d *DatePicker) setValueByUser(date time.Time) {
+	if date.Equal(d.value) {
+		return
+	}
+	d.SetValue(date)
+	if d.onValueChanged != nil {
+		d.onValueChanged(d.value)
+	}
+}
+
+func (d *DatePicker) commit(text string) {
+	if strings.TrimSpace(text) == "" {
+		d.setValueByUser(time.Time{})
+		return
+	}
+	date, ok := calendarLocaleForTags(d.tmpLocales).parseDate(text, time.Local)
+	if !ok || !d.popupContent.calendar.IsDateEnabled(date) {
+		// Revert the text.
+		d.textInput.ForceSetValue(d.text())
+		return
+	}
+	d.setValueByUser(date)
+	// Normalize the text, e.g. from the ISO 8601 format.
+	d.textInput.ForceSetValue(d.text())
+}
+
+func (d *DatePicker) text() string {
+	if d.value.IsZero() {
+		return ""
+	}
+	return calendarLocaleForTags(d.tmpLocales).formatDate(d.value)
+}
+
+func (d *DatePicker) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	_, d.tmpLocales = calendarLocaleForContext(context, d.tmpLocales)
+
+	u := UnitSize(context)
+	b := context.Bounds(d)
+
+	d.textInput.SetValue(d.text())
+	d.textInput.SetTabular(true)
+	d.textInput.setPaddingEnd(u)
+	d.textInput.SetOnValueChanged(func(text string, committed bool) {
+		if !committed {
+			return
+		}
+		d.commit(text)
+	})
+	d.textInput.setKeyHandler(func(context *guigui.Context) bool {
+		if isKeyRepeating(ebiten.KeyDown) && d.textInput.IsEditable() {
+			d.openPopup(context)
+			return true
+		}
+		return false
+	})
+	appender.AppendChildWidgetWithBounds(&d.textInput, b)
+
+	img, err := theResourceImages.Get("calendar_today", context.ColorMode())
+	if err != nil {
+		return err
+	}
+	d.button.SetIcon(img)
+	d.button.setSharpenCorners(draw.SharpenCorners{
+		UpperStart: true,
+		LowerStart: true,
+	})
+	d.button.SetOnDown(func() {
+		if d.popup.IsOpen() {
+			d.popup.Close()
+			return
+		}
+		d.openPopup(context)
+	})
+	context.SetEnabled(&d.button, d.textInput.IsEditable())
+	appender.AppendChildWidgetWithBounds(&d.button, image.Rect(b.Max.X-u, b.Min.Y, b.Max.X, b.Max.Y))
+
+	d.popupContent.calendar.SetOnValueChanged(func(date time.Time) {
+		d.setValueByUser(date)
+		d.textInput.ForceSetValue(d.text())
+		d.popup.Close()
+	})
+	d.popup.SetOnClosed(func(reason PopupClosedReason) {
+		// Give the focus back only when the focus was in the popup, as another widget might have already taken it.
+		if d.hasFocus {
+			context.SetFocused(&d.textInput, true)
+		}
+		d.hasFocus = false
+		d.focusRequired = false
+	})
+	d.popup.SetCloseByClickingOutside(true)
+	d.popup.SetContent(&d.popupContent)
+	bounds := d.popupBounds(context)
+	context.SetSize(&d.popupContent, bounds.Size())
+	appender.AppendChildWidgetWithBounds(&d.popup, bounds)
+
+	return nil
+}
+
+// Tick moves the focus into the calendar after opening the popup.
+func (d *DatePicker) Tick(context *guigui.Context) error {
+	if !d.popup.IsOpen() {
+		return nil
+	}
+	d.hasFocus = context.IsFocusedOrHasFocusedChild(&d.popup)
+	if !d.focusRequired {
+		return nil
+	}
+	// The calendar is not in the tree until the popup starts to appear.
+	context.SetFocused(&d.popupContent.calendar, true)
+	if context.IsFocused(&d.popupContent.calendar) {
+		d.focusRequired = false
+		d.hasFocus = true
+	}
+	return nil
+}
+
+// popupBounds returns the bounds of the calendar below the date picker, or above it if there is not enough space.
+func (d *DatePicker) popupBounds(context *guigui.Context) image.Rectangle {
+	bounds := context.Bounds(d)
+	s := d.popupContent.DefaultSize(context)
+
+	as := context.AppBounds()
+	r := image.Rectangle{
+		Min: image.Pt(bounds.Min.X, bounds.Max.Y),
+		Max: image.Pt(bounds.Min.X+s.X, bounds.Max.Y+s.Y),
+	}
+	if r.Max.Y > as.Max.Y && bounds.Min.Y-s.Y >= as.Min.Y {
+		r.Min.Y = bounds.Min.Y - s.Y
+		r.Max.Y = bounds.Min.Y
+	}
+	if r.Max.X > as.Max.X {
+		r = r.Add(image.Pt(as.Max.X-r.Max.X, 0))
+	}
+	if r.Min.X < as.Min.X {
+		r = r.Add(image.Pt(as.Min.X-r.Min.X, 0))
+	}
+	return r
+}
+
+func (d *DatePicker) DefaultSize(context *guigui.Context) image.Point {
+	return image.Pt(7*UnitSize(context), d.textInput.DefaultSize(context).Y)
+}
+
+type datePickerPopupContent struct {
+	guigui.DefaultWidget
+
+	calendar Calendar
+}
+
+func datePickerPopupPadding(context *guigui.Context) int {
+	return UnitSize(context) / 4
+}
+
+func (d *datePickerPopupContent) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	appender.AppendChildWidgetWithBounds(&d.calendar, context.Bounds(d).Inset(datePickerPopupPadding(context)))
+	return nil
+}
+
+func (d *datePickerPopupContent) DefaultSize(context *guigui.Context) image.Point {
+	p := datePickerPopupPadding(context)
+	return d.calendar.DefaultSize(context).Add(image.Pt(2*p, 2*p))
+}
@@ -0,0 +1,608 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// DialogButton is a button in the button row of a Dialog.
+type DialogButton struct {
+	Text     string
+	Disabled bool
+
+	// Default reports whether the button is pressed by Enter. The default button is drawn with the accent color.
+	Default bool
+
+	// Cancel reports whether the button is pressed by Escape.
+	Cancel bool
+
+	// KeepOpen reports whether the dialog is kept open when the button is pressed.
+	// Call Close to close the dialog, e.g. after validating the input.
+	KeepOpen bool
+}
+
+// Dialog is a modal popup with a title, a message, an optional content and a row of buttons.
+//
+// The dialog is centered in the app, and is closed when a button is pressed.
+// The focus is kept in the dialog while the dialog is open, and is given back when the dialog is closed.
+//
+// To open dialogs on top of other dialogs, use DialogStack.
+type Dialog struct {
+	guigui.DefaultWidget
+
+	popup   Popup
+	content dialogContent
+
+	title   Text
+	message Text
+	body    guigui.Widget
+	buttons []Button
+
+	buttonSpecs []DialogButton
+
+	movable bool
+	offset  image.Point
+
+	initialFocus  guigui.Widget
+	focusedBefore guigui.Widget
+	hasFocus      bool
+	focusRequired bool
+
+	// zDelta is the z offset given by DialogStack.
+	zDelta int
+
+	onButtonPressed func(index int)
+	onClosed        func(reason PopupClosedReason)
+}
+
+func (d *Dialog) SetTitle(title string) {
+	d.title.SetValue(title)
+}
+
+func (d *Dialog) SetMessage(message string) {
+	d.message.SetValue(message)
+}
+
+// SetContent sets the widget shown below the message, e.g. a text input.
+func (d *Dialog) SetContent(widget guigui.Widget) {
+	d.body = widget
+}
+
+// SetInitialFocus sets the widget focused when the dialog is opened.
+// If widget is nil, the default button is focused.
+func (d *Dialog) SetInitialFocus(widget guigui.Widget) {
+	d.initialFocus = widget
+}
+
+func (d *Dialog) SetButtons(buttons []DialogButton) {
+	d.buttonSpecs = adjustSliceSize(d.buttonSpecs, len(buttons))
+	copy(d.buttonSpecs, buttons)
+	d.buttons = adjustSliceSize(d.buttons, len(buttons))
+}
+
+// SetOnButtonPressed sets the function called when a button is pressed, by a click, Enter or Escape.
+// The dialog is being closed when the function is called, unless the button is KeepOpen.
+func (d *Dialog) SetOnButtonPressed(f func(index int)) {
+	d.onButtonPressed = f
+}
+
+func (d *Dialog) SetOnClosed(f func(reason PopupClosedReason)) {
+	d.onClosed = f
+}
+
+// SetMovable sets whether the dialog can be moved by dragging its title.
+func (d *Dialog) SetMovable(movable bool) {
+	d.movable = movable
+}
+
+func (d *Dialog) SetBackgroundBlurred(blurred bool) {
+	d.popup.SetBackgroundBlurred(blurred)
+}
+
+func (d *Dialog) IsOpen() bool {
+	return d.popup.IsOpen()
+}
+
+// Open opens the dialog at the center of the app.
+func (d *Dialog) Open(context *guigui.Context) {
+	if d.popup.IsOpen() {
+		return
+	}
+	d.focusedBefore = context.FocusedWidget()
+	d.offset = image.Point{}
+	d.focusRequired = true
+	d.popup.Open(context)
+}
+
+func (d *Dialog) Close() {
+	d.popup.Close()
+}
+
+// PressButton presses the button at the index as if it is clicked.
+func (d *Dialog) PressButton(index int) {
+	if index < 0 || index >= len(d.buttonSpecs) || d.buttonSpecs[index].Disabled {
+		return
+	}
+	if !d.popup.IsOpen() || d.popup.hiding {
+		return
+	}
+	if !d.buttonSpecs[index].KeepOpen {
+		d.popup.Close()
+	}
+	if d.onButtonPressed != nil {
+		d.onButtonPressed(index)
+	}
+}
+
+// buttonIndex returns the index of the first enabled button satisfying f, or -1.
+func (d *Dialog) buttonIndex(f func(button DialogButton) bool) int {
+	for i, b := range d.buttonSpecs {
+		if !b.Disabled && f(b) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *Dialog) defaultButtonIndex() int {
+	return d.buttonIndex(func(button DialogButton) bool {
+		return button.Default
+	})
+}
+
+func (d *Dialog) cancelButtonIndex() int {
+	return d.buttonIndex(func(button DialogButton) bool {
+		return button.Cancel
+	})
+}
+
+// pressDefaultButton presses the default button, and reports whether there is the default button.
+func (d *Dialog) pressDefaultButton() bool {
+	if !d.popup.IsOpen() || d.popup.hiding {
+		return false
+	}
+	index := d.defaultButtonIndex()
+	if index < 0 {
+		return false
+	}
+	d.PressButton(index)
+	return true
+}
+
+// pressCancelButton presses the cancel button, and reports whether there is the cancel button.
+func (d *Dialog) pressCancelButton() bool {
+	if !d.popup.IsOpen() || d.popup.hiding {
+		return false
+	}
+	index := d.cancelButtonIndex()
+	if index < 0 {
+		return false
+	}
+	d.PressButton(index)
+	return true
+}
+
+func (d *Dialog) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	d.title.SetBold(true)
+	d.message.SetMultiline(true)
+	d.message.SetAutoWrap(true)
+
+	for i := range d.buttons {
+		spec := d.buttonSpecs[i]
+		d.buttons[i].SetText(spec.Text)
+		d.buttons[i].setUseAccentColor(spec.Default)
+		context.SetEnabled(&d.buttons[i], !spec.Disabled)
+		d.buttons[i].SetOnUp(func() {
+			d.PressButton(i)
+		})
+	}
+
+	d.popup.SetOnClosed(func(reason PopupClosedReason) {
+		// Give the focus back only when the focus was in the dialog, as another widget might have already taken it.
+		if d.hasFocus && d.focusedBefore != nil {
+			context.SetFocused(d.focusedBefore, true)
+		}
+		d.focusedBefore = nil
+		d.hasFocus = false
+		if d.onClosed != nil {
+			d.onClosed(reason)
+		}
+	})
+	d.popup.SetCloseByClickingOutside(false)
+	d.popup.SetAnimationDuringFade(true)
+	d.content.dialog = d
+	d.popup.SetContent(&d.content)
+
+	bounds := d.bounds(context)
+	context.SetSize(&d.content, bounds.Size())
+	appender.AppendChildWidgetWithBounds(&d.popup, bounds)
+
+	return nil
+}
+
+func dialogPadding(context *guigui.Context) int {
+	return UnitSize(context)
+}
+
+func dialogButtonMinWidth(context *guigui.Context) int {
+	return 4 * UnitSize(context)
+}
+
+func (d *Dialog) buttonWidth(context *guigui.Context, index int) int {
+	return max(d.buttons[index].DefaultSize(context).X, dialogButtonMinWidth(context))
+}
+
+// bounds returns the bounds of the dialog, which is at the center of the app moved by dragging.
+func (d *Dialog) bounds(context *guigui.Context) image.Rectangle {
+	u := UnitSize(context)
+	p := dialogPadding(context)
+	ab := context.AppBounds()
+
+	w := 18 * u
+	if d.body != nil {
+		w = max(w, d.body.DefaultSize(context).X+2*p)
+	}
+	bw := max(len(d.buttons)-1, 0) * u / 4
+	for i := range d.buttons {
+		bw += d.buttonWidth(context, i)
+	}
+	w = max(w, bw+2*p, d.title.DefaultSize(context).X+2*p)
+	w = min(w, ab.Dx()-u)
+
+	var h int
+	for _, rh := range d.rowHeights(context, w-2*p) {
+		if rh == 0 {
+			continue
+		}
+		if h > 0 {
+			h += u / 2
+		}
+		h += rh
+	}
+	h += 2 * p
+
+	pt := image.Pt(ab.Min.X+(ab.Dx()-w)/2, ab.Min.Y+(ab.Dy()-h)/2).Add(d.offset)
+	return image.Rectangle{
+		Min: pt,
+		Max: pt.Add(image.Pt(w, h)),
+	}
+}
+
+// rowHeights returns the heights of the title, the message, the content and the buttons for the content width.
+func (d *Dialog) rowHeights(context *guigui.Context, width int) [4]int {
+	var hs [4]int
+	if d.title.Value() != "" {
+		hs[0] = d.title.DefaultSize(context).Y
+	}
+	if d.message.Value() != "" {
+		context.SetSize(&d.message, image.Pt(width, guigui.DefaultSize))
+		hs[1] = d.message.TextSize(context).Y
+	}
+	if d.body != nil {
+		hs[2] = d.body.DefaultSize(context).Y
+	}
+	for i := range d.buttons {
+		hs[3] = max(hs[3], d.buttons[i].DefaultSize(context).Y)
+	}
+	return hs
+}
+
+// HandleButtonInput presses the default button by Enter.
+// Enter is not handled when the focused widget handles activation, e.g. a button.
+func (d *Dialog) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !d.popup.IsOpen() || d.popup.hiding {
+		return guigui.HandleInputResult{}
+	}
+	if !inpututil.IsKeyJustPressed(ebiten.KeyEnter) && !inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+		return guigui.HandleInputResult{}
+	}
+	if _, ok := context.FocusedWidget().(guigui.ActivateHandler); ok {
+		return guigui.HandleInputResult{}
+	}
+	if !d.pressDefaultButton() {
+		return guigui.HandleInputResult{}
+	}
+	return guigui.HandleInputByWidget(d)
+}
+
+// HandleBack implements guigui.BackHandler.
+// HandleBack presses the cancel button.
+func (d *Dialog) HandleBack(context *guigui.Context) bool {
+	return d.pressCancelButton()
+}
+
+// Tick moves the focus into the dialog after opening it.
+func (d *Dialog) Tick(context *guigui.Context) error {
+	if !d.popup.IsOpen() {
+		return nil
+	}
+	d.hasFocus = context.IsFocusedOrHasFocusedChild(d)
+	if !d.focusRequired {
+		return nil
+	}
+	target := d.initialFocus
+	if target == nil {
+		if index := d.defaultButtonIndex(); index >= 0 {
+			target = &d.buttons[index]
+		}
+	}
+	if target == nil {
+		d.focusRequired = false
+		return nil
+	}
+	// The content is not in the tree until the popup starts to appear.
+	context.SetFocused(target, true)
+	if context.IsFocused(target) {
+		d.focusRequired = false
+		d.hasFocus = true
+	}
+	return nil
+}
+
+func (d *Dialog) ZDelta() int {
+	return d.zDelta
+}
+
+type dialogContent struct {
+	guigui.DefaultWidget
+
+	dialog *Dialog
+
+	titleBounds       image.Rectangle
+	dragStartCursor   image.Point
+	dragStartOffset   image.Point
+	dragStartAppRange image.Rectangle
+}
+
+func (d *dialogContent) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	u := UnitSize(context)
+	p := dialogPadding(context)
+	bounds := context.Bounds(d).Inset(p)
+	hs := d.dialog.rowHeights(context, bounds.Dx())
+
+	y := bounds.Min.Y
+	rowBounds := func(h int) image.Rectangle {
+		if y > bounds.Min.Y {
+			y += u / 2
+		}
+		r := image.Rect(bounds.Min.X, y, bounds.Max.X, y+h)
+		y += h
+		return r
+	}
+
+	d.titleBounds = image.Rectangle{}
+	if hs[0] > 0 {
+		d.titleBounds = rowBounds(hs[0])
+		appender.AppendChildWidgetWithBounds(&d.dialog.title, d.titleBounds)
+	}
+	if hs[1] > 0 {
+		appender.AppendChildWidgetWithBounds(&d.dialog.message, rowBounds(hs[1]))
+	}
+	if hs[2] > 0 {
+		appender.AppendChildWidgetWithBounds(d.dialog.body, rowBounds(hs[2]))
+	}
+	if hs[3] > 0 {
+		r := rowBounds(hs[3])
+		// Align the buttons to the end.
+		x := r.Max.X
+		for i := len(d.dialog.buttons) - 1; i >= 0; i-- {
+			w := d.dialog.buttonWidth(context, i)
+			appender.AppendChildWidgetWithBounds(&d.dialog.buttons[i], image.Rect(x-w, r.Min.Y, x, r.Max.Y))
+			x -= w + u/4
+		}
+	}
+
+	return nil
+}
+
+// HandlePointingInput moves the dialog by dragging the title.
+func (d *dialogContent) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if !d.dialog.movable {
+		return guigui.HandleInputResult{}
+	}
+
+	if context.HasPointerCapture(d) {
+		if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			context.ReleasePointer(d)
+			return guigui.HandleInputResult{}
+		}
+		delta := image.Pt(ebiten.CursorPosition()).Sub(d.dragStartCursor)
+		offset := d.dragStartOffset.Add(delta)
+		// Keep the dialog inside the app.
+		r := d.dragStartAppRange
+		offset.X = min(max(offset.X, r.Min.X), r.Max.X)
+		offset.Y = min(max(offset.Y, r.Min.Y), r.Max.Y)
+		if d.dialog.offset != offset {
+			d.dialog.offset = offset
+			guigui.RequestRedraw(d.dialog)
+		}
+		return guigui.HandleInputByWidget(d)
+	}
+
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || !d.isTitleHovered(context) {
+		return guigui.HandleInputResult{}
+	}
+	d.dragStartCursor = image.Pt(ebiten.CursorPosition())
+	d.dragStartOffset = d.dialog.offset
+	// The range of the offset to keep the dialog inside the app.
+	b := context.Bounds(d)
+	ab := context.AppBounds()
+	o := d.dialog.offset
+	d.dragStartAppRange = image.Rectangle{
+		Min: o.Add(ab.Min.Sub(b.Min)),
+		Max: o.Add(ab.Max.Sub(b.Max)),
+	}
+	context.CapturePointer(d)
+	return guigui.HandleInputByWidget(d)
+}
+
+// isTitleHovered reports whether the cursor is on the title row including the padding around it.
+func (d *dialogContent) isTitleHovered(context *guigui.Context) bool {
+	if !context.IsWidgetHitAtCursor(d) && !context.IsWidgetHitAtCursor(&d.dialog.title) {
+		return false
+	}
+	b := context.Bounds(d)
+	r := image.Rect(b.Min.X, b.Min.Y, b.Max.X, d.titleBounds.Max.Y)
+	if d.titleBounds.Empty() {
+		r.Max.Y = b.Min.Y + dialogPadding(context)
+	}
+	return image.Pt(ebiten.CursorPosition()).In(r)
+}
+
+func (d *dialogContent) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	if d.dialog.movable && (context.HasPointerCapture(d) || d.isTitleHovered(context)) {
+		return ebiten.CursorShapeMove, true
+	}
+	return 0, false
+}
+
+func dialogStackZDelta() int {
+	return 4 * popupZ
+}
+
+// DialogStack opens dialogs on top of each other, e.g. a confirmation from another dialog.
+// The last opened dialog is on the top, and a dialog is removed from the stack after it is closed.
+//
+// DialogStack also provides the helpers Alert, Confirm and Prompt.
+//
+// Append one DialogStack to the root widget after the other widgets. Its bounds are not used.
+type DialogStack struct {
+	guigui.DefaultWidget
+
+	dialogs []*Dialog
+}
+
+// Open opens the dialog on the top of the stack.
+func (s *DialogStack) Open(context *guigui.Context, dialog *Dialog) {
+	if !s.push(dialog) {
+		return
+	}
+	dialog.Open(context)
+}
+
+// push adds the dialog on the top of the stack, and reports whether the dialog is added.
+func (s *DialogStack) push(dialog *Dialog) bool {
+	if slices.Contains(s.dialogs, dialog) {
+		return false
+	}
+	// Put the dialog above the top one, as the z of the top one might be larger than the number of the dialogs after removals.
+	dialog.zDelta = 0
+	if len(s.dialogs) > 0 {
+		dialog.zDelta = s.dialogs[len(s.dialogs)-1].zDelta + dialogStackZDelta()
+	}
+	s.dialogs = append(s.dialogs, dialog)
+	guigui.RequestRedraw(s)
+	return true
+}
+
+// Len returns the number of the dialogs in the stack.
+func (s *DialogStack) Len() int {
+	return len(s.dialogs)
+}
+
+func (s *DialogStack) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	for _, d := range s.dialogs {
+		appender.AppendChildWidget(d)
+	}
+	return nil
+}
+
+// Tick removes the closed dialogs.
+func (s *DialogStack) Tick(context *guigui.Context) error {
+	var removed bool
+	s.dialogs = slices.DeleteFunc(s.dialogs, func(d *Dialog) bool {
+		if d.IsOpen() {
+			return false
+		}
+		removed = true
+		return true
+	})
+	if removed {
+		guigui.RequestRedraw(s)
+	}
+	return nil
+}
+
+func (s *DialogStack) newDialog(title, message string) *Dialog {
+	d := &Dialog{}
+	d.SetTitle(title)
+	d.SetMessage(message)
+	d.SetMovable(true)
+	return d
+}
+
+// Alert opens a dialog with an OK button. onClosed is called when the button is pressed.
+func (s *DialogStack) Alert(context *guigui.Context, title, message string, onClosed func()) {
+	d := s.newDialog(title, message)
+	d.SetButtons([]DialogButton{
+		{
+			Text:    "OK",
+			Default: true,
+			Cancel:  true,
+		},
+	})
+	d.SetOnButtonPressed(func(index int) {
+		if onClosed != nil {
+			onClosed()
+		}
+	})
+	s.Open(context, d)
+}
+
+// Confirm opens a dialog with OK and Cancel buttons. onResult is called with true when OK is pressed.
+func (s *DialogStack) Confirm(context *guigui.Context, title, message string, onResult func(ok bool)) {
+	d := s.newDialog(title, message)
+	d.SetButtons([]DialogButton{
+		{
+			Text:   "Cancel",
+			Cancel: true,
+		},
+		{
+			Text:    "OK",
+			Default: true,
+		},
+	})
+	d.SetOnButtonPressed(func(index int) {
+		if onResult != nil {
+			onResult(index == 1)
+		}
+	})
+	s.Open(context, d)
+}
+
+// Prompt opens a dialog with a text input and OK and Cancel buttons.
+// onResult is called with the text and true when OK is pressed, or with false when Cancel is pressed.
+func (s *DialogStack) Prompt(context *guigui.Context, title, message, value string, onResult func(value string, ok bool)) {
+	d := s.newDialog(title, message)
+	var textInput TextInput
+	textInput.SetValue(value)
+	textInput.SetOnEnterPressed(func(text string) {
+		d.PressButton(1)
+	})
+	d.SetContent(&textInput)
+	d.SetInitialFocus(&textInput)
+	d.SetButtons([]DialogButton{
+		{
+			Text:   "Cancel",
+			Cancel: true,
+		},
+		{
+			Text:    "OK",
+			Default: true,
+		},
+	})
+	d.SetOnButtonPressed(func(index int) {
+		if onResult != nil {
+			onResult(textInput.Value(), index == 1)
+		}
+	})
+	s.Open(context, d)
+}
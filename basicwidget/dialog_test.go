@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"slices"
+	"testing"
+)
+
+// openDialog opens d without the animation. Dialog.Open requires a context to move the focus.
+func openDialog(d *Dialog) {
+	d.popup.showing = true
+	for d.popup.showing {
+		_ = d.popup.Tick(nil)
+	}
+}
+
+// finishClosingDialog ends the closing animation of d. The end of the animation requires a context to release the focus.
+func finishClosingDialog(d *Dialog) {
+	d.popup.hiding = false
+	d.popup.openingCount = 0
+}
+
+func TestDialogStack(t *testing.T) {
+	var s DialogStack
+	var d0, d1, d2 Dialog
+	for _, d := range []*Dialog{&d0, &d1, &d2} {
+		if !s.push(d) {
+			t.Fatalf("push: got: false, want: true")
+		}
+		openDialog(d)
+	}
+	// Opening a dialog in the stack again does nothing.
+	if s.push(&d1) {
+		t.Errorf("push again: got: true, want: false")
+	}
+
+	if got, want := s.dialogs, []*Dialog{&d0, &d1, &d2}; !slices.Equal(got, want) {
+		t.Fatalf("dialogs: got: %v, want: %v", got, want)
+	}
+	delta := dialogStackZDelta()
+	for i, d := range s.dialogs {
+		if got, want := d.ZDelta(), i*delta; got != want {
+			t.Errorf("dialog %d: ZDelta: got: %d, want: %d", i, got, want)
+		}
+	}
+
+	// A dialog is removed after it is closed.
+	d1.Close()
+	if err := s.Tick(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Len(), 3; got != want {
+		t.Errorf("Len while closing: got: %d, want: %d", got, want)
+	}
+	finishClosingDialog(&d1)
+	if err := s.Tick(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.dialogs, []*Dialog{&d0, &d2}; !slices.Equal(got, want) {
+		t.Errorf("dialogs after closing: got: %v, want: %v", got, want)
+	}
+
+	// A dialog opened again is on the top.
+	s.push(&d1)
+	openDialog(&d1)
+	if got, want := s.dialogs, []*Dialog{&d0, &d2, &d1}; !slices.Equal(got, want) {
+		t.Errorf("dialogs after opening again: got: %v, want: %v", got, want)
+	}
+	if d1.ZDelta() <= d2.ZDelta() {
+		t.Errorf("ZDelta: got: %d, want: > %d", d1.ZDelta(), d2.ZDelta())
+	}
+}
+
+func TestDialogDefaultAndCancelButtons(t *testing.T) {
+	testCases := []struct {
+		name        string
+		buttons     []DialogButton
+		wantDefault int
+		wantCancel  int
+	}{
+		{
+			name: "ok cancel",
+			buttons: []DialogButton{
+				{Text: "Cancel", Cancel: true},
+				{Text: "OK", Default: true},
+			},
+			wantDefault: 1,
+			wantCancel:  0,
+		},
+		{
+			name: "both",
+			buttons: []DialogButton{
+				{Text: "OK", Default: true, Cancel: true},
+			},
+			wantDefault: 0,
+			wantCancel:  0,
+		},
+		{
+			name: "none",
+			buttons: []DialogButton{
+				{Text: "Yes"},
+				{Text: "No"},
+			},
+			wantDefault: -1,
+			wantCancel:  -1,
+		},
+		{
+			name: "disabled",
+			buttons: []DialogButton{
+				{Text: "Cancel", Cancel: true, Disabled: true},
+				{Text: "Save", Default: true, Disabled: true},
+				{Text: "Discard", Default: true, Cancel: true},
+			},
+			wantDefault: 2,
+			wantCancel:  2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d Dialog
+			d.SetButtons(tc.buttons)
+			if got := d.defaultButtonIndex(); got != tc.wantDefault {
+				t.Errorf("defaultButtonIndex: got: %d, want: %d", got, tc.wantDefault)
+			}
+			if got := d.cancelButtonIndex(); got != tc.wantCancel {
+				t.Errorf("cancelButtonIndex: got: %d, want: %d", got, tc.wantCancel)
+			}
+
+			// Enter presses the default button.
+			pressed := -1
+			d.SetOnButtonPressed(func(index int) {
+				pressed = index
+			})
+			openDialog(&d)
+			if got, want := d.pressDefaultButton(), tc.wantDefault >= 0; got != want {
+				t.Errorf("pressDefaultButton: got: %t, want: %t", got, want)
+			}
+			if pressed != tc.wantDefault {
+				t.Errorf("pressed by Enter: got: %d, want: %d", pressed, tc.wantDefault)
+			}
+
+			// Escape presses the cancel button.
+			pressed = -1
+			finishClosingDialog(&d)
+			openDialog(&d)
+			if got, want := d.HandleBack(nil), tc.wantCancel >= 0; got != want {
+				t.Errorf("HandleBack: got: %t, want: %t", got, want)
+			}
+			if pressed != tc.wantCancel {
+				t.Errorf("pressed by Escape: got: %d, want: %d", pressed, tc.wantCancel)
+			}
+		})
+	}
+}
+
+func TestDialogKeepOpen(t *testing.T) {
+	var d Dialog
+	d.SetButtons([]DialogButton{
+		{Text: "Cancel", Cancel: true},
+		{Text: "Apply", KeepOpen: true},
+		{Text: "OK", Default: true},
+	})
+	var pressed []int
+	d.SetOnButtonPressed(func(index int) {
+		pressed = append(pressed, index)
+	})
+	openDialog(&d)
+
+	d.PressButton(1)
+	if d.popup.hiding {
+		t.Errorf("the dialog is being closed after pressing a KeepOpen button")
+	}
+	d.PressButton(1)
+	d.PressButton(2)
+	if !d.popup.hiding {
+		t.Errorf("the dialog is not being closed after pressing a button")
+	}
+	if got, want := pressed, []int{1, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("pressed: got: %v, want: %v", got, want)
+	}
+}
+
+func TestDialogPressButtonGuards(t *testing.T) {
+	testCases := []struct {
+		name    string
+		index   int
+		open    bool
+		closing bool
+		want    []int
+	}{
+		{name: "enabled", index: 0, open: true, want: []int{0}},
+		{name: "negative index", index: -1, open: true},
+		{name: "too large index", index: 2, open: true},
+		{name: "disabled", index: 1, open: true},
+		{name: "closed", index: 0},
+		{name: "closing", index: 0, open: true, closing: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d Dialog
+			d.SetButtons([]DialogButton{
+				{Text: "OK", Default: true, Cancel: true},
+				{Text: "Disabled", Disabled: true},
+			})
+			var pressed []int
+			d.SetOnButtonPressed(func(index int) {
+				pressed = append(pressed, index)
+			})
+			if tc.open {
+				openDialog(&d)
+			}
+			if tc.closing {
+				d.Close()
+			}
+
+			d.PressButton(tc.index)
+			if !slices.Equal(pressed, tc.want) {
+				t.Errorf("pressed: got: %v, want: %v", pressed, tc.want)
+			}
+		})
+	}
+}
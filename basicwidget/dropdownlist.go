@@ -8,6 +8,9 @@ import (
 	"image/color"
 	"log/slog"
 
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
 	"github.com/hajimehoshi/guigui"
 )
 
@@ -20,6 +23,28 @@ type DropdownListItem[T comparable] struct {
 	ID        T
 }
 
+func (d *DropdownListItem[T]) popupMenuItem() PopupMenuItem[T] {
+	return PopupMenuItem[T]{
+		Text:      d.Text,
+		TextColor: d.TextColor,
+		Header:    d.Header,
+		Disabled:  d.Disabled,
+		Border:    d.Border,
+		ID:        d.ID,
+	}
+}
+
+func dropdownListItemFromPopupMenuItem[T comparable](item PopupMenuItem[T]) DropdownListItem[T] {
+	return DropdownListItem[T]{
+		Text:      item.Text,
+		TextColor: item.TextColor,
+		Header:    item.Header,
+		Disabled:  item.Disabled,
+		Border:    item.Border,
+		ID:        item.ID,
+	}
+}
+
 type DropdownList[T comparable] struct {
 	guigui.DefaultWidget
 
@@ -75,6 +100,26 @@ func (d *DropdownList[T]) Build(context *guigui.Context, appender *guigui.ChildW
 	return nil
 }
 
+// HandleButtonInput opens the popup by the up and down keys, and selects an item by type-ahead search while the popup is closed.
+// Enter and Space open the popup by activating the button.
+func (d *DropdownList[T]) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if d.popupMenu.IsOpen() {
+		return guigui.HandleInputResult{}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) || inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		d.popupMenu.Open(context)
+		return guigui.HandleInputByWidget(d)
+	}
+	index, ok := d.popupMenu.list.list.typeAheadItemIndex(d.SelectedItemIndex())
+	if !ok {
+		return guigui.HandleInputResult{}
+	}
+	if index >= 0 {
+		d.SelectItemByIndex(index)
+	}
+	return guigui.HandleInputByWidget(d)
+}
+
 func (d *DropdownList[T]) updateText() {
 	if item, ok := d.popupMenu.SelectedItem(); ok {
 		d.button.SetText(item.Text)
@@ -86,7 +131,7 @@ func (d *DropdownList[T]) updateText() {
 func (d *DropdownList[T]) SetItems(items []DropdownListItem[T]) {
 	var popupMenuItems []PopupMenuItem[T]
 	for _, item := range items {
-		popupMenuItems = append(popupMenuItems, PopupMenuItem[T](item))
+		popupMenuItems = append(popupMenuItems, item.popupMenuItem())
 	}
 	d.popupMenu.SetItems(popupMenuItems)
 	d.updateText()
@@ -102,7 +147,7 @@ func (d *DropdownList[T]) SelectedItem() (DropdownListItem[T], bool) {
 	if !ok {
 		return DropdownListItem[T]{}, false
 	}
-	return DropdownListItem[T](item), true
+	return dropdownListItemFromPopupMenuItem(item), true
 }
 
 func (d *DropdownList[T]) ItemByIndex(index int) (DropdownListItem[T], bool) {
@@ -110,7 +155,7 @@ func (d *DropdownList[T]) ItemByIndex(index int) (DropdownListItem[T], bool) {
 	if !ok {
 		return DropdownListItem[T]{}, false
 	}
-	return DropdownListItem[T](item), true
+	return dropdownListItemFromPopupMenuItem(item), true
 }
 
 func (d *DropdownList[T]) SelectedItemIndex() int {
@@ -3,6 +3,164 @@
 
 package basicwidget
 
+import (
+	"image"
+	"image/color"
+	"io/fs"
+	"time"
+
+	"github.com/hajimehoshi/oklab"
+	"golang.org/x/text/language"
+)
+
+func TooltipBounds(anchor image.Rectangle, size image.Point, appBounds image.Rectangle, placement TooltipPlacement, gap int) image.Rectangle {
+	return tooltipBounds(anchor, size, appBounds, placement, gap)
+}
+
+func ScrollAxis(offset, delta, lo, hi float64) (float64, float64) {
+	return scrollAxis(offset, delta, lo, hi)
+}
+
+func RubberBandDelta(delta, overscroll, size float64) float64 {
+	return rubberBandDelta(delta, overscroll, size)
+}
+
 func ReplaceNewLinesWithSpace(text string, start, end, shiftIndex int) (string, int, int, int) {
 	return replaceNewLinesWithSpace(text, start, end, shiftIndex)
 }
+
+type ItemHeightCache = itemHeightCache
+
+func (c *itemHeightCache) Resize(count int, estimated int) bool {
+	return c.resize(count, estimated)
+}
+
+func (c *itemHeightCache) Height(index int) int {
+	return c.height(index)
+}
+
+func (c *itemHeightCache) SetHeight(index int, height int) int {
+	return c.setHeight(index, height)
+}
+
+func (c *itemHeightCache) Offset(index int) int {
+	return c.offset(index)
+}
+
+func (c *itemHeightCache) Total() int {
+	return c.total()
+}
+
+func (c *itemHeightCache) IndexAt(y int) int {
+	return c.indexAt(y)
+}
+
+func FoldedIndex(s, substr string) (int, int, bool) {
+	return foldedIndex(s, substr)
+}
+
+func ParseMnemonic(title string) (string, int, rune) {
+	return parseMnemonic(title)
+}
+
+func ShortcutString(s Shortcut, darwin bool) string {
+	return s.string(darwin)
+}
+
+func FileChooserEntryNames(fsys fs.FS, dir string, patterns []string, hiddenVisible bool, dirsOnly bool) ([]string, error) {
+	var filter *FileFilter
+	if patterns != nil {
+		filter = &FileFilter{
+			Patterns: patterns,
+		}
+	}
+	entries, err := appendFileChooserEntries(nil, fsys, dir, filter, hiddenVisible, dirsOnly)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		name := e.name
+		if e.isDir {
+			name += "/"
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func BreadcrumbPaths(dir string) []string {
+	return breadcrumbPaths(dir)
+}
+
+func FileChooserSavePath(fsys fs.FS, dir string, name string) (string, bool, bool, error) {
+	return fileChooserSavePath(fsys, dir, name)
+}
+
+func FormatFileSize(size int64) string {
+	return formatFileSize(size)
+}
+
+func ParseHexColor(str string) (color.NRGBA, bool) {
+	return parseHexColor(str)
+}
+
+func FormatHexColor(clr color.NRGBA) string {
+	return formatHexColor(clr)
+}
+
+func ParseOklchColor(str string) (oklab.Oklch, bool) {
+	return parseOklchColor(str)
+}
+
+func FormatOklchColor(value oklab.Oklch) string {
+	return formatOklchColor(value)
+}
+
+func OklchToNRGBA(value oklab.Oklch) color.NRGBA {
+	return oklchToNRGBA(value)
+}
+
+func CalendarFirstWeekday(tags []language.Tag) time.Weekday {
+	return calendarLocaleForTags(tags).firstWeekday
+}
+
+func CalendarTwelveHour(tags []language.Tag) bool {
+	return calendarLocaleForTags(tags).twelveHour
+}
+
+func CalendarMonthYear(tags []language.Tag, year int, month time.Month) string {
+	return calendarLocaleForTags(tags).monthYear(year, month)
+}
+
+func FormatDate(tags []language.Tag, date time.Time) string {
+	return calendarLocaleForTags(tags).formatDate(date)
+}
+
+func ParseDate(tags []language.Tag, str string) (time.Time, bool) {
+	return calendarLocaleForTags(tags).parseDate(str, time.UTC)
+}
+
+func CalendarGridStart(year int, month time.Month, firstWeekday time.Weekday) time.Time {
+	return calendarGridStart(year, month, firstWeekday)
+}
+
+func AddMonthsClamped(date time.Time, months int) time.Time {
+	return addMonthsClamped(date, months)
+}
+
+func (c *Calendar) SelectDate(date time.Time) {
+	c.selectDate(date)
+}
+
+func VisibleTabRange(widths []int, width int, start int, selected int) (int, int) {
+	return visibleTabRange(widths, width, start, selected)
+}
+
+func (t *Toggle) PullValueFromBinding() {
+	t.pullValueFromBinding(nil)
+}
+
+func (n *NumberInput) PullValueFromBinding() {
+	n.abstractNumberInput.pullValueFromBinding(nil)
+}
@@ -0,0 +1,740 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/layout"
+)
+
+type FileChooserMode int
+
+const (
+	// FileChooserModeOpen chooses existing files.
+	FileChooserModeOpen FileChooserMode = iota
+
+	// FileChooserModeSave chooses a file name to save, which might not exist.
+	FileChooserModeSave
+
+	// FileChooserModeFolder chooses a directory.
+	FileChooserModeFolder
+)
+
+// FileFilter filters the files shown in a FileChooser. Directories are always shown.
+type FileFilter struct {
+	Name string
+
+	// Patterns is the patterns of the file names in the path.Match syntax, e.g. "*.png".
+	// The patterns are matched case-insensitively. An empty Patterns matches all the files.
+	Patterns []string
+}
+
+func (f *FileFilter) match(name string) bool {
+	if len(f.Patterns) == 0 {
+		return true
+	}
+	name = strings.ToLower(name)
+	for _, p := range f.Patterns {
+		if ok, _ := path.Match(strings.ToLower(p), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FileBookmark is a shortcut to a directory in a FileChooser.
+type FileBookmark struct {
+	Name string
+	Path string
+}
+
+// FileChooser is a dialog to choose files or a directory in a file system.
+//
+// The file system is an fs.FS, and the paths are slash-separated and unrooted as fs.ValidPath requires.
+// For example, use os.DirFS("/") for the whole local file system, and testing/fstest.MapFS for tests.
+//
+// Append a FileChooser to a widget, and call Open to show it. Its bounds are not used.
+type FileChooser struct {
+	guigui.DefaultWidget
+
+	dialog          Dialog
+	content         fileChooserContent
+	overwriteDialog Dialog
+
+	fsys          fs.FS
+	mode          FileChooserMode
+	dir           string
+	rootName      string
+	filters       []FileFilter
+	filterIndex   int
+	hiddenVisible bool
+	bookmarks     []FileBookmark
+	multiSelect   bool
+	title         string
+	fileName      string
+
+	entries    []fileChooserEntry
+	err        error
+	sortColumn int
+	sortOrder  TableSortOrder
+
+	onSelected func(paths []string)
+	onCanceled func()
+}
+
+func (f *FileChooser) SetFS(fsys fs.FS) {
+	f.fsys = fsys
+	f.reload()
+}
+
+func (f *FileChooser) SetMode(mode FileChooserMode) {
+	f.mode = mode
+	f.reload()
+}
+
+// SetDirectory sets the current directory. The root directory is ".".
+func (f *FileChooser) SetDirectory(dir string) {
+	f.navigate(dir)
+}
+
+// Directory returns the current directory.
+func (f *FileChooser) Directory() string {
+	if f.dir == "" {
+		return "."
+	}
+	return f.dir
+}
+
+// SetRootName sets the name of the root directory shown in the path. The default is "/".
+func (f *FileChooser) SetRootName(name string) {
+	f.rootName = name
+}
+
+func (f *FileChooser) SetTitle(title string) {
+	f.title = title
+}
+
+// SetFileName sets the file name in the save mode.
+func (f *FileChooser) SetFileName(name string) {
+	f.fileName = name
+	f.content.nameInput.SetValue(name)
+}
+
+func (f *FileChooser) SetFilters(filters []FileFilter) {
+	f.filters = adjustSliceSize(f.filters, len(filters))
+	copy(f.filters, filters)
+	if f.filterIndex >= len(filters) {
+		f.filterIndex = 0
+	}
+	f.reload()
+}
+
+// SetHiddenFilesVisible sets whether the files whose names start with '.' are shown.
+func (f *FileChooser) SetHiddenFilesVisible(visible bool) {
+	f.hiddenVisible = visible
+	f.reload()
+}
+
+func (f *FileChooser) SetBookmarks(bookmarks []FileBookmark) {
+	f.bookmarks = adjustSliceSize(f.bookmarks, len(bookmarks))
+	copy(f.bookmarks, bookmarks)
+}
+
+// SetMultiSelect sets whether multiple files can be chosen in the open mode.
+func (f *FileChooser) SetMultiSelect(multiSelect bool) {
+	f.multiSelect = multiSelect
+}
+
+// SetOnSelected sets the function called with the chosen paths when the dialog is accepted.
+func (f *FileChooser) SetOnSelected(fn func(paths []string)) {
+	f.onSelected = fn
+}
+
+// SetOnCanceled sets the function called when the dialog is canceled.
+func (f *FileChooser) SetOnCanceled(fn func()) {
+	f.onCanceled = fn
+}
+
+func (f *FileChooser) Open(context *guigui.Context) {
+	f.reload()
+	f.content.table.SelectItemByIndex(-1)
+	if f.mode == FileChooserModeSave {
+		f.content.nameInput.SetValue(f.fileName)
+		f.dialog.SetInitialFocus(&f.content.nameInput)
+	} else {
+		f.dialog.SetInitialFocus(&f.content.table.list)
+	}
+	f.dialog.Open(context)
+}
+
+func (f *FileChooser) Close() {
+	f.dialog.Close()
+}
+
+func (f *FileChooser) IsOpen() bool {
+	return f.dialog.IsOpen()
+}
+
+func (f *FileChooser) acceptButtonText() string {
+	switch f.mode {
+	case FileChooserModeSave:
+		return "Save"
+	case FileChooserModeFolder:
+		return "Select"
+	default:
+		return "Open"
+	}
+}
+
+func (f *FileChooser) defaultTitle() string {
+	switch f.mode {
+	case FileChooserModeSave:
+		return "Save File"
+	case FileChooserModeFolder:
+		return "Select Folder"
+	default:
+		return "Open File"
+	}
+}
+
+func (f *FileChooser) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	title := f.title
+	if title == "" {
+		title = f.defaultTitle()
+	}
+	f.dialog.SetTitle(title)
+	f.dialog.SetMovable(true)
+	f.content.chooser = f
+	f.dialog.SetContent(&f.content)
+	f.dialog.SetButtons([]DialogButton{
+		{
+			Text:   "Cancel",
+			Cancel: true,
+		},
+		{
+			Text:     f.acceptButtonText(),
+			Default:  true,
+			KeepOpen: true,
+		},
+	})
+	f.dialog.SetOnButtonPressed(func(index int) {
+		if index == 1 {
+			f.accept(context)
+			return
+		}
+		if f.onCanceled != nil {
+			f.onCanceled()
+		}
+	})
+	appender.AppendChildWidget(&f.dialog)
+	return nil
+}
+
+// navigate changes the current directory.
+func (f *FileChooser) navigate(dir string) {
+	if dir == "" || !fs.ValidPath(dir) {
+		dir = "."
+	}
+	f.dir = dir
+	f.content.table.SelectItemByIndex(-1)
+	f.content.table.JumpToItemIndex(0)
+	f.reload()
+}
+
+// reload reads the entries of the current directory.
+func (f *FileChooser) reload() {
+	f.entries = f.entries[:0]
+	f.err = nil
+	if f.fsys != nil {
+		var filter *FileFilter
+		if f.filterIndex < len(f.filters) {
+			filter = &f.filters[f.filterIndex]
+		}
+		f.entries, f.err = appendFileChooserEntries(f.entries, f.fsys, f.Directory(), filter, f.hiddenVisible, f.mode == FileChooserModeFolder)
+	}
+	sortFileChooserEntries(f.entries, f.sortColumn, f.sortOrder)
+	f.content.updateRows()
+	guigui.RequestRedraw(f)
+}
+
+// activate opens the directory at the index, or accepts the file at the index.
+func (f *FileChooser) activate(context *guigui.Context, index int) {
+	if index < 0 || index >= len(f.entries) {
+		return
+	}
+	e := f.entries[index]
+	if e.isDir {
+		f.navigate(path.Join(f.Directory(), e.name))
+		return
+	}
+	switch f.mode {
+	case FileChooserModeOpen:
+		f.finish([]string{path.Join(f.Directory(), e.name)})
+	case FileChooserModeSave:
+		f.content.nameInput.SetValue(e.name)
+		f.accept(context)
+	}
+}
+
+// accept chooses the selected entries, or the file name in the save mode.
+func (f *FileChooser) accept(context *guigui.Context) {
+	dir := f.Directory()
+	indices := f.content.table.AppendSelectedItemIndices(nil)
+
+	switch f.mode {
+	case FileChooserModeOpen:
+		if len(indices) == 1 && f.entries[indices[0]].isDir {
+			f.navigate(path.Join(dir, f.entries[indices[0]].name))
+			return
+		}
+		var paths []string
+		for _, i := range indices {
+			if !f.entries[i].isDir {
+				paths = append(paths, path.Join(dir, f.entries[i].name))
+			}
+		}
+		if len(paths) == 0 {
+			return
+		}
+		f.finish(paths)
+
+	case FileChooserModeFolder:
+		p := dir
+		if len(indices) == 1 && f.entries[indices[0]].isDir {
+			p = path.Join(dir, f.entries[indices[0]].name)
+		}
+		f.finish([]string{p})
+
+	case FileChooserModeSave:
+		name := strings.TrimSpace(f.content.nameInput.Value())
+		if name == "" {
+			return
+		}
+		p, exists, isDir, err := fileChooserSavePath(f.fsys, dir, name)
+		if err != nil {
+			f.err = err
+			guigui.RequestRedraw(f)
+			return
+		}
+		if isDir {
+			f.content.nameInput.SetValue("")
+			f.navigate(p)
+			return
+		}
+		if exists {
+			f.confirmOverwrite(context, p)
+			return
+		}
+		f.finish([]string{p})
+	}
+}
+
+func (f *FileChooser) confirmOverwrite(context *guigui.Context, p string) {
+	f.overwriteDialog.SetTitle("Replace File")
+	f.overwriteDialog.SetMessage(fmt.Sprintf("%q already exists. Do you want to replace it?", path.Base(p)))
+	f.overwriteDialog.SetButtons([]DialogButton{
+		{
+			Text:   "Cancel",
+			Cancel: true,
+		},
+		{
+			Text:    "Replace",
+			Default: true,
+		},
+	})
+	f.overwriteDialog.SetOnButtonPressed(func(index int) {
+		if index == 1 {
+			f.finish([]string{p})
+		}
+	})
+	f.overwriteDialog.Open(context)
+}
+
+func (f *FileChooser) finish(paths []string) {
+	f.dialog.Close()
+	if f.onSelected != nil {
+		f.onSelected(paths)
+	}
+}
+
+type fileChooserEntry struct {
+	name    string
+	isDir   bool
+	size    int64
+	modTime time.Time
+}
+
+// appendFileChooserEntries appends the entries in the directory to entries, sorted by names with directories first.
+func appendFileChooserEntries(entries []fileChooserEntry, fsys fs.FS, dir string, filter *FileFilter, hiddenVisible bool, dirsOnly bool) ([]fileChooserEntry, error) {
+	des, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return entries, err
+	}
+	for _, de := range des {
+		name := de.Name()
+		if !hiddenVisible && strings.HasPrefix(name, ".") {
+			continue
+		}
+		isDir := de.IsDir()
+		if !isDir && de.Type()&fs.ModeSymlink != 0 {
+			// Follow a symbolic link to know whether it is a directory.
+			if fi, err := fs.Stat(fsys, path.Join(dir, name)); err == nil {
+				isDir = fi.IsDir()
+			}
+		}
+		if dirsOnly && !isDir {
+			continue
+		}
+		if !isDir && filter != nil && !filter.match(name) {
+			continue
+		}
+		e := fileChooserEntry{
+			name:  name,
+			isDir: isDir,
+		}
+		if fi, err := de.Info(); err == nil {
+			e.size = fi.Size()
+			e.modTime = fi.ModTime()
+		}
+		entries = append(entries, e)
+	}
+	sortFileChooserEntries(entries, 0, TableSortOrderNone)
+	return entries, nil
+}
+
+const (
+	fileChooserColumnName = iota
+	fileChooserColumnSize
+	fileChooserColumnModified
+)
+
+// sortFileChooserEntries sorts the entries by the column with directories first.
+// TableSortOrderNone sorts the entries by names.
+func sortFileChooserEntries(entries []fileChooserEntry, column int, order TableSortOrder) {
+	slices.SortStableFunc(entries, func(a, b fileChooserEntry) int {
+		if a.isDir != b.isDir {
+			if a.isDir {
+				return -1
+			}
+			return 1
+		}
+		var c int
+		switch column {
+		case fileChooserColumnSize:
+			c = compareInt64(a.size, b.size)
+		case fileChooserColumnModified:
+			c = a.modTime.Compare(b.modTime)
+		}
+		if c == 0 || order == TableSortOrderNone {
+			c = strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+			if c == 0 {
+				c = strings.Compare(a.name, b.name)
+			}
+		}
+		if order == TableSortOrderDescending {
+			c = -c
+		}
+		return c
+	})
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// fileChooserSavePath returns the path of name in dir to save, and whether the path exists and is a directory.
+func fileChooserSavePath(fsys fs.FS, dir string, name string) (p string, exists bool, isDir bool, err error) {
+	p = path.Join(dir, name)
+	if strings.HasPrefix(name, "/") || !fs.ValidPath(p) || p == "." {
+		return "", false, false, fmt.Errorf("basicwidget: invalid file name: %q", name)
+	}
+	if fsys == nil {
+		return p, false, false, nil
+	}
+	fi, err := fs.Stat(fsys, p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, false, false, nil
+	}
+	if err != nil {
+		return "", false, false, err
+	}
+	return p, true, fi.IsDir(), nil
+}
+
+// breadcrumbPaths returns the paths from the root to dir, e.g. ".", "a", "a/b" for "a/b".
+func breadcrumbPaths(dir string) []string {
+	paths := []string{"."}
+	if dir == "" || dir == "." {
+		return paths
+	}
+	for i := range len(dir) {
+		if dir[i] == '/' {
+			paths = append(paths, dir[:i])
+		}
+	}
+	return append(paths, dir)
+}
+
+func formatFileSize(size int64) string {
+	if size < 1000 {
+		return fmt.Sprintf("%d B", size)
+	}
+	s := float64(size)
+	for _, unit := range []string{"KB", "MB", "GB", "TB"} {
+		s /= 1000
+		if s < 1000 || unit == "TB" {
+			return fmt.Sprintf("%.1f %s", s, unit)
+		}
+	}
+	return ""
+}
+
+type fileChooserContent struct {
+	guigui.DefaultWidget
+
+	chooser *FileChooser
+
+	breadcrumb   []Button
+	bookmarkList List[int]
+	table        Table[string]
+	errorText    Text
+	nameText     Text
+	nameInput    TextInput
+	filterList   DropdownList[int]
+	hiddenText   Text
+	hiddenToggle Toggle
+
+	rows          []TableRow[string]
+	bookmarkItems []ListItem[int]
+	filterItems   []DropdownListItem[int]
+}
+
+func (c *fileChooserContent) updateRows() {
+	f := c.chooser
+	if f == nil {
+		return
+	}
+	c.rows = adjustSliceSize(c.rows, len(f.entries))
+	for i, e := range f.entries {
+		cells := adjustSliceSize(c.rows[i].Cells, 3)
+		if e.isDir {
+			cells[0] = TableCell{Text: e.name + "/"}
+			cells[1] = TableCell{}
+		} else {
+			cells[0] = TableCell{Text: e.name}
+			cells[1] = TableCell{Text: formatFileSize(e.size)}
+		}
+		cells[2] = TableCell{}
+		if !e.modTime.IsZero() {
+			cells[2].Text = e.modTime.Format("2006-01-02 15:04")
+		}
+		c.rows[i] = TableRow[string]{
+			Cells: cells,
+			ID:    e.name,
+		}
+	}
+	c.table.SetItems(c.rows)
+}
+
+func (c *fileChooserContent) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	f := c.chooser
+	u := UnitSize(context)
+	bounds := context.Bounds(c)
+
+	// The breadcrumb path.
+	paths := breadcrumbPaths(f.Directory())
+	c.breadcrumb = adjustSliceSize(c.breadcrumb, len(paths))
+	rowH := u
+	for i, p := range paths {
+		name := path.Base(p)
+		if p == "." {
+			name = f.rootName
+			if name == "" {
+				name = "/"
+			}
+		}
+		c.breadcrumb[i].SetText(name)
+		c.breadcrumb[i].SetTextBold(i == len(paths)-1)
+		c.breadcrumb[i].SetOnUp(func() {
+			f.navigate(p)
+		})
+		rowH = max(rowH, c.breadcrumb[i].DefaultSize(context).Y)
+	}
+	// Show the last segments that fit in the width.
+	gap := u / 4
+	first := len(paths) - 1
+	w := c.breadcrumb[first].DefaultSize(context).X
+	for first > 0 {
+		bw := c.breadcrumb[first-1].DefaultSize(context).X + gap
+		if w+bw > bounds.Dx() {
+			break
+		}
+		w += bw
+		first--
+	}
+	x := bounds.Min.X
+	for i := first; i < len(paths); i++ {
+		bw := c.breadcrumb[i].DefaultSize(context).X
+		appender.AppendChildWidgetWithBounds(&c.breadcrumb[i], image.Rect(x, bounds.Min.Y, x+bw, bounds.Min.Y+rowH))
+		x += bw + gap
+	}
+
+	// The options at the bottom.
+	optionsH := max(c.filterList.DefaultSize(context).Y, c.hiddenToggle.DefaultSize(context).Y)
+	y1 := bounds.Max.Y - optionsH
+	c.hiddenText.SetValue("Hidden files")
+	c.hiddenText.SetVerticalAlign(VerticalAlignMiddle)
+	c.hiddenToggle.SetValue(f.hiddenVisible)
+	c.hiddenToggle.SetOnValueChanged(func(value bool) {
+		f.SetHiddenFilesVisible(value)
+	})
+	{
+		tw := c.hiddenText.DefaultSize(context).X
+		ts := c.hiddenToggle.DefaultSize(context)
+		r := image.Rect(bounds.Max.X-ts.X, y1+(optionsH-ts.Y)/2, bounds.Max.X, y1+(optionsH-ts.Y)/2+ts.Y)
+		appender.AppendChildWidgetWithBounds(&c.hiddenToggle, r)
+		appender.AppendChildWidgetWithBounds(&c.hiddenText, image.Rect(r.Min.X-u/4-tw, y1, r.Min.X-u/4, y1+optionsH))
+	}
+	if len(f.filters) > 0 && f.mode != FileChooserModeFolder {
+		c.filterItems = adjustSliceSize(c.filterItems, len(f.filters))
+		for i, filter := range f.filters {
+			c.filterItems[i] = DropdownListItem[int]{
+				Text: filter.Name,
+				ID:   i,
+			}
+		}
+		c.filterList.SetItems(c.filterItems)
+		c.filterList.SelectItemByIndex(f.filterIndex)
+		c.filterList.SetOnItemSelected(func(index int) {
+			if f.filterIndex == index {
+				return
+			}
+			f.filterIndex = index
+			f.reload()
+		})
+		s := c.filterList.DefaultSize(context)
+		appender.AppendChildWidgetWithBounds(&c.filterList, image.Rect(bounds.Min.X, y1, bounds.Min.X+s.X, y1+optionsH))
+	}
+
+	// The file name in the save mode.
+	if f.mode == FileChooserModeSave {
+		h := c.nameInput.DefaultSize(context).Y
+		y0 := y1 - u/2 - h
+		c.nameInput.SetOnEnterPressed(func(text string) {
+			f.accept(context)
+		})
+		c.nameText.SetValue("Name:")
+		c.nameText.SetVerticalAlign(VerticalAlignMiddle)
+		tw := c.nameText.DefaultSize(context).X
+		appender.AppendChildWidgetWithBounds(&c.nameText, image.Rect(bounds.Min.X, y0, bounds.Min.X+tw, y0+h))
+		appender.AppendChildWidgetWithBounds(&c.nameInput, image.Rect(bounds.Min.X+tw+u/4, y0, bounds.Max.X, y0+h))
+		y1 = y0
+	}
+
+	// The bookmarks and the entries.
+	middle := image.Rect(bounds.Min.X, bounds.Min.Y+rowH+u/2, bounds.Max.X, y1-u/2)
+	if len(f.bookmarks) > 0 {
+		c.bookmarkItems = adjustSliceSize(c.bookmarkItems, len(f.bookmarks))
+		for i, b := range f.bookmarks {
+			c.bookmarkItems[i] = ListItem[int]{
+				Text: b.Name,
+				ID:   i,
+			}
+		}
+		c.bookmarkList.SetStyle(ListStyleSidebar)
+		c.bookmarkList.SetItems(c.bookmarkItems)
+		c.bookmarkList.SetOnItemSelected(func(index int) {
+			if index < 0 || index >= len(f.bookmarks) {
+				return
+			}
+			f.navigate(f.bookmarks[index].Path)
+		})
+		// Select the bookmark of the current directory if any.
+		c.bookmarkList.SelectItemByIndex(slices.IndexFunc(f.bookmarks, func(b FileBookmark) bool {
+			return path.Clean(b.Path) == f.Directory()
+		}))
+		bw := 6 * u
+		appender.AppendChildWidgetWithBounds(&c.bookmarkList, image.Rect(middle.Min.X, middle.Min.Y, middle.Min.X+bw, middle.Max.Y))
+		middle.Min.X += bw + u/2
+	}
+
+	c.table.SetColumns([]TableColumn{
+		{
+			Title:    "Name",
+			Width:    layout.FlexibleSize(1),
+			MinWidth: 6 * u,
+			Sortable: true,
+		},
+		{
+			Title:           "Size",
+			Width:           layout.FixedSize(4 * u),
+			HorizontalAlign: HorizontalAlignEnd,
+			Sortable:        true,
+		},
+		{
+			Title:    "Modified",
+			Width:    layout.FixedSize(6 * u),
+			Sortable: true,
+		},
+	})
+	if f.mode == FileChooserModeOpen && f.multiSelect {
+		c.table.SetSelectionMode(ListSelectionModeMultiple)
+	} else {
+		c.table.SetSelectionMode(ListSelectionModeSingle)
+	}
+	c.table.SetOnSortChanged(func(column int, order TableSortOrder) {
+		f.sortColumn = column
+		f.sortOrder = order
+		c.table.SelectItemByIndex(-1)
+		f.reload()
+	})
+	c.table.SetOnItemActivated(func(index int) {
+		f.activate(context, index)
+	})
+	c.table.SetOnSelectionChanged(func(indices []int) {
+		// Fill the file name with the selected file in the save mode.
+		if f.mode != FileChooserModeSave || len(indices) != 1 {
+			return
+		}
+		if e := f.entries[indices[0]]; !e.isDir {
+			c.nameInput.SetValue(e.name)
+		}
+	})
+	appender.AppendChildWidgetWithBounds(&c.table, middle)
+
+	if f.err != nil {
+		c.errorText.SetValue(f.err.Error())
+		c.errorText.SetMultiline(true)
+		c.errorText.SetAutoWrap(true)
+		c.errorText.SetHorizontalAlign(HorizontalAlignCenter)
+		c.errorText.SetVerticalAlign(VerticalAlignMiddle)
+		c.errorText.SetColor(DefaultDisabledListItemTextColor(context))
+		appender.AppendChildWidgetWithBounds(&c.errorText, middle.Inset(u))
+	}
+
+	appender.AppendChildWidget(&f.overwriteDialog)
+
+	return nil
+}
+
+func (c *fileChooserContent) DefaultSize(context *guigui.Context) image.Point {
+	u := UnitSize(context)
+	ab := context.AppBounds()
+	return image.Pt(min(32*u, ab.Dx()-4*u), min(18*u, ab.Dy()-8*u))
+}
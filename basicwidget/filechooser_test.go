@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"docs/readme.md":    {Data: []byte("readme")},
+		"docs/Notes.TXT":    {Data: []byte("notes")},
+		"docs/.secret":      {Data: []byte("secret")},
+		"docs/images/a.PNG": {Data: []byte("a")},
+		"docs/.cache/b":     {Data: []byte("b")},
+		"docs/zoo.png":      {Data: []byte("zoo")},
+		"top.txt":           {Data: []byte("top")},
+	}
+}
+
+func TestFileChooserEntries(t *testing.T) {
+	fsys := testFS()
+	testCases := []struct {
+		Dir           string
+		Patterns      []string
+		HiddenVisible bool
+		DirsOnly      bool
+		Want          []string
+	}{
+		{Dir: ".", Want: []string{"docs/", "top.txt"}},
+		{Dir: "docs", Want: []string{"images/", "Notes.TXT", "readme.md", "zoo.png"}},
+		{Dir: "docs", HiddenVisible: true, Want: []string{".cache/", "images/", ".secret", "Notes.TXT", "readme.md", "zoo.png"}},
+		{Dir: "docs", Patterns: []string{"*.png"}, Want: []string{"images/", "zoo.png"}},
+		{Dir: "docs", Patterns: []string{"*.txt", "*.md"}, Want: []string{"images/", "Notes.TXT", "readme.md"}},
+		{Dir: "docs", DirsOnly: true, HiddenVisible: true, Want: []string{".cache/", "images/"}},
+		{Dir: "docs/images", Patterns: []string{"*.png"}, Want: []string{"a.PNG"}},
+	}
+	for _, tc := range testCases {
+		got, err := basicwidget.FileChooserEntryNames(fsys, tc.Dir, tc.Patterns, tc.HiddenVisible, tc.DirsOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, tc.Want) {
+			t.Errorf("FileChooserEntryNames(%q, %q, %v, %v): got: %q, want: %q", tc.Dir, tc.Patterns, tc.HiddenVisible, tc.DirsOnly, got, tc.Want)
+		}
+	}
+
+	if _, err := basicwidget.FileChooserEntryNames(fsys, "missing", nil, false, false); err == nil {
+		t.Errorf("FileChooserEntryNames for a missing directory must return an error")
+	}
+}
+
+func TestBreadcrumbPaths(t *testing.T) {
+	testCases := []struct {
+		Dir  string
+		Want []string
+	}{
+		{Dir: ".", Want: []string{"."}},
+		{Dir: "a", Want: []string{".", "a"}},
+		{Dir: "a/b/c", Want: []string{".", "a", "a/b", "a/b/c"}},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.BreadcrumbPaths(tc.Dir); !slices.Equal(got, tc.Want) {
+			t.Errorf("BreadcrumbPaths(%q): got: %q, want: %q", tc.Dir, got, tc.Want)
+		}
+	}
+}
+
+func TestFileChooserSavePath(t *testing.T) {
+	fsys := testFS()
+	testCases := []struct {
+		Dir    string
+		Name   string
+		Path   string
+		Exists bool
+		IsDir  bool
+		Err    bool
+	}{
+		{Dir: "docs", Name: "new.txt", Path: "docs/new.txt"},
+		{Dir: "docs", Name: "readme.md", Path: "docs/readme.md", Exists: true},
+		{Dir: "docs", Name: "images", Path: "docs/images", Exists: true, IsDir: true},
+		{Dir: "docs", Name: "../top.txt", Path: "top.txt", Exists: true},
+		{Dir: ".", Name: "../escape.txt", Err: true},
+		{Dir: "docs", Name: "/abs.txt", Err: true},
+		{Dir: "docs", Name: "..", Err: true},
+	}
+	for _, tc := range testCases {
+		p, exists, isDir, err := basicwidget.FileChooserSavePath(fsys, tc.Dir, tc.Name)
+		if tc.Err {
+			if err == nil {
+				t.Errorf("FileChooserSavePath(%q, %q) must return an error", tc.Dir, tc.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FileChooserSavePath(%q, %q): %v", tc.Dir, tc.Name, err)
+			continue
+		}
+		if p != tc.Path || exists != tc.Exists || isDir != tc.IsDir {
+			t.Errorf("FileChooserSavePath(%q, %q): got: (%q, %v, %v), want: (%q, %v, %v)", tc.Dir, tc.Name, p, exists, isDir, tc.Path, tc.Exists, tc.IsDir)
+		}
+	}
+}
+
+func TestFormatFileSize(t *testing.T) {
+	testCases := []struct {
+		Size int64
+		Want string
+	}{
+		{Size: 0, Want: "0 B"},
+		{Size: 999, Want: "999 B"},
+		{Size: 1000, Want: "1.0 KB"},
+		{Size: 1536000, Want: "1.5 MB"},
+		{Size: 5e15, Want: "5000.0 TB"},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.FormatFileSize(tc.Size); got != tc.Want {
+			t.Errorf("FormatFileSize(%d): got: %q, want: %q", tc.Size, got, tc.Want)
+		}
+	}
+}
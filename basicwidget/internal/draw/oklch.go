@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package draw
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+const oklchShaderSource = `//kage:unit pixels
+
+package main
+
+const pi = 3.14159265358979
+
+// Mode is 0 for a chroma-lightness plane, 1 for a hue strip, 2 for an alpha strip, and 3 for a color over a checkerboard.
+var Mode int
+var Bounds vec4
+var Radius float
+var Hue float
+var MaxChroma float
+var StripLightness float
+var StripChroma float
+var Color vec3
+var Alpha float
+var CheckerSize float
+var CheckerColor0 vec3
+var CheckerColor1 vec3
+
+func oklchToLinearSRGB(l, c, h float) vec3 {
+	a := c * cos(h)
+	b := c * sin(h)
+
+	l_ := l + 0.3963377774*a + 0.2158037573*b
+	m_ := l - 0.1055613458*a - 0.0638541728*b
+	s_ := l - 0.0894841775*a - 1.2914855480*b
+
+	ll := l_ * l_ * l_
+	mm := m_ * m_ * m_
+	ss := s_ * s_ * s_
+
+	return vec3(
+		+4.0767416621*ll-3.3077115913*mm+0.2309699292*ss,
+		-1.2684380046*ll+2.6097574011*mm-0.3413193965*ss,
+		-0.0041960863*ll-0.7034186147*mm+1.7076147010*ss,
+	)
+}
+
+func toNonLinear(x float) float {
+	if x >= 0.0031308 {
+		return 1.055*pow(x, 1.0/2.4) - 0.055
+	}
+	return 12.92 * x
+}
+
+func linearToSRGB(rgb vec3) vec3 {
+	rgb = clamp(rgb, 0, 1)
+	return vec3(toNonLinear(rgb.r), toNonLinear(rgb.g), toNonLinear(rgb.b))
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c1 := Bounds.xy + vec2(Radius, Radius)
+	c2 := Bounds.zy + vec2(-Radius, Radius)
+	c3 := Bounds.xw + vec2(Radius, -Radius)
+	c4 := Bounds.zw + vec2(-Radius, -Radius)
+	if (dstPos.x < Bounds.x+Radius || dstPos.x >= Bounds.z-Radius) &&
+		(dstPos.y < Bounds.y+Radius || dstPos.y >= Bounds.w-Radius) &&
+		distance(c1, dstPos.xy) > Radius &&
+		distance(c2, dstPos.xy) > Radius &&
+		distance(c3, dstPos.xy) > Radius &&
+		distance(c4, dstPos.xy) > Radius {
+		discard()
+	}
+
+	rate := (dstPos.xy - Bounds.xy) / (Bounds.zw - Bounds.xy)
+	if Mode == 0 {
+		rgb := oklchToLinearSRGB(1-rate.y, rate.x*MaxChroma, Hue)
+		// Colors out of the sRGB gamut are not rendered.
+		const eps = 1.0 / 1024
+		if min(min(rgb.r, rgb.g), rgb.b) < -eps || max(max(rgb.r, rgb.g), rgb.b) > 1+eps {
+			return vec4(0)
+		}
+		return vec4(linearToSRGB(rgb), 1)
+	}
+	if Mode == 1 {
+		rgb := oklchToLinearSRGB(StripLightness, StripChroma, rate.x*2*pi)
+		return vec4(linearToSRGB(rgb), 1)
+	}
+	alpha := Alpha
+	if Mode == 2 {
+		alpha = rate.x
+	}
+	checker := CheckerColor0
+	if mod(floor(srcPos.x/CheckerSize)+floor(srcPos.y/CheckerSize), 2) >= 1 {
+		checker = CheckerColor1
+	}
+	return vec4(mix(checker, Color, alpha), 1)
+}
+`
+
+var oklchShader *ebiten.Shader
+
+func init() {
+	s, err := ebiten.NewShader([]byte(oklchShaderSource))
+	if err != nil {
+		panic(err)
+	}
+	oklchShader = s
+}
+
+func drawOklchShader(dst *ebiten.Image, bounds image.Rectangle, radius int, uniforms map[string]any) {
+	radius = adjustRadius(radius, bounds)
+	uniforms["Bounds"] = []float32{
+		float32(bounds.Min.X),
+		float32(bounds.Min.Y),
+		float32(bounds.Max.X),
+		float32(bounds.Max.Y),
+	}
+	uniforms["Radius"] = float32(radius)
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM.Translate(float64(bounds.Min.X), float64(bounds.Min.Y))
+	op.Uniforms = uniforms
+	dst.DrawRectShader(bounds.Dx(), bounds.Dy(), oklchShader, op)
+}
+
+// DrawOklchPlane draws the OKLCH colors of hue in radians, with the chroma from 0 to maxChroma horizontally
+// and the lightness from 1 to 0 vertically.
+// Colors out of the sRGB gamut are not drawn.
+func DrawOklchPlane(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, radius int, hue float64, maxChroma float64) {
+	drawOklchShader(dst, bounds, radius, map[string]any{
+		"Mode":      0,
+		"Hue":       float32(hue),
+		"MaxChroma": float32(maxChroma),
+	})
+}
+
+// DrawOklchHueStrip draws the OKLCH hues from 0 to 2π horizontally with the given lightness and chroma.
+func DrawOklchHueStrip(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, radius int, lightness, chroma float64) {
+	drawOklchShader(dst, bounds, radius, map[string]any{
+		"Mode":           1,
+		"StripLightness": float32(lightness),
+		"StripChroma":    float32(chroma),
+	})
+}
+
+// DrawAlphaStrip draws clr from transparent to opaque horizontally over a checkerboard.
+func DrawAlphaStrip(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, radius int, clr color.Color) {
+	drawCheckerboard(context, dst, bounds, radius, 2, clr)
+}
+
+// DrawColorOverCheckerboard draws clr over a checkerboard so that its transparency is visible.
+func DrawColorOverCheckerboard(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, radius int, clr color.Color) {
+	drawCheckerboard(context, dst, bounds, radius, 3, clr)
+}
+
+func drawCheckerboard(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, radius int, mode int, clr color.Color) {
+	r, g, b, a := clr.RGBA()
+	rgb := []float32{0, 0, 0}
+	if a > 0 {
+		rgb = []float32{float32(r) / float32(a), float32(g) / float32(a), float32(b) / float32(a)}
+	}
+	drawOklchShader(dst, bounds, radius, map[string]any{
+		"Mode":          mode,
+		"Color":         rgb,
+		"Alpha":         float32(a) / 0xffff,
+		"CheckerSize":   float32(max(int(4*context.Scale()), 1)),
+		"CheckerColor0": opaqueColorToVec3(Color(context.ColorMode(), ColorTypeBase, 1)),
+		"CheckerColor1": opaqueColorToVec3(Color(context.ColorMode(), ColorTypeBase, 0.85)),
+	})
+}
+
+func opaqueColorToVec3(clr color.Color) []float32 {
+	r, g, b, _ := clr.RGBA()
+	return []float32{float32(r) / 0xffff, float32(g) / 0xffff, float32(b) / 0xffff}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+// itemHeightCache caches the heights of items for virtualization.
+// An item that is not measured yet is assumed to have the estimated height.
+//
+// The differences from the estimated height are kept in a Fenwick tree,
+// so that the offset of an item is calculated in O(log n).
+type itemHeightCache struct {
+	estimated int
+
+	// heights is the measured heights. 0 means the item is not measured yet.
+	heights []int
+
+	// tree is a 1-based Fenwick tree of the differences between the measured and the estimated heights.
+	tree []int
+}
+
+// resize sets the item count and the estimated height, and reports whether either is changed.
+//
+// The measured heights are kept when only the item count is changed, and are cleared when the estimated height is changed.
+func (c *itemHeightCache) resize(count int, estimated int) bool {
+	if c.estimated != estimated {
+		c.estimated = estimated
+		c.heights = adjustSliceSize(c.heights, count)
+		clear(c.heights)
+		c.tree = adjustSliceSize(c.tree, count+1)
+		clear(c.tree)
+		return true
+	}
+
+	oldCount := len(c.heights)
+	if oldCount == count {
+		return false
+	}
+	c.heights = adjustSliceSize(c.heights, count)
+	c.tree = adjustSliceSize(c.tree, count+1)
+	// When the cache shrinks, the remaining nodes cover only the remaining items and are still valid.
+	// When the cache grows, a new node might cover existing items. The new items are not measured yet.
+	for i := oldCount + 1; i < len(c.tree); i++ {
+		c.tree[i] = c.diffSum(min(i, oldCount)) - c.diffSum(min(i-(i&-i), oldCount))
+	}
+	return true
+}
+
+func (c *itemHeightCache) count() int {
+	return len(c.heights)
+}
+
+func (c *itemHeightCache) height(index int) int {
+	if index < 0 || index >= len(c.heights) {
+		return 0
+	}
+	if h := c.heights[index]; h > 0 {
+		return h
+	}
+	return c.estimated
+}
+
+// setHeight sets the measured height of the item, and returns the change of the item height.
+func (c *itemHeightCache) setHeight(index int, height int) int {
+	if index < 0 || index >= len(c.heights) {
+		return 0
+	}
+	old := c.height(index)
+	if c.heights[index] == height {
+		return 0
+	}
+	c.heights[index] = height
+	diff := c.height(index) - old
+	for i := index + 1; i < len(c.tree); i += i & -i {
+		c.tree[i] += diff
+	}
+	return diff
+}
+
+// offset returns the sum of the heights of the items before the index.
+func (c *itemHeightCache) offset(index int) int {
+	index = min(max(index, 0), len(c.heights))
+	return index*c.estimated + c.diffSum(index)
+}
+
+// diffSum returns the sum of the differences from the estimated height of the items before the index.
+func (c *itemHeightCache) diffSum(index int) int {
+	var sum int
+	for i := index; i > 0; i -= i & -i {
+		sum += c.tree[i]
+	}
+	return sum
+}
+
+func (c *itemHeightCache) total() int {
+	return c.offset(len(c.heights))
+}
+
+// indexAt returns the index of the item at y, or -1 if there is no item at y.
+func (c *itemHeightCache) indexAt(y int) int {
+	if y < 0 || y >= c.total() {
+		return -1
+	}
+	// Find the last index whose offset is equal to or less than y.
+	lo, hi := 0, len(c.heights)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if c.offset(mid) <= y {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
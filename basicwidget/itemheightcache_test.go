@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func TestItemHeightCache(t *testing.T) {
+	var c basicwidget.ItemHeightCache
+	if !c.Resize(100000, 20) {
+		t.Errorf("Resize: got: false, want: true")
+	}
+	if c.Resize(100000, 20) {
+		t.Errorf("Resize with the same parameters: got: true, want: false")
+	}
+	if got, want := c.Total(), 2000000; got != want {
+		t.Errorf("Total: got: %d, want: %d", got, want)
+	}
+
+	if got, want := c.SetHeight(10, 50), 30; got != want {
+		t.Errorf("SetHeight(10, 50): got: %d, want: %d", got, want)
+	}
+	if got, want := c.SetHeight(10, 50), 0; got != want {
+		t.Errorf("SetHeight(10, 50) again: got: %d, want: %d", got, want)
+	}
+	if got, want := c.SetHeight(20, 10), -10; got != want {
+		t.Errorf("SetHeight(20, 10): got: %d, want: %d", got, want)
+	}
+
+	testCases := []struct {
+		index int
+		want  int
+	}{
+		{index: 0, want: 0},
+		{index: 10, want: 200},
+		{index: 11, want: 250},
+		{index: 20, want: 430},
+		{index: 21, want: 440},
+		{index: 100000, want: 2000020},
+	}
+	for _, tc := range testCases {
+		if got := c.Offset(tc.index); got != tc.want {
+			t.Errorf("Offset(%d): got: %d, want: %d", tc.index, got, tc.want)
+		}
+	}
+	if got, want := c.Height(10), 50; got != want {
+		t.Errorf("Height(10): got: %d, want: %d", got, want)
+	}
+	if got, want := c.Height(11), 20; got != want {
+		t.Errorf("Height(11): got: %d, want: %d", got, want)
+	}
+
+	indexTestCases := []struct {
+		y    int
+		want int
+	}{
+		{y: -1, want: -1},
+		{y: 0, want: 0},
+		{y: 199, want: 9},
+		{y: 200, want: 10},
+		{y: 249, want: 10},
+		{y: 250, want: 11},
+		{y: 435, want: 20},
+		{y: 440, want: 21},
+		{y: 2000019, want: 99999},
+		{y: 2000020, want: -1},
+	}
+	for _, tc := range indexTestCases {
+		if got := c.IndexAt(tc.y); got != tc.want {
+			t.Errorf("IndexAt(%d): got: %d, want: %d", tc.y, got, tc.want)
+		}
+	}
+
+	if !c.Resize(100000, 30) {
+		t.Errorf("Resize with a different estimation: got: false, want: true")
+	}
+	if got, want := c.Offset(11), 330; got != want {
+		t.Errorf("Offset(11) after Resize: got: %d, want: %d", got, want)
+	}
+}
+
+func TestItemHeightCacheResize(t *testing.T) {
+	var c basicwidget.ItemHeightCache
+	c.Resize(20, 10)
+	c.SetHeight(3, 30)
+	c.SetHeight(12, 5)
+	c.SetHeight(19, 20)
+
+	// Growing keeps the measured heights.
+	if !c.Resize(100, 10) {
+		t.Errorf("Resize to grow: got: false, want: true")
+	}
+	// Shrinking keeps the remaining measured heights.
+	if !c.Resize(15, 10) {
+		t.Errorf("Resize to shrink: got: false, want: true")
+	}
+	// Growing again doesn't restore the removed heights.
+	if !c.Resize(40, 10) {
+		t.Errorf("Resize to grow: got: false, want: true")
+	}
+
+	heights := map[int]int{3: 30, 12: 5}
+	var want int
+	for i := range 40 {
+		if got := c.Offset(i); got != want {
+			t.Errorf("Offset(%d): got: %d, want: %d", i, got, want)
+		}
+		h := 10
+		if v, ok := heights[i]; ok {
+			h = v
+		}
+		if got := c.Height(i); got != h {
+			t.Errorf("Height(%d): got: %d, want: %d", i, got, h)
+		}
+		want += h
+	}
+	if got := c.Total(); got != want {
+		t.Errorf("Total: got: %d, want: %d", got, want)
+	}
+
+	// Changing the estimation clears the measured heights.
+	if !c.Resize(40, 20) {
+		t.Errorf("Resize with a different estimation: got: false, want: true")
+	}
+	if got, want := c.Height(3), 20; got != want {
+		t.Errorf("Height(3): got: %d, want: %d", got, want)
+	}
+	if got, want := c.Total(), 800; got != want {
+		t.Errorf("Total: got: %d, want: %d", got, want)
+	}
+}
@@ -21,10 +21,28 @@ type List[T comparable] struct {
 	baseListItems   []baseListItem[T]
 	listItems       []ListItem[T]
 	listItemWidgets []listItemWidget[T]
+	dataSource      ListDataSource[T]
+
+	// lazyItemWidgets is the item widgets for the visible items keyed by the item indices, used with a data source.
+	lazyItemWidgets    map[int]*listItemWidget[T]
+	tmpFreeItemWidgets []*listItemWidget[T]
 
 	listItemHeightPlus1 int
 }
 
+// ListDataSource provides the items of a List lazily.
+//
+// A List with a data source builds and draws only the visible items,
+// so the List can have a huge number of items.
+type ListDataSource[T comparable] interface {
+	// ItemCount returns the number of the items.
+	ItemCount() int
+
+	// ItemByIndex returns the item at the index.
+	// ItemByIndex is called only for indices in [0, ItemCount()).
+	ItemByIndex(index int) ListItem[T]
+}
+
 /*type ListCallback struct {
 	OnItemSelected    func(index int)
 	OnItemEditStarted func(index int, str string) (from int)
@@ -41,7 +59,16 @@ type ListItem[T comparable] struct {
 	Disabled  bool
 	Border    bool
 	Movable   bool
-	ID        T
+	Checked   bool
+	Icon      *ebiten.Image
+
+	// Radio reports whether the item is one of the exclusive choices. A checked radio item shows a dot instead of a checkmark.
+	Radio bool
+
+	// Shortcut is a hint text of a keyboard shortcut, shown at the end of the item.
+	Shortcut string
+
+	ID T
 }
 
 func (t *ListItem[T]) selectable() bool {
@@ -105,6 +132,25 @@ func (l *List[T]) SetOnItemSelected(f func(index int)) {
 	l.list.SetOnItemSelected(f)
 }
 
+// SetOnSelectionChanged sets the function called with the selected indices in ascending order when the selection is changed.
+func (l *List[T]) SetOnSelectionChanged(f func(indices []int)) {
+	l.list.SetOnSelectionChanged(f)
+}
+
+// SetOnItemActivated sets the function called when an item is double-clicked, double-tapped or Enter is pressed on an item.
+func (l *List[T]) SetOnItemActivated(f func(index int)) {
+	l.list.onItemActivated = f
+}
+
+// SetSelectionMode sets how many items can be selected.
+// The default mode is ListSelectionModeSingle.
+//
+// In ListSelectionModeMultiple, Ctrl-click (Cmd-click on macOS) toggles an item, Shift-click selects a range,
+// dragging from an empty space selects the items in the rubber band, and Ctrl+A (Cmd+A on macOS) selects all the items.
+func (l *List[T]) SetSelectionMode(mode ListSelectionMode) {
+	l.list.SetSelectionMode(mode)
+}
+
 func (l *List[T]) SetOnItemsMoved(f func(from, count, to int)) {
 	l.list.SetOnItemsMoved(f)
 }
@@ -117,29 +163,139 @@ func (l *List[T]) updateListItems() {
 	l.listItemWidgets = adjustSliceSize(l.listItemWidgets, len(l.listItems))
 	l.baseListItems = adjustSliceSize(l.baseListItems, len(l.listItems))
 
+	// Align texts when only some items have icons.
+	var hasIcons bool
+	for _, item := range l.listItems {
+		if item.Icon != nil {
+			hasIcons = true
+			break
+		}
+	}
+
 	for i, item := range l.listItems {
 		l.listItemWidgets[i].setListItem(item)
+		l.listItemWidgets[i].iconSpace = hasIcons
 		l.baseListItems[i] = l.listItemWidgets[i].listItem()
 	}
 	l.list.SetItems(l.baseListItems)
 }
 
+// updateListItemsByDataSource updates the item widgets only for the visible items provided by the data source.
+func (l *List[T]) updateListItemsByDataSource(context *guigui.Context) {
+	estimatedHeight := int(LineHeight(context))
+	if l.listItemHeightPlus1 > 0 {
+		estimatedHeight = l.listItemHeightPlus1 - 1
+	}
+	l.list.setItemProvider(l.dataSource.ItemCount(), l.dataSourceItem, estimatedHeight)
+
+	start, end := l.list.updateVisibleItemRange(context)
+	l.updateLazyItemWidgets(start, end)
+
+	l.listItems = adjustSliceSize(l.listItems, end-start)
+	var hasIcons bool
+	for i := start; i < end; i++ {
+		l.listItems[i-start] = l.dataSource.ItemByIndex(i)
+		if l.listItems[i-start].Icon != nil {
+			hasIcons = true
+		}
+	}
+	for i := start; i < end; i++ {
+		w := l.listItemWidget(i)
+		w.setListItem(l.listItems[i-start])
+		w.iconSpace = hasIcons
+	}
+}
+
+func (l *List[T]) dataSourceItem(index int) baseListItem[T] {
+	if w := l.listItemWidget(index); w != nil {
+		return w.listItem()
+	}
+	item := l.dataSource.ItemByIndex(index)
+	return baseListItem[T]{
+		Selectable: item.selectable(),
+		Movable:    item.Movable,
+		Checked:    item.Checked,
+		Radio:      item.Radio,
+		ID:         item.ID,
+		Text:       item.Text,
+	}
+}
+
+// updateLazyItemWidgets keeps the widgets of the items still visible in [start, end),
+// and reuses the other widgets for the newly visible items.
+func (l *List[T]) updateLazyItemWidgets(start, end int) {
+	l.tmpFreeItemWidgets = l.tmpFreeItemWidgets[:0]
+	for index, w := range l.lazyItemWidgets {
+		if index < start || index >= end {
+			l.tmpFreeItemWidgets = append(l.tmpFreeItemWidgets, w)
+			delete(l.lazyItemWidgets, index)
+		}
+	}
+	if l.lazyItemWidgets == nil {
+		l.lazyItemWidgets = map[int]*listItemWidget[T]{}
+	}
+	for i := start; i < end; i++ {
+		if _, ok := l.lazyItemWidgets[i]; ok {
+			continue
+		}
+		if n := len(l.tmpFreeItemWidgets); n > 0 {
+			l.lazyItemWidgets[i] = l.tmpFreeItemWidgets[n-1]
+			l.tmpFreeItemWidgets = l.tmpFreeItemWidgets[:n-1]
+			continue
+		}
+		l.lazyItemWidgets[i] = &listItemWidget[T]{}
+	}
+	// Drop the rest so that the pool has only the widgets for the visible items.
+	clear(l.tmpFreeItemWidgets)
+	l.tmpFreeItemWidgets = l.tmpFreeItemWidgets[:0]
+}
+
+// listItemWidget returns the item widget at the index, or nil if the item does not have its widget.
+func (l *List[T]) listItemWidget(index int) *listItemWidget[T] {
+	if l.dataSource != nil {
+		return l.lazyItemWidgets[index]
+	}
+	if index < 0 || index >= len(l.listItemWidgets) {
+		return nil
+	}
+	return &l.listItemWidgets[index]
+}
+
 func (l *List[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
 	context.SetSize(&l.list, context.Size(l))
 
-	l.updateListItems()
+	if l.dataSource != nil {
+		l.updateListItemsByDataSource(context)
+	} else {
+		l.updateListItems()
+	}
+	start, end := l.list.itemRangeToBuild()
 
 	appender.AppendChildWidgetWithPosition(&l.list, context.Position(l))
 
-	for i := range l.listItemWidgets {
-		item := &l.listItemWidgets[i]
-		item.text.SetBold(item.item.Header || l.list.style == ListStyleSidebar && l.SelectedItemIndex() == i)
-		item.text.SetColor(l.ItemTextColor(context, i))
-
+	menuItemWidth := context.Size(l).X - 2*RoundedCornerRadius(context) - 2*listItemPadding(context)
+	if l.list.hasCheckmarks() {
+		menuItemWidth -= listItemCheckmarkSize(context) + listItemTextAndImagePadding(context)
+	}
+	for i := start; i < end; i++ {
+		item := l.listItemWidget(i)
+		item.text.SetBold(item.item.Header || l.list.style == ListStyleSidebar && l.list.IsItemSelected(i))
+		clr := l.ItemTextColor(context, i)
+		item.text.SetColor(clr)
+		item.shortcut.SetColor(draw.ScaleAlpha(clr, 0.6))
+
+		w := guigui.DefaultSize
+		if l.list.style == ListStyleMenu {
+			// Widen the items in a menu to align the shortcuts and the submenu indicators at the end.
+			w = max(item.DefaultSize(context).X, menuItemWidth)
+		}
 		if l.listItemHeightPlus1 > 0 {
-			context.SetSize(item, image.Pt(guigui.DefaultSize, l.listItemHeightPlus1-1))
+			context.SetSize(item, image.Pt(w, l.listItemHeightPlus1-1))
 		} else {
-			context.SetSize(item, image.Pt(guigui.DefaultSize, guigui.DefaultSize))
+			context.SetSize(item, image.Pt(w, guigui.DefaultSize))
+		}
+		if l.dataSource != nil {
+			l.list.setItemHeight(context, i, context.Size(item).Y)
 		}
 	}
 
@@ -147,38 +303,52 @@ func (l *List[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetApp
 }
 
 func (l *List[T]) ItemTextColor(context *guigui.Context, index int) color.Color {
-	item := &l.listItemWidgets[index]
+	item, _ := l.ItemByIndex(index)
 	switch {
-	case l.list.style == ListStyleNormal && l.list.SelectedItemIndex() == index && item.selectable():
+	case l.list.style == ListStyleNormal && l.list.IsItemSelected(index) && item.selectable():
 		return DefaultActiveListItemTextColor(context)
-	case l.list.style == ListStyleSidebar && l.list.SelectedItemIndex() == index && item.selectable():
+	case l.list.style == ListStyleSidebar && l.list.IsItemSelected(index) && item.selectable():
 		return DefaultActiveListItemTextColor(context)
-	case l.list.style == ListStyleMenu && l.list.isHoveringVisible() && l.list.hoveredItemIndex(context) == index && item.selectable():
+	case l.list.style == ListStyleMenu && l.list.isHoveringVisible() && l.list.highlightedItemIndex(context) == index && item.selectable():
 		return DefaultActiveListItemTextColor(context)
-	case !item.selectable() && !item.item.Header:
+	case !item.selectable() && !item.Header:
 		return DefaultDisabledListItemTextColor(context)
-	case item.item.TextColor != nil:
-		return item.item.TextColor
+	case item.TextColor != nil:
+		return item.TextColor
 	default:
-		return draw.TextColor(context.ColorMode(), context.IsEnabled(item))
+		// An item without its widget is not built, and its color is not used for rendering.
+		if itemWidget := l.listItemWidget(index); itemWidget != nil {
+			return draw.TextColor(context.ColorMode(), context.IsEnabled(itemWidget))
+		}
+		return draw.TextColor(context.ColorMode(), context.IsEnabled(l))
 	}
 }
 
+// SelectedItemIndex returns the smallest index of the selected items, or -1 if no item is selected.
 func (l *List[T]) SelectedItemIndex() int {
 	return l.list.SelectedItemIndex()
 }
 
+// AppendSelectedItemIndices appends the selected indices in ascending order to indices.
+func (l *List[T]) AppendSelectedItemIndices(indices []int) []int {
+	return l.list.AppendSelectedItemIndices(indices)
+}
+
+func (l *List[T]) IsItemSelected(index int) bool {
+	return l.list.IsItemSelected(index)
+}
+
 func (l *List[T]) SelectedItem() (ListItem[T], bool) {
-	if l.list.SelectedItemIndex() < 0 || l.list.SelectedItemIndex() >= len(l.listItemWidgets) {
-		return ListItem[T]{}, false
-	}
-	return l.listItemWidgets[l.list.SelectedItemIndex()].item, true
+	return l.ItemByIndex(l.list.SelectedItemIndex())
 }
 
 func (l *List[T]) ItemByIndex(index int) (ListItem[T], bool) {
-	if index < 0 || index >= len(l.listItemWidgets) {
+	if index < 0 || index >= l.ItemsCount() {
 		return ListItem[T]{}, false
 	}
+	if l.dataSource != nil {
+		return l.dataSource.ItemByIndex(index), true
+	}
 	return l.listItemWidgets[index].item, true
 }
 
@@ -190,7 +360,9 @@ func (l *List[T]) SetItemsByStrings(strs []string) {
 	l.SetItems(items)
 }
 
+// SetItems sets the items, and removes the data source if set.
 func (l *List[T]) SetItems(items []ListItem[T]) {
+	l.dataSource = nil
 	l.listItems = adjustSliceSize(l.listItems, len(items))
 	copy(l.listItems, items)
 
@@ -199,18 +371,51 @@ func (l *List[T]) SetItems(items []ListItem[T]) {
 	l.updateListItems()
 }
 
+// SetDataSource sets the data source that provides the items lazily, instead of SetItems.
+// nil removes the data source.
+func (l *List[T]) SetDataSource(dataSource ListDataSource[T]) {
+	l.dataSource = dataSource
+	if dataSource == nil {
+		clear(l.lazyItemWidgets)
+		l.listItems = l.listItems[:0]
+		l.updateListItems()
+	}
+	guigui.RequestRedraw(l)
+}
+
 func (l *List[T]) ItemsCount() int {
+	if l.dataSource != nil {
+		return l.dataSource.ItemCount()
+	}
 	return len(l.listItemWidgets)
 }
 
 func (l *List[T]) ID(index int) any {
-	return l.listItemWidgets[index].item.ID
+	item, _ := l.ItemByIndex(index)
+	return item.ID
 }
 
 func (l *List[T]) SelectItemByIndex(index int) {
 	l.list.SelectItemByIndex(index)
 }
 
+// SelectItemsByIndices replaces the selection with the items at indices.
+// In ListSelectionModeSingle, only the item at the smallest index is selected.
+func (l *List[T]) SelectItemsByIndices(indices []int) {
+	l.list.SelectItemsByIndices(indices)
+}
+
+// SelectAll selects all the selectable items in ListSelectionModeMultiple.
+func (l *List[T]) SelectAll() {
+	l.list.SelectAll()
+}
+
+// BindSelectedItemIndex binds the selected item index to index in both directions.
+// -1 means no item is selected.
+func (l *List[T]) BindSelectedItemIndex(index *guigui.Observable[int]) {
+	l.list.bindSelectedItemIndex(index)
+}
+
 func (l *List[T]) SelectItemByID(id T) {
 	l.list.SelectItemByID(id)
 }
@@ -223,7 +428,12 @@ func (l *List[T]) SetStyle(style ListStyle) {
 	l.list.SetStyle(style)
 }
 
+// SetItemString sets the text of the item at the index.
+// SetItemString does nothing when a data source is set.
 func (l *List[T]) SetItemString(str string, index int) {
+	if l.dataSource != nil {
+		return
+	}
 	l.listItemWidgets[index].item.Text = str
 }
 
@@ -234,24 +444,73 @@ func (l *List[T]) DefaultSize(context *guigui.Context) image.Point {
 type listItemWidget[T comparable] struct {
 	guigui.DefaultWidget
 
-	item ListItem[T]
+	item      ListItem[T]
+	iconSpace bool
+
+	// hasSubmenu shows an indicator of a submenu at the end.
+	hasSubmenu bool
 
-	text Text
+	text     Text
+	icon     Image
+	shortcut Text
 }
 
 func (l *listItemWidget[T]) setListItem(listItem ListItem[T]) {
 	l.item = listItem
 	l.text.SetValue(listItem.Text)
+	l.shortcut.SetValue(listItem.Shortcut)
+}
+
+func listItemIconSize(context *guigui.Context) int {
+	return int(LineHeight(context))
+}
+
+func listItemSubmenuIndicatorSize(context *guigui.Context) int {
+	return int(LineHeight(context))
+}
+
+func listItemShortcutPadding(context *guigui.Context) int {
+	return UnitSize(context)
 }
 
 func (l *listItemWidget[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	bounds := context.Bounds(l)
 	if l.item.Content != nil {
-		appender.AppendChildWidgetWithBounds(l.item.Content, context.Bounds(l))
+		appender.AppendChildWidgetWithBounds(l.item.Content, bounds)
+	}
+
+	textBounds := bounds
+	if l.iconSpace {
+		if l.item.Icon != nil {
+			s := listItemIconSize(context)
+			p := image.Pt(bounds.Min.X, bounds.Min.Y+(bounds.Dy()-s)/2)
+			l.icon.SetImage(l.item.Icon)
+			appender.AppendChildWidgetWithBounds(&l.icon, image.Rectangle{
+				Min: p,
+				Max: p.Add(image.Pt(s, s)),
+			})
+		}
+		textBounds.Min.X += listItemIconSize(context) + listItemTextAndImagePadding(context)
+	}
+
+	if l.hasSubmenu {
+		textBounds.Max.X = max(textBounds.Max.X-listItemSubmenuIndicatorSize(context), textBounds.Min.X)
+		bounds.Max.X = textBounds.Max.X
+	}
+
+	if l.item.Shortcut != "" {
+		l.shortcut.SetValue(l.item.Shortcut)
+		l.shortcut.SetHorizontalAlign(HorizontalAlignEnd)
+		l.shortcut.SetVerticalAlign(VerticalAlignMiddle)
+		shortcutBounds := bounds
+		shortcutBounds.Min.X = max(bounds.Max.X-l.shortcut.DefaultSize(context).X, textBounds.Min.X)
+		textBounds.Max.X = max(shortcutBounds.Min.X-listItemShortcutPadding(context), textBounds.Min.X)
+		appender.AppendChildWidgetWithBounds(&l.shortcut, shortcutBounds)
 	}
 
 	l.text.SetValue(l.item.Text)
 	l.text.SetVerticalAlign(VerticalAlignMiddle)
-	appender.AppendChildWidgetWithBounds(&l.text, context.Bounds(l))
+	appender.AppendChildWidgetWithBounds(&l.text, textBounds)
 
 	return nil
 }
@@ -267,6 +526,24 @@ func (l *listItemWidget[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
 		vector.StrokeLine(dst, x0, y, x1, y, width, draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.8), false)
 		return
 	}
+	if l.hasSubmenu {
+		// Draw a triangle pointing to the right.
+		bounds := context.Bounds(l)
+		s := float32(listItemSubmenuIndicatorSize(context))
+		cx := float32(bounds.Max.X) - s/2
+		cy := float32(bounds.Min.Y+bounds.Max.Y) / 2
+		r := s / 5
+		var path vector.Path
+		path.MoveTo(cx-r/2, cy-r)
+		path.LineTo(cx+r, cy)
+		path.LineTo(cx-r/2, cy+r)
+		path.Close()
+		clr := l.text.color
+		if clr == nil {
+			clr = draw.TextColor(context.ColorMode(), context.IsEnabled(l))
+		}
+		vector.DrawFilledPath(dst, &path, clr, true, vector.FillRuleNonZero)
+	}
 	/*if l.item.Header {
 		bounds := context.Bounds(l)
 		draw.DrawRoundedRect(context, dst, bounds, draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.8), RoundedCornerRadius(context))
@@ -281,7 +558,17 @@ func (l *listItemWidget[T]) DefaultSize(context *guigui.Context) image.Point {
 	}
 
 	// Assume that every item can use a bold font.
-	w = max(w, l.text.boldTextSize(context).X)
+	tw := l.text.boldTextSize(context).X
+	if l.iconSpace {
+		tw += listItemIconSize(context) + listItemTextAndImagePadding(context)
+	}
+	if l.item.Shortcut != "" {
+		tw += listItemShortcutPadding(context) + l.shortcut.DefaultSize(context).X
+	}
+	if l.hasSubmenu {
+		tw += listItemSubmenuIndicatorSize(context)
+	}
+	w = max(w, tw)
 	h = max(h, int(LineHeight(context)))
 	if l.item.Border {
 		h = UnitSize(context) / 2
@@ -309,7 +596,10 @@ func (l *listItemWidget[T]) listItem() baseListItem[T] {
 		Content:    l,
 		Selectable: l.selectable(),
 		Movable:    l.item.Movable,
+		Checked:    l.item.Checked,
+		Radio:      l.item.Radio,
 		ID:         l.item.ID,
+		Text:       l.item.Text,
 	}
 }
 
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"testing"
+)
+
+func TestListLazyItemWidgets(t *testing.T) {
+	var l List[int]
+	l.updateLazyItemWidgets(0, 5)
+	if got, want := len(l.lazyItemWidgets), 5; got != want {
+		t.Fatalf("len(lazyItemWidgets): got: %d, want: %d", got, want)
+	}
+	widgets := map[int]*listItemWidget[int]{}
+	for i, w := range l.lazyItemWidgets {
+		widgets[i] = w
+	}
+
+	// The items still visible keep their widgets.
+	l.updateLazyItemWidgets(2, 7)
+	if got, want := len(l.lazyItemWidgets), 5; got != want {
+		t.Errorf("len(lazyItemWidgets): got: %d, want: %d", got, want)
+	}
+	for i := 2; i < 5; i++ {
+		if l.lazyItemWidgets[i] != widgets[i] {
+			t.Errorf("the widget for item %d is changed", i)
+		}
+	}
+	for i := 5; i < 7; i++ {
+		if l.lazyItemWidgets[i] == nil {
+			t.Errorf("item %d doesn't have its widget", i)
+		}
+	}
+
+	// The pool shrinks to the visible range.
+	l.updateLazyItemWidgets(3, 5)
+	if got, want := len(l.lazyItemWidgets), 2; got != want {
+		t.Errorf("len(lazyItemWidgets): got: %d, want: %d", got, want)
+	}
+	if l.lazyItemWidgets[2] != nil {
+		t.Errorf("item 2 has its widget after it becomes invisible")
+	}
+}
@@ -0,0 +1,564 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+)
+
+// MenuBarMenu is a top-level menu of a MenuBar.
+type MenuBarMenu struct {
+	// Title is the title of the menu.
+	// The character after '&' is the mnemonic, e.g. "&File" is opened by Alt+F. Use "&&" for '&' itself.
+	Title string
+
+	Items    []MenuItem
+	Disabled bool
+}
+
+// MenuItem is an item of a menu in a MenuBar.
+type MenuItem struct {
+	Text     string
+	Icon     *ebiten.Image
+	Header   bool
+	Disabled bool
+	Checked  bool
+
+	// Radio reports whether the item is one of the exclusive choices.
+	Radio bool
+
+	// Border is a separator line between items.
+	Border bool
+
+	// Shortcut is the accelerator of the item, or nil if the item has no shortcut.
+	// The shortcut is shown at the end of the item, and selects the item even while the menu is closed.
+	Shortcut *Shortcut
+
+	// OnSelected is called when the item is selected.
+	OnSelected func()
+
+	// Submenu is the items of the submenu.
+	Submenu []MenuItem
+}
+
+// MenuBar is a row of menu titles, typically at the top of a desktop app.
+//
+// A menu is opened by clicking its title. While a menu is open, hovering another title opens the other menu.
+// The menu bar is activated by F10, or by pressing and releasing Alt except on macOS,
+// and then the arrow keys move between the menus.
+// Alt and a mnemonic opens the menu directly.
+//
+// The shortcuts of the items work regardless of the focus, unless the focused widget handles the same keys.
+type MenuBar struct {
+	guigui.DefaultWidget
+
+	menus       []MenuBarMenu
+	titles      []menuBarTitle
+	titleBounds []image.Rectangle
+	popupMenu   PopupMenu[int]
+
+	openIndexPlus1 int
+
+	// activeIndexPlus1 is the index plus one of the title highlighted by the keyboard navigation.
+	activeIndexPlus1 int
+
+	// focusedBefore is the widget focused before the menu bar is activated.
+	focusedBefore guigui.Widget
+
+	// altAlone reports whether Alt is being pressed without any other keys.
+	altAlone bool
+
+	// flatItems is the items of the open menu including the submenu items. The ID of a menu item is the index in flatItems.
+	flatItems    []MenuItem
+	tmpMenuItems []PopupMenuItem[int]
+	tmpKeys      []ebiten.Key
+}
+
+func (m *MenuBar) SetMenus(menus []MenuBarMenu) {
+	m.menus = adjustSliceSize(m.menus, len(menus))
+	copy(m.menus, menus)
+	m.titles = adjustSliceSize(m.titles, len(menus))
+	for i, menu := range menus {
+		text, index, _ := parseMnemonic(menu.Title)
+		m.titles[i].text.SetValue(text)
+		m.titles[i].mnemonicIndex = index
+	}
+	if m.openIndexPlus1 > len(menus) {
+		m.popupMenu.Close()
+		m.openIndexPlus1 = 0
+	}
+	if m.activeIndexPlus1 > len(menus) {
+		m.activeIndexPlus1 = 0
+	}
+	guigui.RequestRedraw(m)
+}
+
+// IsOpen reports whether a menu is open.
+func (m *MenuBar) IsOpen() bool {
+	return m.popupMenu.IsOpen()
+}
+
+// Close closes the open menu.
+func (m *MenuBar) Close() {
+	m.popupMenu.Close()
+}
+
+func (m *MenuBar) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	m.popupMenu.SetOnMenuItemSelected(func(item PopupMenuItem[int]) {
+		if item.ID < 0 || item.ID >= len(m.flatItems) {
+			return
+		}
+		f := m.flatItems[item.ID].OnSelected
+		m.openIndexPlus1 = 0
+		// Give the focus back before running the command, as the command might depend on the focus.
+		m.deactivate(context)
+		if f != nil {
+			f()
+		}
+	})
+	m.popupMenu.SetOnClosed(func(reason PopupClosedReason) {
+		if reason == PopupClosedReasonReopen {
+			return
+		}
+		index := m.openIndexPlus1 - 1
+		m.openIndexPlus1 = 0
+		// Escape closes only the menu, and keeps the title highlighted.
+		if reason == PopupClosedReasonBack && index >= 0 {
+			m.activeIndexPlus1 = index + 1
+			context.SetFocused(m, true)
+			return
+		}
+		m.deactivate(context)
+	})
+
+	bounds := context.Bounds(m)
+	m.titleBounds = adjustSliceSize(m.titleBounds, len(m.menus))
+	x := bounds.Min.X
+	for i, menu := range m.menus {
+		t := &m.titles[i]
+		t.menuBar = m
+		t.index = i
+		context.SetEnabled(t, !menu.Disabled)
+		w := t.DefaultSize(context).X
+		m.titleBounds[i] = image.Rect(x, bounds.Min.Y, x+w, bounds.Max.Y)
+		appender.AppendChildWidgetWithBounds(t, m.titleBounds[i])
+		x += w
+	}
+
+	var pos image.Point
+	if index := m.openIndexPlus1 - 1; index >= 0 && index < len(m.titleBounds) {
+		b := m.titleBounds[index]
+		pos = image.Pt(b.Min.X, b.Max.Y)
+	}
+	appender.AppendChildWidgetWithPosition(&m.popupMenu, pos)
+
+	return nil
+}
+
+// openMenu opens the menu at the index.
+// If highlightFirst is true, the first item in the menu is highlighted for the keyboard navigation.
+func (m *MenuBar) openMenu(context *guigui.Context, index int, highlightFirst bool) {
+	if index < 0 || index >= len(m.menus) || m.menus[index].Disabled {
+		return
+	}
+	if m.activeIndexPlus1 == 0 && m.openIndexPlus1 == 0 {
+		m.focusedBefore = context.FocusedWidget()
+	}
+	m.flatItems = m.flatItems[:0]
+	m.tmpMenuItems = m.appendMenuItems(m.tmpMenuItems[:0], m.menus[index].Items)
+	m.popupMenu.SetItems(m.tmpMenuItems)
+	m.openIndexPlus1 = index + 1
+	m.activeIndexPlus1 = index + 1
+	m.popupMenu.Open(context)
+	if highlightFirst {
+		m.popupMenu.highlightFirstItem()
+	}
+	guigui.RequestRedraw(m)
+}
+
+// appendMenuItems appends the menu items converted from items and their submenus to menuItems.
+func (m *MenuBar) appendMenuItems(menuItems []PopupMenuItem[int], items []MenuItem) []PopupMenuItem[int] {
+	for _, item := range items {
+		menuItem := PopupMenuItem[int]{
+			Text:     item.Text,
+			Header:   item.Header,
+			Disabled: item.Disabled,
+			Border:   item.Border,
+			Checked:  item.Checked,
+			Radio:    item.Radio,
+			Icon:     item.Icon,
+			ID:       len(m.flatItems),
+		}
+		if item.Shortcut != nil {
+			menuItem.Shortcut = item.Shortcut.String()
+		}
+		m.flatItems = append(m.flatItems, item)
+		if len(item.Submenu) > 0 {
+			menuItem.Submenu = m.appendMenuItems(nil, item.Submenu)
+		}
+		menuItems = append(menuItems, menuItem)
+	}
+	return menuItems
+}
+
+// activate highlights the first title and takes the focus for the keyboard navigation.
+func (m *MenuBar) activate(context *guigui.Context) {
+	index := m.nextEnabledIndex(-1, 1)
+	if index < 0 {
+		return
+	}
+	if m.activeIndexPlus1 == 0 && m.openIndexPlus1 == 0 {
+		m.focusedBefore = context.FocusedWidget()
+	}
+	m.activeIndexPlus1 = index + 1
+	context.SetFocused(m, true)
+	guigui.RequestRedraw(m)
+}
+
+// deactivate ends the keyboard navigation and gives the focus back.
+func (m *MenuBar) deactivate(context *guigui.Context) {
+	m.activeIndexPlus1 = 0
+	// Do not steal the focus if another widget has already taken it, e.g. by clicking.
+	if context.IsFocusedOrHasFocusedChild(m) {
+		if m.focusedBefore != nil {
+			context.SetFocused(m.focusedBefore, true)
+		} else {
+			context.SetFocused(m, false)
+		}
+	}
+	m.focusedBefore = nil
+	guigui.RequestRedraw(m)
+}
+
+// nextEnabledIndex returns the index of the next enabled menu from the index in the direction, or -1 if there is none.
+func (m *MenuBar) nextEnabledIndex(from int, dir int) int {
+	count := len(m.menus)
+	i := from
+	for range count {
+		i = (i + dir + count) % count
+		if !m.menus[i].Disabled {
+			return i
+		}
+	}
+	return -1
+}
+
+// menuIndexByMnemonicKey returns the index of the enabled menu whose mnemonic is the just pressed key, or -1.
+func (m *MenuBar) menuIndexByMnemonicKey() int {
+	m.tmpKeys = inpututil.AppendJustPressedKeys(m.tmpKeys[:0])
+	for _, key := range m.tmpKeys {
+		name := shortcutKeyName(key)
+		if utf8.RuneCountInString(name) != 1 {
+			continue
+		}
+		for i, menu := range m.menus {
+			if menu.Disabled {
+				continue
+			}
+			if _, _, r := parseMnemonic(menu.Title); r != 0 && strings.EqualFold(string(r), name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (m *MenuBar) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if m.activeIndexPlus1 == 0 {
+		return guigui.HandleInputResult{}
+	}
+	open := m.openIndexPlus1 > 0
+	switch {
+	case isKeyRepeating(ebiten.KeyLeft) || isKeyRepeating(ebiten.KeyRight):
+		dir := 1
+		if isKeyRepeating(ebiten.KeyLeft) {
+			dir = -1
+		}
+		index := m.nextEnabledIndex(m.activeIndexPlus1-1, dir)
+		if index < 0 {
+			return guigui.HandleInputResult{}
+		}
+		if open {
+			m.openMenu(context, index, true)
+		} else {
+			m.activeIndexPlus1 = index + 1
+			guigui.RequestRedraw(m)
+		}
+		return guigui.HandleInputByWidget(m)
+	case open:
+		return guigui.HandleInputResult{}
+	case isKeyRepeating(ebiten.KeyDown) || isKeyRepeating(ebiten.KeyUp) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) ||
+		inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		m.openMenu(context, m.activeIndexPlus1-1, true)
+		return guigui.HandleInputByWidget(m)
+	}
+	// While the menu bar is active, a mnemonic works without Alt.
+	if index := m.menuIndexByMnemonicKey(); index >= 0 {
+		m.openMenu(context, index, true)
+		return guigui.HandleInputByWidget(m)
+	}
+	return guigui.HandleInputResult{}
+}
+
+// HandleBack implements guigui.BackHandler.
+// HandleBack ends the keyboard navigation.
+func (m *MenuBar) HandleBack(context *guigui.Context) bool {
+	if m.activeIndexPlus1 == 0 || m.openIndexPlus1 > 0 {
+		return false
+	}
+	m.deactivate(context)
+	return true
+}
+
+func (m *MenuBar) Tick(context *guigui.Context) error {
+	// End the keyboard navigation when the focus leaves the menu bar, e.g. by clicking another widget.
+	if m.activeIndexPlus1 > 0 && m.openIndexPlus1 == 0 && !context.IsFocusedOrHasFocusedChild(m) {
+		m.activeIndexPlus1 = 0
+		m.focusedBefore = nil
+		guigui.RequestRedraw(m)
+	}
+
+	// Switch the menus by hovering the titles.
+	if m.openIndexPlus1 > 0 && m.popupMenu.IsOpen() {
+		for i := range m.titles {
+			if i == m.openIndexPlus1-1 || m.menus[i].Disabled {
+				continue
+			}
+			if m.popupMenu.IsWidgetOrBackgroundHitAtCursor(context, &m.titles[i]) {
+				m.openMenu(context, i, false)
+				break
+			}
+		}
+	}
+
+	m.handleActivationKeys(context)
+	return nil
+}
+
+// HandleShortcut implements guigui.ShortcutHandler.
+// HandleShortcut selects the item whose shortcut is just pressed while no menu is open.
+func (m *MenuBar) HandleShortcut(context *guigui.Context) bool {
+	if m.popupMenu.IsOpen() {
+		return false
+	}
+	item, ok := m.itemByShortcut(m.menus)
+	if !ok {
+		return false
+	}
+	if item.OnSelected != nil {
+		item.OnSelected()
+	}
+	return true
+}
+
+// handleActivationKeys handles F10, Alt and Alt with a mnemonic.
+// Alt is not used on macOS, where Option with a letter inputs a character.
+func (m *MenuBar) handleActivationKeys(context *guigui.Context) {
+	if len(m.menus) == 0 {
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF10) && !ebiten.IsKeyPressed(ebiten.KeyShift) && !isCommandKeyPressed() {
+		m.toggleActivation(context)
+		return
+	}
+
+	if useEmacsKeybind() {
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyAlt) {
+		m.altAlone = true
+		return
+	}
+	if !ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		if inpututil.IsKeyJustReleased(ebiten.KeyAlt) && m.altAlone {
+			m.toggleActivation(context)
+		}
+		m.altAlone = false
+		return
+	}
+
+	if index := m.menuIndexByMnemonicKey(); index >= 0 && !isCommandKeyPressed() {
+		m.altAlone = false
+		m.openMenu(context, index, true)
+		return
+	}
+	m.tmpKeys = inpututil.AppendJustPressedKeys(m.tmpKeys[:0])
+	for _, key := range m.tmpKeys {
+		switch key {
+		case ebiten.KeyAlt, ebiten.KeyAltLeft, ebiten.KeyAltRight:
+		default:
+			m.altAlone = false
+		}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		m.altAlone = false
+	}
+}
+
+func (m *MenuBar) toggleActivation(context *guigui.Context) {
+	if m.popupMenu.IsOpen() {
+		m.popupMenu.Close()
+		return
+	}
+	if m.activeIndexPlus1 > 0 {
+		m.deactivate(context)
+		return
+	}
+	m.activate(context)
+}
+
+// itemByShortcut returns the enabled item whose shortcut is just pressed, searching the items recursively.
+func (m *MenuBar) itemByShortcut(menus []MenuBarMenu) (MenuItem, bool) {
+	for _, menu := range menus {
+		if menu.Disabled {
+			continue
+		}
+		if item, ok := menuItemByShortcut(menu.Items); ok {
+			return item, true
+		}
+	}
+	return MenuItem{}, false
+}
+
+func menuItemByShortcut(items []MenuItem) (MenuItem, bool) {
+	for _, item := range items {
+		if item.Disabled {
+			continue
+		}
+		if item.Shortcut != nil && item.Shortcut.isJustPressed() {
+			return item, true
+		}
+		if item, ok := menuItemByShortcut(item.Submenu); ok {
+			return item, true
+		}
+	}
+	return MenuItem{}, false
+}
+
+// showsMnemonics reports whether the mnemonics are underlined.
+func (m *MenuBar) showsMnemonics() bool {
+	if m.activeIndexPlus1 > 0 {
+		return true
+	}
+	return !useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyAlt)
+}
+
+func (m *MenuBar) DefaultSize(context *guigui.Context) image.Point {
+	var w int
+	for i := range m.titles {
+		w += m.titles[i].DefaultSize(context).X
+	}
+	return image.Pt(w, UnitSize(context))
+}
+
+// parseMnemonic returns the title without the mnemonic marker, the byte index of the mnemonic in the result, and the mnemonic.
+// The mnemonic is the character after the first single '&'. "&&" is '&' itself.
+// If there is no mnemonic, index is -1 and mnemonic is 0.
+func parseMnemonic(title string) (text string, index int, mnemonic rune) {
+	if !strings.Contains(title, "&") {
+		return title, -1, 0
+	}
+	var b strings.Builder
+	index = -1
+	for i := 0; i < len(title); i++ {
+		if title[i] != '&' || i == len(title)-1 {
+			b.WriteByte(title[i])
+			continue
+		}
+		i++
+		if title[i] == '&' {
+			b.WriteByte('&')
+			continue
+		}
+		if index < 0 {
+			index = b.Len()
+			r, _ := utf8.DecodeRuneInString(title[i:])
+			mnemonic = unicode.ToLower(r)
+		}
+		b.WriteByte(title[i])
+	}
+	return b.String(), index, mnemonic
+}
+
+type menuBarTitle struct {
+	guigui.DefaultWidget
+
+	menuBar *MenuBar
+	index   int
+
+	text          Text
+	mnemonicIndex int
+}
+
+func menuBarTitlePadding(context *guigui.Context) int {
+	return UnitSize(context) / 2
+}
+
+func (m *menuBarTitle) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	m.text.SetHorizontalAlign(HorizontalAlignCenter)
+	m.text.SetVerticalAlign(VerticalAlignMiddle)
+	appender.AppendChildWidgetWithBounds(&m.text, context.Bounds(m))
+	return nil
+}
+
+func (m *menuBarTitle) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsWidgetHitAtCursor(m) {
+		return guigui.HandleInputResult{}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		m.menuBar.openMenu(context, m.index, false)
+		return guigui.HandleInputByWidget(m)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (m *menuBarTitle) isHighlighted(context *guigui.Context) bool {
+	if m.menuBar.openIndexPlus1 == m.index+1 || m.menuBar.activeIndexPlus1 == m.index+1 {
+		return true
+	}
+	return context.IsEnabled(m) && context.IsWidgetHitAtCursor(m)
+}
+
+func (m *menuBarTitle) Draw(context *guigui.Context, dst *ebiten.Image) {
+	bounds := context.Bounds(m)
+	if m.isHighlighted(context) {
+		clr := draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.9)
+		if m.menuBar.openIndexPlus1 == m.index+1 {
+			clr = draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.85)
+		}
+		b := bounds.Inset(int(2 * context.Scale()))
+		draw.DrawRoundedRect(context, dst, b, clr, RoundedCornerRadius(context))
+	}
+
+	if m.mnemonicIndex < 0 || !m.menuBar.showsMnemonics() {
+		return
+	}
+	_, size := utf8.DecodeRuneInString(m.text.Value()[m.mnemonicIndex:])
+	pos0, ok0 := m.text.textPosition(context, m.mnemonicIndex, false)
+	pos1, ok1 := m.text.textPosition(context, m.mnemonicIndex+size, false)
+	if !ok0 || !ok1 {
+		return
+	}
+	y := float32(pos0.Bottom) - float32(2*context.Scale())
+	clr := draw.TextColor(context.ColorMode(), context.IsEnabled(m))
+	vector.StrokeLine(dst, float32(pos0.X), y, float32(pos1.X), y, float32(context.Scale()), clr, false)
+}
+
+func (m *menuBarTitle) DefaultSize(context *guigui.Context) image.Point {
+	s := m.text.DefaultSize(context)
+	return image.Pt(s.X+2*menuBarTitlePadding(context), UnitSize(context))
+}
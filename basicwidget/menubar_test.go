@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func TestParseMnemonic(t *testing.T) {
+	testCases := []struct {
+		In       string
+		Text     string
+		Index    int
+		Mnemonic rune
+	}{
+		{In: "File", Text: "File", Index: -1},
+		{In: "&File", Text: "File", Index: 0, Mnemonic: 'f'},
+		{In: "E&xit", Text: "Exit", Index: 1, Mnemonic: 'x'},
+		{In: "Save && &Quit", Text: "Save & Quit", Index: 7, Mnemonic: 'q'},
+		{In: "&A&B", Text: "AB", Index: 0, Mnemonic: 'a'},
+		{In: "Tail&", Text: "Tail&", Index: -1},
+		{In: "&Über", Text: "Über", Index: 0, Mnemonic: 'ü'},
+	}
+	for _, tc := range testCases {
+		text, index, mnemonic := basicwidget.ParseMnemonic(tc.In)
+		if text != tc.Text || index != tc.Index || mnemonic != tc.Mnemonic {
+			t.Errorf("ParseMnemonic(%q): got: (%q, %d, %q), want: (%q, %d, %q)", tc.In, text, index, mnemonic, tc.Text, tc.Index, tc.Mnemonic)
+		}
+	}
+}
+
+func TestShortcutString(t *testing.T) {
+	testCases := []struct {
+		Shortcut basicwidget.Shortcut
+		Darwin   bool
+		Out      string
+	}{
+		{Shortcut: basicwidget.Shortcut{}, Out: "A"},
+		{Shortcut: basicwidget.Shortcut{Key: ebiten.KeyS, Modifiers: basicwidget.ShortcutModifierCommand}, Out: "Ctrl+S"},
+		{Shortcut: basicwidget.Shortcut{Key: ebiten.KeyS, Modifiers: basicwidget.ShortcutModifierCommand}, Darwin: true, Out: "Cmd+S"},
+		{Shortcut: basicwidget.Shortcut{Key: ebiten.KeyZ, Modifiers: basicwidget.ShortcutModifierCommand | basicwidget.ShortcutModifierShift}, Out: "Ctrl+Shift+Z"},
+		{Shortcut: basicwidget.Shortcut{Key: ebiten.KeyDigit1, Modifiers: basicwidget.ShortcutModifierAlt}, Out: "Alt+1"},
+		{Shortcut: basicwidget.Shortcut{Key: ebiten.KeyComma, Modifiers: basicwidget.ShortcutModifierCommand}, Darwin: true, Out: "Cmd+,"},
+		{Shortcut: basicwidget.Shortcut{Key: ebiten.KeyF5}, Out: "F5"},
+		{Shortcut: basicwidget.Shortcut{Key: ebiten.KeyArrowUp, Modifiers: basicwidget.ShortcutModifierAlt}, Darwin: true, Out: "Option+Up"},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.ShortcutString(tc.Shortcut, tc.Darwin); got != tc.Out {
+			t.Errorf("ShortcutString(%v, %v): got: %q, want: %q", tc.Shortcut, tc.Darwin, got, tc.Out)
+		}
+	}
+}
@@ -48,6 +48,11 @@ func (n *NumberInput) SetOnValueChangedBigInt(f func(value *big.Int)) {
 	n.abstractNumberInput.SetOnValueChangedBigInt(f)
 }
 
+// BindValueInt64 binds the number input's value to value in both directions.
+func (n *NumberInput) BindValueInt64(value *guigui.Observable[int64]) {
+	n.abstractNumberInput.bindValueInt64(value)
+}
+
 func (n *NumberInput) SetOnValueChangedInt64(f func(value int64)) {
 	n.abstractNumberInput.SetOnValueChangedInt64(f)
 }
@@ -132,6 +137,7 @@ func (n *NumberInput) Build(context *guigui.Context, appender *guigui.ChildWidge
 			n.textInput.SetValue(text)
 		}
 	})
+	n.abstractNumberInput.pullValueFromBinding(context)
 
 	n.textInput.SetValue(n.abstractNumberInput.ValueString())
 	n.textInput.SetHorizontalAlign(HorizontalAlignEnd)
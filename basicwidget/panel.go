@@ -35,10 +35,11 @@ type Panel struct {
 	border       panelBorder
 	style        PanelStyle
 
-	hasNextOffset     bool
-	nextOffsetX       float64
-	nextOffsetY       float64
-	isNextOffsetDelta bool
+	hasNextOffset        bool
+	nextOffsetX          float64
+	nextOffsetY          float64
+	isNextOffsetDelta    bool
+	isNextOffsetAnimated bool
 }
 
 func (p *Panel) SetContent(widget guigui.Widget) {
@@ -61,11 +62,31 @@ func (p *Panel) SetAutoBorder(auto bool) {
 	p.border.SetAutoBorder(auto)
 }
 
+// SetInertialScrolling sets whether scrolling by a touch continues with decreasing speed after the touch is released.
+func (p *Panel) SetInertialScrolling(inertial bool) {
+	p.scollOverlay.SetInertialScrolling(inertial)
+}
+
+// SetRubberBandOverscroll sets whether scrolling by a touch can go beyond the content with resistance.
+func (p *Panel) SetRubberBandOverscroll(rubberBand bool) {
+	p.scollOverlay.SetRubberBandOverscroll(rubberBand)
+}
+
 func (p *Panel) SetScrollOffset(offsetX, offsetY float64) {
 	p.hasNextOffset = true
 	p.nextOffsetX = offsetX
 	p.nextOffsetY = offsetY
 	p.isNextOffsetDelta = false
+	p.isNextOffsetAnimated = false
+}
+
+// SetScrollOffsetAnimated is like SetScrollOffset, but scrolls to the offset smoothly.
+func (p *Panel) SetScrollOffsetAnimated(offsetX, offsetY float64) {
+	p.hasNextOffset = true
+	p.nextOffsetX = offsetX
+	p.nextOffsetY = offsetY
+	p.isNextOffsetDelta = false
+	p.isNextOffsetAnimated = true
 }
 
 func (p *Panel) SetScrollOffsetByDelta(offsetXDelta, offsetYDelta float64) {
@@ -73,6 +94,15 @@ func (p *Panel) SetScrollOffsetByDelta(offsetXDelta, offsetYDelta float64) {
 	p.nextOffsetX = offsetXDelta
 	p.nextOffsetY = offsetYDelta
 	p.isNextOffsetDelta = true
+	p.isNextOffsetAnimated = false
+}
+
+// HandleScrollIntoView implements guigui.ScrollIntoViewHandler.
+func (p *Panel) HandleScrollIntoView(context *guigui.Context, bounds image.Rectangle, alignment guigui.ScrollAlignment, animated bool) image.Point {
+	if p.content == nil {
+		return image.Point{}
+	}
+	return p.scollOverlay.scrollIntoView(context, context.Bounds(p), bounds, alignment, animated)
 }
 
 func (p *Panel) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
@@ -81,14 +111,18 @@ func (p *Panel) Build(context *guigui.Context, appender *guigui.ChildWidgetAppen
 	}
 
 	if p.hasNextOffset {
-		if p.isNextOffsetDelta {
+		switch {
+		case p.isNextOffsetDelta:
 			p.scollOverlay.SetOffsetByDelta(context, context.Size(p.content), p.nextOffsetX, p.nextOffsetY)
-		} else {
+		case p.isNextOffsetAnimated:
+			p.scollOverlay.SetOffsetAnimated(context, context.Size(p.content), p.nextOffsetX, p.nextOffsetY)
+		default:
 			p.scollOverlay.SetOffset(context, context.Size(p.content), p.nextOffsetX, p.nextOffsetY)
 		}
 		p.hasNextOffset = false
 		p.nextOffsetX = 0
 		p.nextOffsetY = 0
+		p.isNextOffsetAnimated = false
 	}
 
 	offsetX, offsetY := p.scollOverlay.Offset()
@@ -33,6 +33,7 @@ const (
 	PopupClosedReasonFuncCall
 	PopupClosedReasonClickOutside
 	PopupClosedReasonReopen
+	PopupClosedReasonBack
 )
 
 type Popup struct {
@@ -55,6 +56,9 @@ type Popup struct {
 	hasNextContentPosition bool
 	openAfterClose         bool
 
+	// modeless makes the popup neither take the focus nor block inputs to the other widgets, e.g. for suggestions of a text input.
+	modeless bool
+
 	onClosed func(reason PopupClosedReason)
 }
 
@@ -113,6 +117,9 @@ func (p *Popup) Build(context *guigui.Context, appender *guigui.ChildWidgetAppen
 		p.hasNextContentPosition = false
 	}
 
+	// Keep the directional focus navigation in the popup.
+	context.SetFocusNavigationMode(p, guigui.FocusNavigationModeContain)
+
 	p.background.popup = p
 	p.shadow.popup = p
 	p.content.popup = p
@@ -126,7 +133,9 @@ func (p *Popup) Build(context *guigui.Context, appender *guigui.ChildWidgetAppen
 	context.SetOpacity(&p.frame, p.openingRate())
 
 	if p.openingRate() > 0 {
-		appender.AppendChildWidgetWithBounds(&p.background, context.AppBounds())
+		if !p.modeless {
+			appender.AppendChildWidgetWithBounds(&p.background, context.AppBounds())
+		}
 		appender.AppendChildWidgetWithBounds(&p.shadow, context.AppBounds())
 		appender.AppendChildWidgetWithBounds(&p.content, p.ContentBounds(context))
 		appender.AppendChildWidgetWithBounds(&p.frame, context.AppBounds())
@@ -146,13 +155,25 @@ func (p *Popup) Open(context *guigui.Context) {
 	}
 	p.showing = true
 	p.hiding = false
-	context.SetFocused(p, true)
+	if !p.modeless {
+		context.SetFocused(p, true)
+	}
 }
 
 func (p *Popup) Close() {
 	p.close(PopupClosedReasonFuncCall)
 }
 
+// HandleBack implements guigui.BackHandler.
+// HandleBack closes the popup if the popup can be closed by clicking outside.
+func (p *Popup) HandleBack(context *guigui.Context) bool {
+	if !p.closeByClickingOutside || !p.IsOpen() {
+		return false
+	}
+	p.close(PopupClosedReasonBack)
+	return true
+}
+
 func (p *Popup) setClosedReason(reason PopupClosedReason) {
 	if p.closedReason == PopupClosedReasonNone {
 		p.closedReason = reason
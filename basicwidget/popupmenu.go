@@ -7,6 +7,9 @@ import (
 	"image"
 	"image/color"
 
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
 	"github.com/hajimehoshi/guigui"
 )
 
@@ -16,7 +19,34 @@ type PopupMenuItem[T comparable] struct {
 	Header    bool
 	Disabled  bool
 	Border    bool
-	ID        T
+	Checked   bool
+	Icon      *ebiten.Image
+
+	// Radio reports whether the item is one of the exclusive choices. A checked radio item shows a dot instead of a checkmark.
+	Radio bool
+
+	// Shortcut is a hint text of a keyboard shortcut, shown at the end of the item.
+	Shortcut string
+
+	// Submenu is the items of the submenu, which is opened by hovering the item or by the right key.
+	Submenu []PopupMenuItem[T]
+
+	ID T
+}
+
+func (p *PopupMenuItem[T]) listItem() ListItem[T] {
+	return ListItem[T]{
+		Text:      p.Text,
+		TextColor: p.TextColor,
+		Header:    p.Header,
+		Disabled:  p.Disabled,
+		Border:    p.Border,
+		Checked:   p.Checked,
+		Icon:      p.Icon,
+		Radio:     p.Radio,
+		Shortcut:  p.Shortcut,
+		ID:        p.ID,
+	}
 }
 
 type PopupMenu[T comparable] struct {
@@ -24,37 +54,190 @@ type PopupMenu[T comparable] struct {
 
 	list  List[T]
 	popup Popup
+	items []PopupMenuItem[T]
 
-	onItemSelected func(index int)
+	// submenu is the open submenu, and parent is the menu opening this menu as a submenu.
+	submenu           *PopupMenu[T]
+	submenuIndexPlus1 int
+	parent            *PopupMenu[T]
+
+	onItemSelected     func(index int)
+	onMenuItemSelected func(item PopupMenuItem[T])
 }
 
 func (p *PopupMenu[T]) SetOnItemSelected(f func(index int)) {
 	p.onItemSelected = f
 }
 
+// SetOnMenuItemSelected sets the function called when an item is selected in the menu or in its submenus.
+func (p *PopupMenu[T]) SetOnMenuItemSelected(f func(item PopupMenuItem[T])) {
+	p.onMenuItemSelected = f
+}
+
+func (p *PopupMenu[T]) SetOnClosed(f func(reason PopupClosedReason)) {
+	p.popup.SetOnClosed(f)
+}
+
 func (p *PopupMenu[T]) SetCheckmarkIndex(index int) {
 	p.list.SetCheckmarkIndex(index)
 }
 
 func (p *PopupMenu[T]) IsWidgetOrBackgroundHitAtCursor(context *guigui.Context, widget guigui.Widget) bool {
-	return p.popup.IsWidgetOrBackgroundHitAtCursor(context, widget)
+	return p.root().popup.IsWidgetOrBackgroundHitAtCursor(context, widget)
+}
+
+func (p *PopupMenu[T]) root() *PopupMenu[T] {
+	m := p
+	for m.parent != nil {
+		m = m.parent
+	}
+	return m
+}
+
+// deepestMenu returns the innermost open submenu, or the menu itself if no submenu is open.
+func (p *PopupMenu[T]) deepestMenu() *PopupMenu[T] {
+	m := p
+	for m.submenuIndexPlus1 > 0 && m.submenu != nil {
+		m = m.submenu
+	}
+	return m
 }
 
 func (p *PopupMenu[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
 	p.list.SetStyle(ListStyleMenu)
 	p.list.list.SetOnItemSelected(func(index int) {
-		p.popup.Close()
-		if p.onItemSelected != nil {
-			p.onItemSelected(index)
-		}
+		p.selectItem(context, index)
 	})
 
+	// Close the submenus when this menu is being closed, e.g. by clicking outside.
+	if !p.popup.IsOpen() || p.popup.hiding {
+		p.closeSubmenu()
+	}
+
 	p.popup.SetContent(&p.list)
 	p.popup.SetCloseByClickingOutside(true)
 	bounds := p.contentBounds(context)
 	context.SetSize(&p.list, bounds.Size())
 	appender.AppendChildWidgetWithBounds(&p.popup, bounds)
 
+	if p.submenuIndexPlus1 > 0 && p.submenu != nil {
+		appender.AppendChildWidgetWithPosition(p.submenu, p.submenuPosition(context, p.submenuIndexPlus1-1))
+	}
+
+	return nil
+}
+
+func (p *PopupMenu[T]) selectItem(context *guigui.Context, index int) {
+	if index < 0 || index >= len(p.items) {
+		return
+	}
+	if len(p.items[index].Submenu) > 0 {
+		// Selecting an item with a submenu opens the submenu instead.
+		p.list.SelectItemByIndex(-1)
+		p.openSubmenu(context, index, true)
+		return
+	}
+	root := p.root()
+	root.Close()
+	if p.onItemSelected != nil {
+		p.onItemSelected(index)
+	}
+	if root.onMenuItemSelected != nil {
+		root.onMenuItemSelected(p.items[index])
+	}
+}
+
+// openSubmenu opens the submenu of the item at the index.
+// If highlightFirst is true, the first item in the submenu is highlighted for the keyboard navigation.
+func (p *PopupMenu[T]) openSubmenu(context *guigui.Context, index int, highlightFirst bool) {
+	if !p.showSubmenu(context, index) {
+		return
+	}
+	if highlightFirst {
+		p.submenu.highlightFirstItem()
+	}
+	// Keep the keyboard inputs in the root menu, which dispatches them to the innermost submenu.
+	context.SetFocused(&p.root().popup, true)
+	guigui.RequestRedraw(p)
+}
+
+// showSubmenu shows the submenu of the item at the index without moving the focus.
+// showSubmenu reports whether the item has a submenu.
+func (p *PopupMenu[T]) showSubmenu(context *guigui.Context, index int) bool {
+	if index < 0 || index >= len(p.items) || len(p.items[index].Submenu) == 0 {
+		return false
+	}
+	if p.submenu == nil {
+		p.submenu = &PopupMenu[T]{}
+	}
+	if p.submenuIndexPlus1 != index+1 {
+		p.submenu.closeSubmenu()
+		p.submenu.SetItems(p.items[index].Submenu)
+		p.submenuIndexPlus1 = index + 1
+	}
+	p.submenu.parent = p
+	// A modeless popup doesn't take the focus when opened.
+	p.submenu.popup.modeless = true
+	if !p.submenu.popup.IsOpen() || p.submenu.popup.hiding {
+		p.submenu.Open(context)
+	}
+	return true
+}
+
+// highlightFirstItem highlights the first selectable item for the keyboard navigation.
+func (p *PopupMenu[T]) highlightFirstItem() {
+	b := &p.list.list
+	b.setKeyboardHighlightIndex(b.nextSelectableIndex(-1, 1, false))
+}
+
+func (p *PopupMenu[T]) closeSubmenu() {
+	if p.submenu == nil || p.submenuIndexPlus1 == 0 {
+		return
+	}
+	p.submenu.closeSubmenu()
+	p.submenu.popup.Close()
+	p.submenuIndexPlus1 = 0
+}
+
+// submenuPosition returns the position of the submenu next to the item at the index.
+// The submenu is at the left side if there is not enough space at the right side,
+// and is moved up if there is not enough space below.
+func (p *PopupMenu[T]) submenuPosition(context *guigui.Context, index int) image.Point {
+	bounds := p.popup.ContentBounds(context)
+	itemBounds := p.list.list.itemBounds(context, index, false)
+	size := p.submenu.contentBounds(context).Size()
+	return popupSubmenuPosition(bounds, itemBounds.Min.Y-RoundedCornerRadius(context), size, context.AppBounds())
+}
+
+// popupSubmenuPosition returns the position of a submenu with the size next to the menu bounds at y.
+func popupSubmenuPosition(menuBounds image.Rectangle, y int, size image.Point, appBounds image.Rectangle) image.Point {
+	pt := image.Pt(menuBounds.Max.X, y)
+	if pt.X+size.X > appBounds.Max.X {
+		pt.X = menuBounds.Min.X - size.X
+	}
+	if pt.Y+size.Y > appBounds.Max.Y {
+		pt.Y = appBounds.Max.Y - size.Y
+	}
+	if pt.Y < appBounds.Min.Y {
+		pt.Y = appBounds.Min.Y
+	}
+	return pt
+}
+
+// Tick opens the submenu of the hovered item, and closes the submenu when another item is hovered.
+func (p *PopupMenu[T]) Tick(context *guigui.Context) error {
+	if !p.popup.IsOpen() || p.popup.hiding {
+		return nil
+	}
+	index := p.list.list.hoveredItemIndex(context)
+	if index < 0 || index >= len(p.items) || index == p.submenuIndexPlus1-1 {
+		return nil
+	}
+	if item := p.items[index]; len(item.Submenu) > 0 && !item.Disabled {
+		p.openSubmenu(context, index, false)
+	} else {
+		p.closeSubmenu()
+	}
 	return nil
 }
 
@@ -70,31 +253,68 @@ func (p *PopupMenu[T]) contentBounds(context *guigui.Context) image.Rectangle {
 		Min: pos,
 		Max: pos.Add(s),
 	}
-	as := context.AppSize()
-	if r.Max.X > as.X {
-		r.Min.X = as.X - s.X
-		r.Max.X = as.X
+	// Keep the menu inside the app.
+	ab := context.AppBounds()
+	if r.Max.X > ab.Max.X {
+		r.Min.X = ab.Max.X - s.X
+		r.Max.X = ab.Max.X
 	}
-	if r.Min.X < 0 {
-		r.Min.X = 0
-		r.Max.X = s.X
+	if r.Min.X < ab.Min.X {
+		r.Min.X = ab.Min.X
+		r.Max.X = ab.Min.X + s.X
 	}
-	if r.Max.Y > as.Y {
-		r.Min.Y = as.Y - s.Y
-		r.Max.Y = as.Y
+	if r.Max.Y > ab.Max.Y {
+		r.Min.Y = ab.Max.Y - s.Y
+		r.Max.Y = ab.Max.Y
 	}
-	if r.Min.Y < 0 {
-		r.Min.Y = 0
-		r.Max.Y = s.Y
+	if r.Min.Y < ab.Min.Y {
+		r.Min.Y = ab.Min.Y
+		r.Max.Y = ab.Min.Y + s.Y
 	}
 	return r
 }
 
 func (p *PopupMenu[T]) Open(context *guigui.Context) {
 	p.popup.Open(context)
+	// Start the keyboard navigation from the selected item.
+	p.list.list.setKeyboardHighlightIndex(p.list.SelectedItemIndex())
+}
+
+// HandleButtonInput handles the keyboard navigation while the popup itself is focused.
+// The key inputs are dispatched to the innermost submenu.
+// The right key opens a submenu, and the left key and Escape close a submenu.
+// Escape closes the root menu by the directional focus navigation.
+func (p *PopupMenu[T]) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !p.popup.IsOpen() || p.parent != nil {
+		return guigui.HandleInputResult{}
+	}
+	m := p.deepestMenu()
+	if m == p && context.IsFocusedOrHasFocusedChild(&p.list) {
+		return guigui.HandleInputResult{}
+	}
+
+	b := &m.list.list
+	switch {
+	case isKeyRepeating(ebiten.KeyRight):
+		index := b.currentItemIndex(context)
+		if index < 0 || index >= len(m.items) || len(m.items[index].Submenu) == 0 {
+			return guigui.HandleInputResult{}
+		}
+		m.openSubmenu(context, index, true)
+		return guigui.HandleInputByWidget(p)
+	case isKeyRepeating(ebiten.KeyLeft) || inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		if m.parent == nil {
+			return guigui.HandleInputResult{}
+		}
+		m.parent.closeSubmenu()
+		return guigui.HandleInputByWidget(p)
+	}
+	return b.handleKeyboardInput(context)
 }
 
+// Close closes the menu and its submenus.
 func (p *PopupMenu[T]) Close() {
+	p.closeSubmenu()
 	p.popup.Close()
 }
 
@@ -103,52 +323,44 @@ func (p *PopupMenu[T]) IsOpen() bool {
 }
 
 func (p *PopupMenu[T]) SetItems(items []PopupMenuItem[T]) {
+	p.items = adjustSliceSize(p.items, len(items))
+	copy(p.items, items)
+
 	var listItems []ListItem[T]
 	for _, item := range items {
-		listItems = append(listItems, ListItem[T]{
-			Text:      item.Text,
-			TextColor: item.TextColor,
-			Header:    item.Header,
-			Disabled:  item.Disabled,
-			Border:    item.Border,
-			ID:        item.ID,
-		})
+		listItems = append(listItems, item.listItem())
 	}
 	p.list.SetItems(listItems)
+	for i, item := range items {
+		p.list.listItemWidget(i).hasSubmenu = len(item.Submenu) > 0
+	}
+	if p.submenuIndexPlus1 > 0 {
+		p.closeSubmenu()
+	}
 }
 
 func (p *PopupMenu[T]) SetItemsByStrings(items []string) {
+	p.items = adjustSliceSize(p.items, len(items))
+	for i, str := range items {
+		p.items[i] = PopupMenuItem[T]{
+			Text: str,
+		}
+	}
 	p.list.SetItemsByStrings(items)
+	for i := range items {
+		p.list.listItemWidget(i).hasSubmenu = false
+	}
 }
 
 func (p *PopupMenu[T]) SelectedItem() (PopupMenuItem[T], bool) {
-	listItem, ok := p.list.SelectedItem()
-	if !ok {
-		return PopupMenuItem[T]{}, false
-	}
-	return PopupMenuItem[T]{
-		Text:      listItem.Text,
-		TextColor: listItem.TextColor,
-		Header:    listItem.Header,
-		Disabled:  listItem.Disabled,
-		Border:    listItem.Border,
-		ID:        listItem.ID,
-	}, true
+	return p.ItemByIndex(p.list.SelectedItemIndex())
 }
 
 func (p *PopupMenu[T]) ItemByIndex(index int) (PopupMenuItem[T], bool) {
-	listItem, ok := p.list.ItemByIndex(index)
-	if !ok {
+	if index < 0 || index >= len(p.items) {
 		return PopupMenuItem[T]{}, false
 	}
-	return PopupMenuItem[T]{
-		Text:      listItem.Text,
-		TextColor: listItem.TextColor,
-		Header:    listItem.Header,
-		Disabled:  listItem.Disabled,
-		Border:    listItem.Border,
-		ID:        listItem.ID,
-	}, true
+	return p.items[index], true
 }
 
 func (p *PopupMenu[T]) SelectedItemIndex() int {
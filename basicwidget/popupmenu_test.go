@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPopupSubmenuPosition(t *testing.T) {
+	appBounds := image.Rect(0, 0, 400, 300)
+	testCases := []struct {
+		menuBounds image.Rectangle
+		y          int
+		size       image.Point
+		want       image.Point
+	}{
+		// The submenu is at the right side.
+		{menuBounds: image.Rect(10, 20, 110, 200), y: 50, size: image.Pt(100, 100), want: image.Pt(110, 50)},
+		{menuBounds: image.Rect(200, 20, 300, 200), y: 50, size: image.Pt(100, 100), want: image.Pt(300, 50)},
+		// The submenu is at the left side if there is not enough space at the right side.
+		{menuBounds: image.Rect(250, 20, 350, 200), y: 50, size: image.Pt(100, 100), want: image.Pt(150, 50)},
+		{menuBounds: image.Rect(200, 20, 300, 200), y: 80, size: image.Pt(101, 100), want: image.Pt(99, 80)},
+		// The submenu is moved up if there is not enough space below.
+		{menuBounds: image.Rect(10, 20, 110, 280), y: 250, size: image.Pt(100, 100), want: image.Pt(110, 200)},
+		// The submenu is at the top if it is taller than the app.
+		{menuBounds: image.Rect(10, 20, 110, 280), y: 250, size: image.Pt(100, 400), want: image.Pt(110, 0)},
+	}
+	for _, tc := range testCases {
+		if got := popupSubmenuPosition(tc.menuBounds, tc.y, tc.size, appBounds); got != tc.want {
+			t.Errorf("popupSubmenuPosition(%v, %d, %v): got: %v, want: %v", tc.menuBounds, tc.y, tc.size, got, tc.want)
+		}
+	}
+}
+
+// testPopupMenuItems are the items for the submenu tests:
+//
+//	a > a1 > a1x
+//	    a2
+//	b
+var testPopupMenuItems = []PopupMenuItem[string]{
+	{
+		Text: "a",
+		ID:   "a",
+		Submenu: []PopupMenuItem[string]{
+			{
+				Text: "a1",
+				ID:   "a1",
+				Submenu: []PopupMenuItem[string]{
+					{Text: "a1x", ID: "a1x"},
+				},
+			},
+			{Text: "a2", ID: "a2"},
+		},
+	},
+	{Text: "b", ID: "b"},
+}
+
+// openPopupMenuChain opens menu and the submenus of the items at indices without the animation,
+// and returns the open menus from the outermost.
+func openPopupMenuChain(menu *PopupMenu[string], indices ...int) []*PopupMenu[string] {
+	// Opening the root menu by Open requires a context to move the focus.
+	menu.popup.showing = true
+	for menu.popup.showing {
+		_ = menu.popup.Tick(nil)
+	}
+	menus := []*PopupMenu[string]{menu}
+	for _, index := range indices {
+		menu.showSubmenu(nil, index)
+		menu = menu.submenu
+		for menu.popup.showing {
+			_ = menu.popup.Tick(nil)
+		}
+		menus = append(menus, menu)
+	}
+	return menus
+}
+
+func TestPopupMenuCloseChain(t *testing.T) {
+	testCases := []struct {
+		name   string
+		chain  []int
+		depth  int
+		index  int
+		wantID string
+	}{
+		{name: "innermost", chain: []int{0, 0}, depth: 2, index: 0, wantID: "a1x"},
+		{name: "middle", chain: []int{0, 0}, depth: 1, index: 1, wantID: "a2"},
+		{name: "root", chain: []int{0}, depth: 0, index: 1, wantID: "b"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var root PopupMenu[string]
+			root.SetItems(testPopupMenuItems)
+			var selected []string
+			root.SetOnMenuItemSelected(func(item PopupMenuItem[string]) {
+				selected = append(selected, item.ID)
+			})
+			menus := openPopupMenuChain(&root, tc.chain...)
+
+			menus[tc.depth].selectItem(nil, tc.index)
+			if len(selected) != 1 || selected[0] != tc.wantID {
+				t.Errorf("selected: got: %v, want: [%s]", selected, tc.wantID)
+			}
+			// Selecting an item in any menu closes the whole chain.
+			for i, m := range menus {
+				if !m.popup.hiding {
+					t.Errorf("menu %d is not being closed", i)
+				}
+				if m.submenuIndexPlus1 != 0 {
+					t.Errorf("menu %d still has its submenu", i)
+				}
+			}
+		})
+	}
+}
+
+func TestPopupMenuClose(t *testing.T) {
+	var root PopupMenu[string]
+	root.SetItems(testPopupMenuItems)
+	menus := openPopupMenuChain(&root, 0, 0)
+
+	root.Close()
+	for i, m := range menus {
+		if !m.popup.hiding {
+			t.Errorf("menu %d is not being closed", i)
+		}
+		if m.submenuIndexPlus1 != 0 {
+			t.Errorf("menu %d still has its submenu", i)
+		}
+	}
+}
@@ -5,13 +5,16 @@ package basicwidget
 
 import (
 	"image"
+	"math"
 	"runtime"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/hajimehoshi/guigui"
 	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+	"github.com/hajimehoshi/guigui/gesture"
 )
 
 func scrollBarFadingInTime() int {
@@ -30,6 +33,20 @@ func scrollBarMaxCount() int {
 	return scrollBarFadingInTime() + scrollBarShowingTime() + scrollBarFadingOutTime()
 }
 
+func scrollAnimationMaxCount() int {
+	return ebiten.TPS() / 4
+}
+
+// scrollFriction returns the rate of the velocity kept in one tick in inertial scrolling.
+func scrollFriction() float64 {
+	return math.Pow(0.95, 60/float64(ebiten.TPS()))
+}
+
+// scrollSpringBackRate returns the rate of the overscroll resolved in one tick.
+func scrollSpringBackRate() float64 {
+	return 1 - math.Pow(0.8, 60/float64(ebiten.TPS()))
+}
+
 func scrollBarOpacity(count int) float64 {
 	switch {
 	case scrollBarMaxCount()-scrollBarFadingInTime() <= count:
@@ -42,6 +59,14 @@ func scrollBarOpacity(count int) float64 {
 	}
 }
 
+type scrollBarType int
+
+const (
+	scrollBarTypeNone scrollBarType = iota
+	scrollBarTypeHorizontal
+	scrollBarTypeVertical
+)
+
 type ScrollOverlay struct {
 	guigui.DefaultWidget
 
@@ -56,13 +81,34 @@ type ScrollOverlay struct {
 	lastWheelY              float64
 	lastOffsetX             float64
 	lastOffsetY             float64
-	draggingX               bool
-	draggingY               bool
+	draggingBar             scrollBarType
 	draggingStartPosition   image.Point
 	draggingStartOffsetX    float64
 	draggingStartOffsetY    float64
 	onceBuilt               bool
 
+	touch          gesture.Recognizer
+	touchID        ebiten.TouchID
+	tmpTouchIDs    []ebiten.TouchID
+	tmpWidgets     []guigui.Widget
+	tmpChain       []*ScrollOverlay
+	inertial       bool
+	rubberBand     bool
+	holdOverscroll bool
+
+	// chain is the scroll overlays scrolled by the current touch pan or inertial scrolling, from the innermost.
+	chain []*ScrollOverlay
+
+	// velocityX and velocityY are the velocity of inertial scrolling in pixels per tick.
+	velocityX float64
+	velocityY float64
+
+	animationCount int
+	animationFromX float64
+	animationFromY float64
+	animationToX   float64
+	animationToY   float64
+
 	barCount int
 
 	onScroll func(offsetX, offsetY float64)
@@ -72,6 +118,17 @@ func (s *ScrollOverlay) SetOnScroll(f func(offsetX, offsetY float64)) {
 	s.onScroll = f
 }
 
+// SetInertialScrolling sets whether scrolling by a touch continues with decreasing speed after the touch is released.
+func (s *ScrollOverlay) SetInertialScrolling(inertial bool) {
+	s.inertial = inertial
+}
+
+// SetRubberBandOverscroll sets whether scrolling by a touch can go beyond the content with resistance.
+// The offset springs back to the content after the touch is released.
+func (s *ScrollOverlay) SetRubberBandOverscroll(rubberBand bool) {
+	s.rubberBand = rubberBand
+}
+
 func (s *ScrollOverlay) Reset() {
 	s.offsetX = 0
 	s.offsetY = 0
@@ -94,6 +151,7 @@ func (s *ScrollOverlay) SetOffsetByDelta(context *guigui.Context, contentSize im
 
 func (s *ScrollOverlay) SetOffset(context *guigui.Context, contentSize image.Point, x, y float64) {
 	s.SetContentSize(context, contentSize)
+	s.stopMotion()
 
 	x, y = s.doAdjustOffset(context, x, y)
 	if s.offsetX == x && s.offsetY == y {
@@ -106,13 +164,58 @@ func (s *ScrollOverlay) SetOffset(context *guigui.Context, contentSize image.Poi
 	}
 }
 
-func (s *ScrollOverlay) setDragging(draggingX, draggingY bool) {
-	if s.draggingX == draggingX && s.draggingY == draggingY {
+// SetOffsetAnimated is like SetOffset, but scrolls to the offset smoothly.
+func (s *ScrollOverlay) SetOffsetAnimated(context *guigui.Context, contentSize image.Point, x, y float64) {
+	s.SetContentSize(context, contentSize)
+	s.stopMotion()
+
+	x, y = s.doAdjustOffset(context, x, y)
+	if s.offsetX == x && s.offsetY == y {
 		return
 	}
+	s.animationCount = scrollAnimationMaxCount()
+	s.animationFromX = s.offsetX
+	s.animationFromY = s.offsetY
+	s.animationToX = x
+	s.animationToY = y
+}
+
+// scrollIntoView scrolls the content so that bounds is visible in viewport, and returns the amount by which the content is moved.
+func (s *ScrollOverlay) scrollIntoView(context *guigui.Context, viewport, bounds image.Rectangle, alignment guigui.ScrollAlignment, animated bool) image.Point {
+	dx := guigui.ScrollDeltaToReveal(viewport.Min.X, viewport.Max.X, bounds.Min.X, bounds.Max.X, alignment)
+	dy := guigui.ScrollDeltaToReveal(viewport.Min.Y, viewport.Max.Y, bounds.Min.Y, bounds.Max.Y, alignment)
+	if dx == 0 && dy == 0 {
+		return image.Point{}
+	}
+	x, y := s.doAdjustOffset(context, s.offsetX+float64(dx), s.offsetY+float64(dy))
+	delta := image.Pt(int(x)-int(s.offsetX), int(y)-int(s.offsetY))
+	if animated {
+		s.SetOffsetAnimated(context, s.contentSize, x, y)
+	} else {
+		s.SetOffset(context, s.contentSize, x, y)
+	}
+	return delta
+}
+
+// stopMotion stops the animation and the inertial scrolling.
+func (s *ScrollOverlay) stopMotion() {
+	s.animationCount = 0
+	s.velocityX = 0
+	s.velocityY = 0
+}
+
+// isDraggingBar reports whether a bar is being dragged.
+// A bar is dragged only while s holds the pointer capture.
+func (s *ScrollOverlay) isDraggingBar(context *guigui.Context) bool {
+	return s.draggingBar != scrollBarTypeNone && context.HasPointerCapture(s)
+}
 
-	s.draggingX = draggingX
-	s.draggingY = draggingY
+func (s *ScrollOverlay) endDraggingBar(context *guigui.Context) {
+	if s.draggingBar == scrollBarTypeNone {
+		return
+	}
+	s.draggingBar = scrollBarTypeNone
+	context.ReleasePointer(s)
 }
 
 func adjustedWheel() (float64, float64) {
@@ -126,6 +229,42 @@ func adjustedWheel() (float64, float64) {
 }
 
 func (s *ScrollOverlay) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	s.touch.SetOnPanStart(func(position image.Point) {
+		// Calculate the chain before capturing the pointer, as the capture affects the hit testing.
+		s.chain = s.appendScrollChainAt(context, s.chain[:0], image.Pt(ebiten.TouchPosition(s.touchID)), func(widget guigui.Widget) bool {
+			return context.IsWidgetHitAtTouch(widget, s.touchID)
+		})
+		if len(s.chain) == 0 {
+			s.chain = append(s.chain, s)
+		}
+		for _, o := range s.chain {
+			o.stopMotion()
+			o.holdOverscroll = true
+		}
+		// Keep receiving the touches while panning, even when the touch is outside the overlay.
+		context.CapturePointer(s)
+		s.showBars(context)
+	})
+	s.touch.SetOnPan(func(dx, dy float64) {
+		scrollChainBy(context, s.chain, dx, dy, true)
+	})
+	s.touch.SetOnPanEnd(func(velocityX, velocityY float64) {
+		for _, o := range s.chain {
+			o.holdOverscroll = false
+		}
+		if s.inertial {
+			tps := float64(ebiten.TPS())
+			s.velocityX = velocityX / tps
+			s.velocityY = velocityY / tps
+		}
+	})
+	if s.isInnermostAtJustPressedTouches(context) {
+		s.touch.HandleTouchInput(context, s)
+	}
+	if s.touch.IsActive() {
+		return guigui.HandleInputByWidget(s)
+	}
+
 	hovered := context.IsWidgetHitAtCursor(s)
 	if hovered {
 		x, y := ebiten.CursorPosition()
@@ -139,34 +278,42 @@ func (s *ScrollOverlay) HandlePointingInput(context *guigui.Context) guigui.Hand
 		s.lastWheelY = 0
 	}
 
-	if !s.draggingX && !s.draggingY && hovered && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	// The capture might have been released by the app, e.g. when s is disabled.
+	if !s.isDraggingBar(context) {
+		s.draggingBar = scrollBarTypeNone
+	}
+
+	if s.draggingBar == scrollBarTypeNone && hovered && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		x, y := ebiten.CursorPosition()
 		hb, vb := s.barBounds(context)
 		if image.Pt(x, y).In(hb) {
-			s.setDragging(true, s.draggingY)
+			s.draggingBar = scrollBarTypeHorizontal
 			s.draggingStartPosition.X = x
 			s.draggingStartOffsetX = s.offsetX
 		} else if image.Pt(x, y).In(vb) {
-			s.setDragging(s.draggingX, true)
+			s.draggingBar = scrollBarTypeVertical
 			s.draggingStartPosition.Y = y
 			s.draggingStartOffsetY = s.offsetY
 		}
-		if s.draggingX || s.draggingY {
+		if s.draggingBar != scrollBarTypeNone {
+			s.stopMotion()
+			// Keep receiving the pointing input while dragging a bar, even when the cursor is outside the overlay.
+			context.CapturePointer(s)
 			return guigui.HandleInputByWidget(s)
 		}
 	}
 
 	if dx, dy := adjustedWheel(); dx != 0 || dy != 0 {
-		s.setDragging(false, false)
+		s.endDraggingBar(context)
 	}
 
-	if (s.draggingX || s.draggingY) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if s.draggingBar != scrollBarTypeNone && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		x, y := ebiten.CursorPosition()
 		var dx, dy float64
-		if s.draggingX {
+		if s.draggingBar == scrollBarTypeHorizontal {
 			dx = float64(x - s.draggingStartPosition.X)
 		}
-		if s.draggingY {
+		if s.draggingBar == scrollBarTypeVertical {
 			dy = float64(y - s.draggingStartPosition.Y)
 		}
 		if dx != 0 || dy != 0 {
@@ -175,11 +322,11 @@ func (s *ScrollOverlay) HandlePointingInput(context *guigui.Context) guigui.Hand
 
 			cs := context.Size(s)
 			barWidth, barHeight := s.barSize(context)
-			if s.draggingX && barWidth > 0 && s.contentSize.X-cs.X > 0 {
+			if s.draggingBar == scrollBarTypeHorizontal && barWidth > 0 && s.contentSize.X-cs.X > 0 {
 				offsetPerPixel := float64(s.contentSize.X-cs.X) / (float64(cs.X) - barWidth)
 				s.offsetX = s.draggingStartOffsetX + float64(-dx)*offsetPerPixel
 			}
-			if s.draggingY && barHeight > 0 && s.contentSize.Y-cs.Y > 0 {
+			if s.draggingBar == scrollBarTypeVertical && barHeight > 0 && s.contentSize.Y-cs.Y > 0 {
 				offsetPerPixel := float64(s.contentSize.Y-cs.Y) / (float64(cs.Y) - barHeight)
 				s.offsetY = s.draggingStartOffsetY + float64(-dy)*offsetPerPixel
 			}
@@ -194,26 +341,28 @@ func (s *ScrollOverlay) HandlePointingInput(context *guigui.Context) guigui.Hand
 		return guigui.HandleInputByWidget(s)
 	}
 
-	if (s.draggingX || s.draggingY) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		s.setDragging(false, false)
+	if s.draggingBar != scrollBarTypeNone && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		s.endDraggingBar(context)
 	}
 
 	if dx, dy := adjustedWheel(); dx != 0 || dy != 0 {
 		if !hovered {
 			return guigui.HandleInputResult{}
 		}
-		s.setDragging(false, false)
 
-		prevOffsetX := s.offsetX
-		prevOffsetY := s.offsetY
-		s.offsetX += dx * 4 * context.Scale()
-		s.offsetY += dy * 4 * context.Scale()
-		s.adjustOffset(context)
-		if prevOffsetX != s.offsetX || prevOffsetY != s.offsetY {
-			if s.onScroll != nil {
-				s.onScroll(s.offsetX, s.offsetY)
-			}
-			guigui.RequestRedraw(s)
+		// The innermost scroll overlay scrolls the chain, and the outer ones scroll the remaining delta.
+		chain := s.appendScrollChainAt(context, s.tmpChain[:0], image.Pt(ebiten.CursorPosition()), context.IsWidgetHitAtCursor)
+		s.tmpChain = chain
+		if len(chain) > 0 && chain[0] != s {
+			return guigui.HandleInputResult{}
+		}
+		if len(chain) == 0 {
+			chain = append(chain, s)
+		}
+		for _, o := range chain {
+			o.stopMotion()
+		}
+		if scrollChainBy(context, chain, dx*4*context.Scale(), dy*4*context.Scale(), false) {
 			return guigui.HandleInputByWidget(s)
 		}
 		return guigui.HandleInputResult{}
@@ -222,6 +371,234 @@ func (s *ScrollOverlay) HandlePointingInput(context *guigui.Context) guigui.Hand
 	return guigui.HandleInputResult{}
 }
 
+// appendScrollChainAt appends the scroll overlays at point for which hit reports true, from the innermost to the outermost.
+func (s *ScrollOverlay) appendScrollChainAt(context *guigui.Context, chain []*ScrollOverlay, point image.Point, hit func(widget guigui.Widget) bool) []*ScrollOverlay {
+	s.tmpWidgets = context.AppendWidgetsAt(s.tmpWidgets[:0], point)
+	for _, w := range s.tmpWidgets {
+		o, ok := w.(*ScrollOverlay)
+		if !ok || !hit(o) {
+			continue
+		}
+		chain = append(chain, o)
+	}
+	slices.SortStableFunc(chain, func(a, b *ScrollOverlay) int {
+		return widgetDepth(context, b) - widgetDepth(context, a)
+	})
+	return chain
+}
+
+func widgetDepth(context *guigui.Context, widget guigui.Widget) int {
+	var depth int
+	for w := context.Parent(widget); w != nil; w = context.Parent(w) {
+		depth++
+	}
+	return depth
+}
+
+// isInnermostAtJustPressedTouches reports whether s is the innermost scroll overlay at the touches pressed in this tick on s.
+// A touch on nested scroll overlays is recognized only by the innermost one.
+func (s *ScrollOverlay) isInnermostAtJustPressedTouches(context *guigui.Context) bool {
+	s.tmpTouchIDs = inpututil.AppendJustPressedTouchIDs(s.tmpTouchIDs[:0])
+	for _, id := range s.tmpTouchIDs {
+		if !context.IsWidgetHitAtTouch(s, id) {
+			continue
+		}
+		s.tmpChain = s.appendScrollChainAt(context, s.tmpChain[:0], image.Pt(ebiten.TouchPosition(id)), func(widget guigui.Widget) bool {
+			return context.IsWidgetHitAtTouch(widget, id)
+		})
+		if len(s.tmpChain) > 0 && s.tmpChain[0] != s {
+			return false
+		}
+		s.touchID = id
+	}
+	return true
+}
+
+// scrollChainBy scrolls the scroll overlays in the chain by the delta in order.
+// Each scroll overlay scrolls the delta remaining from the inner ones.
+// If rubberBand is true, the delta remaining from all the overlays is applied as an overscroll.
+// scrollChainBy reports whether any offset is changed.
+func scrollChainBy(context *guigui.Context, chain []*ScrollOverlay, dx, dy float64, rubberBand bool) bool {
+	var changed bool
+	for _, o := range chain {
+		if dx == 0 && dy == 0 {
+			break
+		}
+		r := o.scrollRange(context)
+		var x, y float64
+		x, dx = scrollAxis(o.offsetX, dx, float64(r.Min.X), float64(r.Max.X))
+		y, dy = scrollAxis(o.offsetY, dy, float64(r.Min.Y), float64(r.Max.Y))
+		if o.setOffset(x, y) {
+			changed = true
+		}
+	}
+	if !rubberBand {
+		return changed
+	}
+
+	if dx != 0 {
+		for _, o := range chain {
+			if !o.rubberBand {
+				continue
+			}
+			size := context.Size(o).X
+			if o.contentSize.X <= size {
+				continue
+			}
+			r := o.scrollRange(context)
+			if o.setOffset(o.offsetX+rubberBandDelta(dx, overscroll(o.offsetX, float64(r.Min.X), float64(r.Max.X)), float64(size)), o.offsetY) {
+				changed = true
+			}
+			break
+		}
+	}
+	if dy != 0 {
+		for _, o := range chain {
+			if !o.rubberBand {
+				continue
+			}
+			size := context.Size(o).Y
+			if o.contentSize.Y <= size {
+				continue
+			}
+			r := o.scrollRange(context)
+			if o.setOffset(o.offsetX, o.offsetY+rubberBandDelta(dy, overscroll(o.offsetY, float64(r.Min.Y), float64(r.Max.Y)), float64(size))) {
+				changed = true
+			}
+			break
+		}
+	}
+	return changed
+}
+
+// scrollAxis scrolls offset by delta within [lo, hi], and returns the new offset and the remaining delta.
+// An overscrolled offset outside of [lo, hi] can move back to the range.
+func scrollAxis(offset, delta, lo, hi float64) (float64, float64) {
+	if delta == 0 {
+		return offset, 0
+	}
+	if offset > hi {
+		if delta > 0 {
+			return offset, delta
+		}
+		if offset+delta >= hi {
+			return offset + delta, 0
+		}
+		delta = offset + delta - hi
+		offset = hi
+	}
+	if offset < lo {
+		if delta < 0 {
+			return offset, delta
+		}
+		if offset+delta <= lo {
+			return offset + delta, 0
+		}
+		delta = offset + delta - lo
+		offset = lo
+	}
+	newOffset := min(max(offset+delta, lo), hi)
+	return newOffset, offset + delta - newOffset
+}
+
+// overscroll returns how far offset is outside of [lo, hi].
+func overscroll(offset, lo, hi float64) float64 {
+	if offset > hi {
+		return offset - hi
+	}
+	if offset < lo {
+		return offset - lo
+	}
+	return 0
+}
+
+// rubberBandDelta returns the delta applied to an overscrolled offset.
+// The more the offset is overscrolled, the more resistant the offset is.
+func rubberBandDelta(delta, overscroll, size float64) float64 {
+	if size <= 0 {
+		return 0
+	}
+	return delta * 0.5 * max(0, 1-math.Abs(overscroll)/size)
+}
+
+// setOffset sets the offset without clamping, and reports whether the offset is changed.
+func (s *ScrollOverlay) setOffset(x, y float64) bool {
+	if s.offsetX == x && s.offsetY == y {
+		return false
+	}
+	s.offsetX = x
+	s.offsetY = y
+	if s.onScroll != nil {
+		s.onScroll(s.offsetX, s.offsetY)
+	}
+	guigui.RequestRedraw(s)
+	return true
+}
+
+// shiftOffset moves the offset without showing the bars, e.g. to keep the visible content at the same position
+// when the content above it changes its size.
+func (s *ScrollOverlay) shiftOffset(dx, dy float64) {
+	if !s.setOffset(s.offsetX+dx, s.offsetY+dy) {
+		return
+	}
+	s.lastOffsetX += dx
+	s.lastOffsetY += dy
+	s.animationFromX += dx
+	s.animationFromY += dy
+	s.animationToX += dx
+	s.animationToY += dy
+}
+
+// updateMotion advances the animation, the inertial scrolling and the spring-back of an overscroll.
+func (s *ScrollOverlay) updateMotion(context *guigui.Context) {
+	if s.animationCount > 0 {
+		s.animationCount--
+		rate := easeOutQuad(1 - float64(s.animationCount)/float64(scrollAnimationMaxCount()))
+		x := s.animationFromX + (s.animationToX-s.animationFromX)*rate
+		y := s.animationFromY + (s.animationToY-s.animationFromY)*rate
+		x, y = s.doAdjustOffset(context, x, y)
+		s.setOffset(x, y)
+	}
+
+	if s.velocityX != 0 || s.velocityY != 0 {
+		chain := s.chain
+		if len(chain) == 0 {
+			s.tmpChain = append(s.tmpChain[:0], s)
+			chain = s.tmpChain
+		}
+		scrollChainBy(context, chain, s.velocityX, s.velocityY, true)
+
+		friction := scrollFriction()
+		r := s.scrollRange(context)
+		if overscroll(s.offsetX, float64(r.Min.X), float64(r.Max.X)) != 0 || overscroll(s.offsetY, float64(r.Min.Y), float64(r.Max.Y)) != 0 {
+			// Stop quickly when the content is overscrolled.
+			friction /= 2
+		}
+		s.velocityX *= friction
+		s.velocityY *= friction
+		if math.Hypot(s.velocityX, s.velocityY) < 0.1*context.Scale() {
+			s.velocityX = 0
+			s.velocityY = 0
+		}
+	}
+
+	if !s.holdOverscroll {
+		x, y := s.doAdjustOffset(context, s.offsetX, s.offsetY)
+		if x != s.offsetX || y != s.offsetY {
+			rate := scrollSpringBackRate()
+			nx := s.offsetX + (x-s.offsetX)*rate
+			ny := s.offsetY + (y-s.offsetY)*rate
+			if math.Abs(x-nx) < 0.5 {
+				nx = x
+			}
+			if math.Abs(y-ny) < 0.5 {
+				ny = y
+			}
+			s.setOffset(nx, ny)
+		}
+	}
+}
+
 func (s *ScrollOverlay) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
 	x, y := ebiten.CursorPosition()
 	hb, vb := s.barBounds(context)
@@ -264,7 +641,7 @@ func (s *ScrollOverlay) isBarVisible(context *guigui.Context) bool {
 		return false
 	}
 
-	if s.draggingX || s.draggingY {
+	if s.isDraggingBar(context) || s.touch.IsActive() || s.velocityX != 0 || s.velocityY != 0 || s.animationCount > 0 {
 		return true
 	}
 	if s.lastWheelX != 0 || s.lastWheelY != 0 {
@@ -321,6 +698,8 @@ func (s *ScrollOverlay) showBars(context *guigui.Context) {
 }
 
 func (s *ScrollOverlay) Tick(context *guigui.Context) error {
+	s.updateMotion(context)
+
 	shouldShowBar := s.isBarVisible(context)
 
 	if s.lastOffsetX != s.offsetX || s.lastOffsetY != s.offsetY {
@@ -414,7 +793,7 @@ func (s *ScrollOverlay) barBounds(context *guigui.Context) (image.Rectangle, ima
 
 	var horizontalBarBounds, verticalBarBounds image.Rectangle
 	if s.contentSize.X > bounds.Dx() {
-		rate := -offsetX / float64(s.contentSize.X-bounds.Dx())
+		rate := min(max(-offsetX/float64(s.contentSize.X-bounds.Dx()), 0), 1)
 		x0 := float64(bounds.Min.X) + padding + rate*(float64(bounds.Dx())-2*padding-barWidth)
 		x1 := x0 + float64(barWidth)
 		var y0, y1 float64
@@ -428,7 +807,7 @@ func (s *ScrollOverlay) barBounds(context *guigui.Context) (image.Rectangle, ima
 		horizontalBarBounds = image.Rect(int(x0), int(y0), int(x1), int(y1))
 	}
 	if s.contentSize.Y > bounds.Dy() {
-		rate := -offsetY / float64(s.contentSize.Y-bounds.Dy())
+		rate := min(max(-offsetY/float64(s.contentSize.Y-bounds.Dy()), 0), 1)
 		y0 := float64(bounds.Min.Y) + padding + rate*(float64(bounds.Dy())-2*padding-barHeight)
 		y1 := y0 + float64(barHeight)
 		var x0, x1 float64
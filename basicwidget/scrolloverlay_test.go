@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func TestScrollAxis(t *testing.T) {
+	testCases := []struct {
+		offset        float64
+		delta         float64
+		wantOffset    float64
+		wantRemaining float64
+	}{
+		// In the range.
+		{offset: -50, delta: 10, wantOffset: -40, wantRemaining: 0},
+		{offset: -50, delta: -10, wantOffset: -60, wantRemaining: 0},
+		// Hitting the limits.
+		{offset: -10, delta: 30, wantOffset: 0, wantRemaining: 20},
+		{offset: -90, delta: -30, wantOffset: -100, wantRemaining: -20},
+		{offset: 0, delta: 5, wantOffset: 0, wantRemaining: 5},
+		// Overscrolled.
+		{offset: 20, delta: 5, wantOffset: 20, wantRemaining: 5},
+		{offset: 20, delta: -5, wantOffset: 15, wantRemaining: 0},
+		{offset: 20, delta: -30, wantOffset: -10, wantRemaining: 0},
+		{offset: 20, delta: -150, wantOffset: -100, wantRemaining: -30},
+		{offset: -120, delta: 10, wantOffset: -110, wantRemaining: 0},
+		{offset: -120, delta: -10, wantOffset: -120, wantRemaining: -10},
+	}
+	for _, tc := range testCases {
+		gotOffset, gotRemaining := basicwidget.ScrollAxis(tc.offset, tc.delta, -100, 0)
+		if gotOffset != tc.wantOffset || gotRemaining != tc.wantRemaining {
+			t.Errorf("ScrollAxis(%v, %v, -100, 0): got: (%v, %v), want: (%v, %v)", tc.offset, tc.delta, gotOffset, gotRemaining, tc.wantOffset, tc.wantRemaining)
+		}
+	}
+}
+
+func TestRubberBandDelta(t *testing.T) {
+	prev := basicwidget.RubberBandDelta(10, 0, 100)
+	if prev <= 0 || prev >= 10 {
+		t.Errorf("RubberBandDelta(10, 0, 100): got: %v, want: (0, 10)", prev)
+	}
+	for _, overscroll := range []float64{10, 50, 90} {
+		got := basicwidget.RubberBandDelta(10, overscroll, 100)
+		if got >= prev {
+			t.Errorf("RubberBandDelta(10, %v, 100): got: %v, want: < %v", overscroll, got, prev)
+		}
+		prev = got
+	}
+	if got := basicwidget.RubberBandDelta(10, 100, 100); got != 0 {
+		t.Errorf("RubberBandDelta(10, 100, 100): got: %v, want: 0", got)
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+type ShortcutModifiers int
+
+const (
+	// ShortcutModifierCommand is the Control key, or the Command key on macOS.
+	ShortcutModifierCommand ShortcutModifiers = 1 << iota
+	ShortcutModifierShift
+	ShortcutModifierAlt
+)
+
+// Shortcut is a keyboard shortcut, e.g. Ctrl+S.
+type Shortcut struct {
+	Key       ebiten.Key
+	Modifiers ShortcutModifiers
+}
+
+// String returns the text of the shortcut for the current platform, e.g. "Ctrl+Shift+S" or "Cmd+Shift+S".
+func (s Shortcut) String() string {
+	return s.string(useEmacsKeybind())
+}
+
+func (s Shortcut) string(darwin bool) string {
+	var b strings.Builder
+	if s.Modifiers&ShortcutModifierCommand != 0 {
+		if darwin {
+			b.WriteString("Cmd+")
+		} else {
+			b.WriteString("Ctrl+")
+		}
+	}
+	if s.Modifiers&ShortcutModifierAlt != 0 {
+		if darwin {
+			b.WriteString("Option+")
+		} else {
+			b.WriteString("Alt+")
+		}
+	}
+	if s.Modifiers&ShortcutModifierShift != 0 {
+		b.WriteString("Shift+")
+	}
+	b.WriteString(shortcutKeyName(s.Key))
+	return b.String()
+}
+
+func shortcutKeyName(key ebiten.Key) string {
+	switch key {
+	case ebiten.KeyComma:
+		return ","
+	case ebiten.KeyPeriod:
+		return "."
+	case ebiten.KeySlash:
+		return "/"
+	case ebiten.KeyBackslash:
+		return "\\"
+	case ebiten.KeySemicolon:
+		return ";"
+	case ebiten.KeyQuote:
+		return "'"
+	case ebiten.KeyMinus:
+		return "-"
+	case ebiten.KeyEqual:
+		return "="
+	case ebiten.KeyBracketLeft:
+		return "["
+	case ebiten.KeyBracketRight:
+		return "]"
+	case ebiten.KeyBackquote:
+		return "`"
+	}
+	name := key.String()
+	for _, prefix := range []string{"Digit", "Arrow"} {
+		if n, ok := strings.CutPrefix(name, prefix); ok {
+			return n
+		}
+	}
+	return name
+}
+
+// isJustPressed reports whether the key of the shortcut is just pressed with exactly the modifiers.
+func (s Shortcut) isJustPressed() bool {
+	if !inpututil.IsKeyJustPressed(s.Key) {
+		return false
+	}
+	if isCommandKeyPressed() != (s.Modifiers&ShortcutModifierCommand != 0) {
+		return false
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyShift) != (s.Modifiers&ShortcutModifierShift != 0) {
+		return false
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyAlt) != (s.Modifiers&ShortcutModifierAlt != 0) {
+		return false
+	}
+	return true
+}
@@ -20,7 +20,6 @@ type Slider struct {
 
 	abstractNumberInput abstractNumberInput
 
-	dragging           bool
 	draggingStartValue big.Int
 	draggingStartX     int
 
@@ -33,6 +32,11 @@ func (s *Slider) SetOnValueChangedBigInt(f func(value *big.Int)) {
 	s.onValueChangedBigInt = f
 }
 
+// BindValueInt64 binds the slider's value to value in both directions.
+func (s *Slider) BindValueInt64(value *guigui.Observable[int64]) {
+	s.abstractNumberInput.bindValueInt64(value)
+}
+
 func (s *Slider) SetOnValueChangedInt64(f func(value int64)) {
 	s.abstractNumberInput.SetOnValueChangedInt64(f)
 }
@@ -98,6 +102,8 @@ func (s *Slider) SetMaximumValueUint64(maximum uint64) {
 }
 
 func (s *Slider) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	s.abstractNumberInput.pullValueFromBinding(context)
+
 	if hovered := s.isThumbHovered(context); s.prevThumbHovered != hovered {
 		s.prevThumbHovered = hovered
 		guigui.RequestRedraw(s)
@@ -119,12 +125,13 @@ func (s *Slider) HandlePointingInput(context *guigui.Context) guigui.HandleInput
 		return guigui.HandleInputResult{}
 	}
 
-	if context.IsEnabled(s) && context.IsWidgetHitAtCursor(s) && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !s.dragging {
+	if context.IsEnabled(s) && context.IsWidgetHitAtCursor(s) && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !context.HasPointerCapture(s) {
 		context.SetFocused(s, true)
 		if !s.isThumbHovered(context) {
 			s.setValueFromCursor(context)
 		}
-		s.dragging = true
+		// Keep receiving the pointing input while dragging, even when the cursor is outside the slider.
+		context.CapturePointer(s)
 		x, _ := ebiten.CursorPosition()
 		s.draggingStartX = x
 		s.draggingStartValue.Set(s.abstractNumberInput.ValueBigInt())
@@ -132,22 +139,20 @@ func (s *Slider) HandlePointingInput(context *guigui.Context) guigui.HandleInput
 		return guigui.HandleInputByWidget(s)
 	}
 
-	if !context.IsEnabled(s) || !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		if s.dragging {
-			guigui.RequestRedraw(s)
-		}
-		s.dragging = false
-		s.draggingStartX = 0
-		s.draggingStartValue = big.Int{}
+	if !context.HasPointerCapture(s) {
 		return guigui.HandleInputResult{}
 	}
 
-	if context.IsEnabled(s) && s.dragging && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		s.setValueFromCursorDelta(context)
-		return guigui.HandleInputByWidget(s)
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		context.ReleasePointer(s)
+		s.draggingStartX = 0
+		s.draggingStartValue = big.Int{}
+		guigui.RequestRedraw(s)
+		return guigui.HandleInputResult{}
 	}
 
-	return guigui.HandleInputResult{}
+	s.setValueFromCursorDelta(context)
+	return guigui.HandleInputByWidget(s)
 }
 
 func (s *Slider) setValueFromCursorDelta(context *guigui.Context) {
@@ -200,7 +205,7 @@ func (s *Slider) thumbBounds(context *guigui.Context) image.Rectangle {
 }
 
 func (s *Slider) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
-	if s.canPress(context) || s.dragging {
+	if s.canPress(context) || context.HasPointerCapture(s) {
 		return ebiten.CursorShapePointer, true
 	}
 	return 0, true
@@ -261,7 +266,7 @@ func (s *Slider) Draw(context *guigui.Context, dst *ebiten.Image) {
 }
 
 func (s *Slider) canPress(context *guigui.Context) bool {
-	return context.IsEnabled(s) && s.isThumbHovered(context) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !s.dragging
+	return context.IsEnabled(s) && s.isThumbHovered(context) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !context.HasPointerCapture(s)
 }
 
 func (s *Slider) isThumbHovered(context *guigui.Context) bool {
@@ -269,7 +274,7 @@ func (s *Slider) isThumbHovered(context *guigui.Context) bool {
 }
 
 func (s *Slider) isActive(context *guigui.Context) bool {
-	return context.IsEnabled(s) && s.isThumbHovered(context) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && s.dragging
+	return context.IsEnabled(s) && s.isThumbHovered(context) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && context.HasPointerCapture(s)
 }
 
 func (s *Slider) DefaultSize(context *guigui.Context) image.Point {
@@ -0,0 +1,769 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+	"github.com/hajimehoshi/guigui/layout"
+)
+
+type TableSortOrder int
+
+const (
+	TableSortOrderNone TableSortOrder = iota
+	TableSortOrderAscending
+	TableSortOrderDescending
+)
+
+type TableColumn struct {
+	Title string
+
+	// Width is the width of the column. If Width is the zero value, the column has the minimum width.
+	// After the column is resized by the user, the column has the fixed width.
+	Width layout.Size
+
+	// MinWidth is the minimum width of the column. The default is UnitSize.
+	// The table scrolls horizontally when the columns cannot be narrower.
+	MinWidth int
+
+	HorizontalAlign HorizontalAlign
+
+	// Sortable reports whether clicking the header changes the sort order of the column.
+	Sortable bool
+
+	// Fixed reports whether the column cannot be resized by the user.
+	Fixed bool
+}
+
+// equal reports whether t and other are the same.
+// TableColumn is not comparable with == as layout.Size is not.
+func (t TableColumn) equal(other TableColumn) bool {
+	return t.Title == other.Title &&
+		t.Width.Equal(other.Width) &&
+		t.MinWidth == other.MinWidth &&
+		t.HorizontalAlign == other.HorizontalAlign &&
+		t.Sortable == other.Sortable &&
+		t.Fixed == other.Fixed
+}
+
+type TableRow[T comparable] struct {
+	Cells    []TableCell
+	Disabled bool
+	ID       T
+}
+
+type TableCell struct {
+	Text      string
+	TextColor color.Color
+
+	// Content is a widget rendering the cell instead of Text, if set.
+	Content guigui.Widget
+}
+
+// TableDataSource provides the rows of a Table lazily.
+type TableDataSource[T comparable] interface {
+	// RowCount returns the number of the rows.
+	RowCount() int
+
+	// RowByIndex returns the row at the index.
+	// RowByIndex is called only for indices in [0, RowCount()).
+	RowByIndex(index int) TableRow[T]
+}
+
+// Table is a widget to show rows in columns.
+//
+// Table builds only the visible rows, so a Table can have a huge number of rows.
+// Table does not sort the rows by itself. Sort the rows by the function set by SetOnSortChanged.
+type Table[T comparable] struct {
+	guigui.DefaultWidget
+
+	list       baseList[T]
+	header     tableHeader[T]
+	frame      listFrame[T]
+	rows       []TableRow[T]
+	dataSource TableDataSource[T]
+	rowWidgets []tableRowWidget[T]
+
+	columns []TableColumn
+
+	// columnOrder is the column indices in the visual order.
+	columnOrder []int
+
+	// columnWidthsPlus1 is the widths of the columns resized by the user.
+	columnWidthsPlus1 []int
+
+	// columnXs and columnWidths are the positions and the widths of the columns in the visual order.
+	columnXs     []int
+	columnWidths []int
+
+	tmpWidths []layout.Size
+
+	sortColumnPlus1 int
+	sortOrder       TableSortOrder
+
+	onSortChanged      func(column int, order TableSortOrder)
+	onColumnResized    func(column int, width int)
+	onColumnsReordered func(order []int)
+}
+
+func (t *Table[T]) SetColumns(columns []TableColumn) {
+	if slices.EqualFunc(t.columns, columns, TableColumn.equal) {
+		return
+	}
+	t.columns = adjustSliceSize(t.columns, len(columns))
+	copy(t.columns, columns)
+
+	// Keep the order and the widths as much as possible.
+	t.columnOrder = slices.DeleteFunc(t.columnOrder, func(c int) bool {
+		return c >= len(columns)
+	})
+	for c := range columns {
+		if !slices.Contains(t.columnOrder, c) {
+			t.columnOrder = append(t.columnOrder, c)
+		}
+	}
+	t.columnWidthsPlus1 = adjustSliceSize(t.columnWidthsPlus1, len(columns))
+	if t.sortColumnPlus1 > len(columns) {
+		t.sortColumnPlus1 = 0
+		t.sortOrder = TableSortOrderNone
+	}
+	guigui.RequestRedraw(t)
+}
+
+func (t *Table[T]) SetItems(rows []TableRow[T]) {
+	t.rows = adjustSliceSize(t.rows, len(rows))
+	copy(t.rows, rows)
+	t.dataSource = nil
+}
+
+// SetDataSource makes the table provide the rows by dataSource instead of the rows set by SetItems.
+// If dataSource is nil, the table shows the rows set by SetItems.
+func (t *Table[T]) SetDataSource(dataSource TableDataSource[T]) {
+	t.dataSource = dataSource
+	guigui.RequestRedraw(t)
+}
+
+func (t *Table[T]) SetItemBorderVisible(visible bool) {
+	t.list.SetStripeVisible(visible)
+}
+
+func (t *Table[T]) SetOnItemSelected(f func(index int)) {
+	t.list.SetOnItemSelected(f)
+}
+
+// SetOnSelectionChanged sets the function called with the selected row indices in ascending order when the selection is changed.
+func (t *Table[T]) SetOnSelectionChanged(f func(indices []int)) {
+	t.list.SetOnSelectionChanged(f)
+}
+
+// SetOnItemActivated sets the function called when a row is double-clicked, double-tapped or Enter is pressed on a row.
+func (t *Table[T]) SetOnItemActivated(f func(index int)) {
+	t.list.onItemActivated = f
+}
+
+func (t *Table[T]) SetSelectionMode(mode ListSelectionMode) {
+	t.list.SetSelectionMode(mode)
+}
+
+func (t *Table[T]) SelectItemByIndex(index int) {
+	t.list.SelectItemByIndex(index)
+}
+
+func (t *Table[T]) SelectItemByID(id T) {
+	t.list.SelectItemByID(id)
+}
+
+// SelectedItemIndex returns the smallest index of the selected rows, or -1 if no row is selected.
+func (t *Table[T]) SelectedItemIndex() int {
+	return t.list.SelectedItemIndex()
+}
+
+func (t *Table[T]) AppendSelectedItemIndices(indices []int) []int {
+	return t.list.AppendSelectedItemIndices(indices)
+}
+
+func (t *Table[T]) IsItemSelected(index int) bool {
+	return t.list.IsItemSelected(index)
+}
+
+func (t *Table[T]) JumpToItemIndex(index int) {
+	t.list.JumpToItemIndex(index)
+}
+
+// SetOnSortChanged sets the function called when the sort order is changed by clicking a sortable column header.
+func (t *Table[T]) SetOnSortChanged(f func(column int, order TableSortOrder)) {
+	t.onSortChanged = f
+}
+
+// SetSort sets the column showing the sort order.
+// If column is negative or order is TableSortOrderNone, no column shows the sort order.
+func (t *Table[T]) SetSort(column int, order TableSortOrder) {
+	if column < 0 || order == TableSortOrderNone {
+		column = -1
+		order = TableSortOrderNone
+	}
+	if t.sortColumnPlus1 == column+1 && t.sortOrder == order {
+		return
+	}
+	t.sortColumnPlus1 = column + 1
+	t.sortOrder = order
+	guigui.RequestRedraw(t)
+}
+
+// Sort returns the column showing the sort order and the order.
+// Sort returns -1 and TableSortOrderNone if no column shows the sort order.
+func (t *Table[T]) Sort() (int, TableSortOrder) {
+	return t.sortColumnPlus1 - 1, t.sortOrder
+}
+
+func (t *Table[T]) toggleSort(column int) {
+	order := TableSortOrderAscending
+	if t.sortColumnPlus1 == column+1 && t.sortOrder == TableSortOrderAscending {
+		order = TableSortOrderDescending
+	}
+	t.SetSort(column, order)
+	if t.onSortChanged != nil {
+		t.onSortChanged(column, order)
+	}
+}
+
+// SetOnColumnResized sets the function called when a column is resized by the user.
+func (t *Table[T]) SetOnColumnResized(f func(column int, width int)) {
+	t.onColumnResized = f
+}
+
+// SetColumnWidth sets the width of the column in pixels, overriding TableColumn.Width.
+// If width is negative, the column uses TableColumn.Width again.
+func (t *Table[T]) SetColumnWidth(column int, width int) {
+	if column < 0 || column >= len(t.columnWidthsPlus1) {
+		return
+	}
+	if width < 0 {
+		width = -1
+	}
+	if t.columnWidthsPlus1[column] == width+1 {
+		return
+	}
+	t.columnWidthsPlus1[column] = width + 1
+	guigui.RequestRedraw(t)
+}
+
+// SetOnColumnsReordered sets the function called with the column indices in the visual order when the columns are reordered by the user.
+func (t *Table[T]) SetOnColumnsReordered(f func(order []int)) {
+	t.onColumnsReordered = f
+}
+
+// SetColumnOrder sets the column indices in the visual order.
+// SetColumnOrder does nothing if order is not a permutation of the column indices.
+func (t *Table[T]) SetColumnOrder(order []int) {
+	if len(order) != len(t.columns) {
+		return
+	}
+	for c := range t.columns {
+		if !slices.Contains(order, c) {
+			return
+		}
+	}
+	if slices.Equal(t.columnOrder, order) {
+		return
+	}
+	t.columnOrder = append(t.columnOrder[:0], order...)
+	guigui.RequestRedraw(t)
+}
+
+// AppendColumnOrder appends the column indices in the visual order to order.
+func (t *Table[T]) AppendColumnOrder(order []int) []int {
+	return append(order, t.columnOrder...)
+}
+
+// moveColumn moves the column at the visual position from to the visual position to.
+// to is a position in the order before the column is removed.
+func (t *Table[T]) moveColumn(from, to int) {
+	if from < 0 || from >= len(t.columnOrder) || to < 0 || to > len(t.columnOrder) {
+		return
+	}
+	if to == from || to == from+1 {
+		return
+	}
+	MoveItemsInSlice(t.columnOrder, from, 1, to)
+	if t.onColumnsReordered != nil {
+		t.onColumnsReordered(slices.Clone(t.columnOrder))
+	}
+	guigui.RequestRedraw(t)
+}
+
+func (t *Table[T]) rowCount() int {
+	if t.dataSource != nil {
+		return t.dataSource.RowCount()
+	}
+	return len(t.rows)
+}
+
+func (t *Table[T]) rowByIndex(index int) TableRow[T] {
+	if t.dataSource != nil {
+		return t.dataSource.RowByIndex(index)
+	}
+	return t.rows[index]
+}
+
+func (t *Table[T]) columnMinWidth(context *guigui.Context, column int) int {
+	if w := t.columns[column].MinWidth; w > 0 {
+		return w
+	}
+	return UnitSize(context)
+}
+
+// resizedColumnWidth returns the width of the column resized from startWidth by dx, clamped to the minimum width.
+func (t *Table[T]) resizedColumnWidth(context *guigui.Context, column int, startWidth int, dx int) int {
+	return max(startWidth+dx, t.columnMinWidth(context, column))
+}
+
+// updateColumnWidths updates the positions and the widths of the columns, and returns the total width.
+func (t *Table[T]) updateColumnWidths(context *guigui.Context) int {
+	t.tmpWidths = adjustSliceSize(t.tmpWidths, len(t.columnOrder))
+	for i, c := range t.columnOrder {
+		if t.columnWidthsPlus1[c] > 0 {
+			t.tmpWidths[i] = layout.FixedSize(t.columnWidthsPlus1[c] - 1)
+			continue
+		}
+		t.tmpWidths[i] = t.columns[c].Width
+	}
+	w := context.Size(t).X - 2*RoundedCornerRadius(context) - 2*listItemPadding(context)
+	gl := layout.GridLayout{
+		Bounds: image.Rect(0, 0, max(w, 0), 0),
+		Widths: t.tmpWidths,
+	}
+
+	t.columnXs = adjustSliceSize(t.columnXs, len(t.columnOrder))
+	t.columnWidths = adjustSliceSize(t.columnWidths, len(t.columnOrder))
+	var x int
+	for i, c := range t.columnOrder {
+		t.columnXs[i] = x
+		t.columnWidths[i] = max(gl.CellBounds(i, 0).Dx(), t.columnMinWidth(context, c))
+		x += t.columnWidths[i]
+	}
+	return x
+}
+
+func tableHeaderHeight(context *guigui.Context) int {
+	return UnitSize(context)
+}
+
+func tableCellPadding(context *guigui.Context) int {
+	return UnitSize(context) / 4
+}
+
+func (t *Table[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	if len(t.columnWidthsPlus1) != len(t.columns) {
+		t.columnWidthsPlus1 = adjustSliceSize(t.columnWidthsPlus1, len(t.columns))
+	}
+	totalWidth := t.updateColumnWidths(context)
+
+	bounds := context.Bounds(t)
+	headerBounds := bounds
+	headerBounds.Max.Y = min(headerBounds.Min.Y+tableHeaderHeight(context), bounds.Max.Y)
+	listBounds := bounds
+	listBounds.Min.Y = headerBounds.Max.Y
+
+	t.list.frameHidden = true
+	t.list.setContentWidth(totalWidth + 2*RoundedCornerRadius(context) + 2*listItemPadding(context))
+	context.SetSize(&t.list, listBounds.Size())
+	t.updateRowWidgets(context)
+	start, end := t.list.itemRangeToBuild()
+
+	appender.AppendChildWidgetWithBounds(&t.list, listBounds)
+
+	for i := start; i < end; i++ {
+		w := t.rowWidget(i)
+		w.setTextColors(context, t.rowTextColor(context, i, &w.row))
+		context.SetSize(w, image.Pt(totalWidth, guigui.DefaultSize))
+		t.list.setItemHeight(context, i, context.Size(w).Y)
+	}
+
+	t.header.table = t
+	appender.AppendChildWidgetWithBounds(&t.header, headerBounds)
+
+	t.frame.list = &t.list
+	appender.AppendChildWidgetWithBounds(&t.frame, bounds)
+
+	return nil
+}
+
+// updateRowWidgets updates the row widgets only for the visible rows.
+func (t *Table[T]) updateRowWidgets(context *guigui.Context) {
+	t.list.setItemProvider(t.rowCount(), t.listItem, int(LineHeight(context)))
+	start, end := t.list.updateVisibleItemRange(context)
+	if len(t.rowWidgets) < end-start {
+		t.rowWidgets = adjustSliceSize(t.rowWidgets, end-start)
+	}
+	for i := start; i < end; i++ {
+		w := t.rowWidget(i)
+		w.table = t
+		w.setRow(t.rowByIndex(i))
+	}
+}
+
+func (t *Table[T]) listItem(index int) baseListItem[T] {
+	if w := t.rowWidget(index); w != nil {
+		return baseListItem[T]{
+			Content:    w,
+			Selectable: !w.row.Disabled,
+			ID:         w.row.ID,
+			Text:       w.firstCellText(),
+		}
+	}
+	row := t.rowByIndex(index)
+	var text string
+	if len(row.Cells) > 0 {
+		text = row.Cells[0].Text
+	}
+	return baseListItem[T]{
+		Selectable: !row.Disabled,
+		ID:         row.ID,
+		Text:       text,
+	}
+}
+
+// rowWidget returns the row widget at the index, or nil if the row does not have its widget.
+func (t *Table[T]) rowWidget(index int) *tableRowWidget[T] {
+	start, end := t.list.itemRangeToBuild()
+	if index < start || index >= end || len(t.rowWidgets) == 0 {
+		return nil
+	}
+	return &t.rowWidgets[index%len(t.rowWidgets)]
+}
+
+func (t *Table[T]) rowTextColor(context *guigui.Context, index int, row *TableRow[T]) color.Color {
+	switch {
+	case t.list.IsItemSelected(index) && !row.Disabled:
+		return DefaultActiveListItemTextColor(context)
+	case row.Disabled:
+		return DefaultDisabledListItemTextColor(context)
+	default:
+		return nil
+	}
+}
+
+func (t *Table[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
+	// Fill the corners of the list below the header.
+	clr := draw.ControlColor(context.ColorMode(), context.IsEnabled(t))
+	draw.DrawRoundedRect(context, dst, context.Bounds(t), clr, RoundedCornerRadius(context))
+}
+
+func (t *Table[T]) DefaultSize(context *guigui.Context) image.Point {
+	w := 2*RoundedCornerRadius(context) + 2*listItemPadding(context)
+	for c := range t.columns {
+		if t.columnWidthsPlus1[c] > 0 {
+			w += t.columnWidthsPlus1[c] - 1
+			continue
+		}
+		w += max(t.columnMinWidth(context, c), 4*UnitSize(context))
+	}
+	return image.Pt(w, 6*UnitSize(context))
+}
+
+type tableRowWidget[T comparable] struct {
+	guigui.DefaultWidget
+
+	table *Table[T]
+	row   TableRow[T]
+	texts []Text
+}
+
+func (t *tableRowWidget[T]) setRow(row TableRow[T]) {
+	t.row = row
+	t.texts = adjustSliceSize(t.texts, len(row.Cells))
+	for i, cell := range row.Cells {
+		t.texts[i].SetValue(cell.Text)
+	}
+}
+
+func (t *tableRowWidget[T]) firstCellText() string {
+	if len(t.row.Cells) == 0 {
+		return ""
+	}
+	return t.row.Cells[0].Text
+}
+
+// setTextColors sets the text color of the cells. If clr is nil, the cells' own colors are used.
+func (t *tableRowWidget[T]) setTextColors(context *guigui.Context, clr color.Color) {
+	for i, cell := range t.row.Cells {
+		c := clr
+		if c == nil {
+			c = cell.TextColor
+		}
+		if c == nil {
+			c = draw.TextColor(context.ColorMode(), context.IsEnabled(t))
+		}
+		t.texts[i].SetColor(c)
+	}
+}
+
+func (t *tableRowWidget[T]) cellBounds(context *guigui.Context, visualIndex int) image.Rectangle {
+	bounds := context.Bounds(t)
+	bounds.Min.X += t.table.columnXs[visualIndex]
+	bounds.Max.X = bounds.Min.X + t.table.columnWidths[visualIndex]
+	return bounds
+}
+
+func (t *tableRowWidget[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	for i, c := range t.table.columnOrder {
+		if c >= len(t.row.Cells) {
+			continue
+		}
+		bounds := t.cellBounds(context, i)
+		if cell := t.row.Cells[c]; cell.Content != nil {
+			appender.AppendChildWidgetWithBounds(cell.Content, bounds)
+			continue
+		}
+		text := &t.texts[c]
+		text.SetHorizontalAlign(t.table.columns[c].HorizontalAlign)
+		text.SetVerticalAlign(VerticalAlignMiddle)
+		appender.AppendChildWidgetWithBounds(text, bounds.Inset(tableCellPadding(context)))
+	}
+	return nil
+}
+
+func (t *tableRowWidget[T]) DefaultSize(context *guigui.Context) image.Point {
+	var w int
+	if len(t.table.columnXs) > 0 {
+		last := len(t.table.columnXs) - 1
+		w = t.table.columnXs[last] + t.table.columnWidths[last]
+	}
+	h := int(LineHeight(context))
+	for _, cell := range t.row.Cells {
+		if cell.Content == nil {
+			continue
+		}
+		h = max(h, cell.Content.DefaultSize(context).Y)
+	}
+	return image.Pt(w, h)
+}
+
+type tableHeader[T comparable] struct {
+	guigui.DefaultWidget
+
+	table      *Table[T]
+	texts      []Text
+	sortIcon   Image
+	sortBounds image.Rectangle
+
+	// resizingColumnPlus1 is the visual index of the column resized by dragging its right edge.
+	resizingColumnPlus1 int
+	resizeStartWidth    int
+
+	// pressingColumnPlus1 is the visual index of the pressed column, which is sorted by clicking or reordered by dragging.
+	pressingColumnPlus1 int
+	reordering          bool
+	reorderDstPlus1     int
+
+	pressStartX int
+}
+
+// columnOffsetX returns the X position of the columns.
+func (t *tableHeader[T]) columnOffsetX(context *guigui.Context) int {
+	offsetX, _ := t.table.list.scrollOverlay.Offset()
+	return context.Position(t).X + RoundedCornerRadius(context) + listItemPadding(context) + int(offsetX)
+}
+
+func (t *tableHeader[T]) cellBounds(context *guigui.Context, visualIndex int) image.Rectangle {
+	bounds := context.Bounds(t)
+	bounds.Min.X = t.columnOffsetX(context) + t.table.columnXs[visualIndex]
+	bounds.Max.X = bounds.Min.X + t.table.columnWidths[visualIndex]
+	return bounds
+}
+
+func (t *tableHeader[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	t.texts = adjustSliceSize(t.texts, len(t.table.columns))
+	t.sortBounds = image.Rectangle{}
+	for i, c := range t.table.columnOrder {
+		column := &t.table.columns[c]
+		bounds := t.cellBounds(context, i).Inset(tableCellPadding(context))
+		if c == t.table.sortColumnPlus1-1 {
+			name := "keyboard_arrow_up"
+			if t.table.sortOrder == TableSortOrderDescending {
+				name = "keyboard_arrow_down"
+			}
+			img, err := theResourceImages.Get(name, context.ColorMode())
+			if err != nil {
+				return err
+			}
+			t.sortIcon.SetImage(img)
+			s := int(LineHeight(context))
+			p := image.Pt(bounds.Max.X-s, bounds.Min.Y+(bounds.Dy()-s)/2)
+			t.sortBounds = image.Rectangle{Min: p, Max: p.Add(image.Pt(s, s))}
+			appender.AppendChildWidgetWithBounds(&t.sortIcon, t.sortBounds)
+			bounds.Max.X = max(bounds.Max.X-s, bounds.Min.X)
+		}
+		text := &t.texts[c]
+		text.SetValue(column.Title)
+		text.SetBold(true)
+		text.SetHorizontalAlign(column.HorizontalAlign)
+		text.SetVerticalAlign(VerticalAlignMiddle)
+		text.SetColor(draw.TextColor(context.ColorMode(), context.IsEnabled(t)))
+		appender.AppendChildWidgetWithBounds(text, bounds)
+	}
+	return nil
+}
+
+// resizeHandleAt returns the visual index of the column whose right edge is at x, or -1 if there is no such column.
+func (t *tableHeader[T]) resizeHandleAt(context *guigui.Context, x int) int {
+	d := UnitSize(context) / 8
+	for i := len(t.table.columnOrder) - 1; i >= 0; i-- {
+		if t.table.columns[t.table.columnOrder[i]].Fixed {
+			continue
+		}
+		edge := t.cellBounds(context, i).Max.X
+		if edge-d <= x && x < edge+d {
+			return i
+		}
+	}
+	return -1
+}
+
+// columnAt returns the visual index of the column at x, or -1 if there is no column.
+func (t *tableHeader[T]) columnAt(context *guigui.Context, x int) int {
+	for i := range t.table.columnOrder {
+		if b := t.cellBounds(context, i); b.Min.X <= x && x < b.Max.X {
+			return i
+		}
+	}
+	return -1
+}
+
+// reorderDstAt returns the visual position where the dragged column is inserted when dropped at x.
+func (t *tableHeader[T]) reorderDstAt(context *guigui.Context, x int) int {
+	for i := range t.table.columnOrder {
+		b := t.cellBounds(context, i)
+		if x < (b.Min.X+b.Max.X)/2 {
+			return i
+		}
+	}
+	return len(t.table.columnOrder)
+}
+
+func (t *tableHeader[T]) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	x, _ := ebiten.CursorPosition()
+
+	if t.resizingColumnPlus1 > 0 {
+		i := t.resizingColumnPlus1 - 1
+		if i >= len(t.table.columnOrder) {
+			t.resizingColumnPlus1 = 0
+			return guigui.HandleInputResult{}
+		}
+		c := t.table.columnOrder[i]
+		w := t.table.resizedColumnWidth(context, c, t.resizeStartWidth, x-t.pressStartX)
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			t.table.SetColumnWidth(c, w)
+			return guigui.HandleInputByWidget(t)
+		}
+		t.resizingColumnPlus1 = 0
+		if t.table.onColumnResized != nil {
+			t.table.onColumnResized(c, w)
+		}
+		return guigui.HandleInputByWidget(t)
+	}
+
+	if t.pressingColumnPlus1 > 0 {
+		i := t.pressingColumnPlus1 - 1
+		if i >= len(t.table.columnOrder) {
+			t.pressingColumnPlus1 = 0
+			return guigui.HandleInputResult{}
+		}
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			if !t.reordering && abs(x-t.pressStartX) >= UnitSize(context)/4 {
+				t.reordering = true
+			}
+			if t.reordering {
+				if dst := t.reorderDstAt(context, x); t.reorderDstPlus1 != dst+1 {
+					t.reorderDstPlus1 = dst + 1
+					guigui.RequestRedraw(t)
+				}
+			}
+			return guigui.HandleInputByWidget(t)
+		}
+		if t.reordering {
+			t.table.moveColumn(i, t.reorderDstPlus1-1)
+		} else if c := t.table.columnOrder[i]; t.table.columns[c].Sortable && context.IsWidgetHitAtCursor(t) && t.columnAt(context, x) == i {
+			t.table.toggleSort(c)
+		}
+		t.pressingColumnPlus1 = 0
+		t.reordering = false
+		t.reorderDstPlus1 = 0
+		guigui.RequestRedraw(t)
+		return guigui.HandleInputByWidget(t)
+	}
+
+	if !context.IsWidgetHitAtCursor(t) {
+		return guigui.HandleInputResult{}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		t.pressStartX = x
+		if i := t.resizeHandleAt(context, x); i >= 0 {
+			t.resizingColumnPlus1 = i + 1
+			t.resizeStartWidth = t.table.columnWidths[i]
+			return guigui.HandleInputByWidget(t)
+		}
+		if i := t.columnAt(context, x); i >= 0 {
+			t.pressingColumnPlus1 = i + 1
+			return guigui.HandleInputByWidget(t)
+		}
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (t *tableHeader[T]) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	if t.resizingColumnPlus1 > 0 {
+		return ebiten.CursorShapeEWResize, true
+	}
+	x, _ := ebiten.CursorPosition()
+	if t.resizeHandleAt(context, x) >= 0 {
+		return ebiten.CursorShapeEWResize, true
+	}
+	return 0, false
+}
+
+func (t *tableHeader[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
+	// Round only the top corners. The bottom corners are out of the visible bounds.
+	bounds := context.Bounds(t)
+	r := RoundedCornerRadius(context)
+	bg := bounds
+	bg.Max.Y += r
+	draw.DrawRoundedRect(context, dst, bg, draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.9), r)
+
+	clr := draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.8)
+	width := float32(1 * context.Scale())
+	y0 := float32(bounds.Min.Y) + float32(bounds.Dy())/4
+	y1 := float32(bounds.Max.Y) - float32(bounds.Dy())/4
+	for i := range len(t.table.columnOrder) - 1 {
+		x := float32(t.cellBounds(context, i).Max.X)
+		vector.StrokeLine(dst, x, y0, x, y1, width, clr, false)
+	}
+	vector.StrokeLine(dst, float32(bounds.Min.X), float32(bounds.Max.Y)-width/2, float32(bounds.Max.X), float32(bounds.Max.Y)-width/2, width, clr, false)
+
+	// Draw the dragged column and the destination.
+	if t.reordering && t.pressingColumnPlus1 > 0 {
+		cb := t.cellBounds(context, t.pressingColumnPlus1-1)
+		draw.DrawRoundedRect(context, dst, cb, draw.ScaleAlpha(draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5), 0.2), r)
+		if to := t.reorderDstPlus1 - 1; to >= 0 {
+			var x int
+			if to < len(t.table.columnOrder) {
+				x = t.cellBounds(context, to).Min.X
+			} else {
+				x = t.cellBounds(context, to-1).Max.X
+			}
+			accent := draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5)
+			vector.StrokeLine(dst, float32(x), float32(bounds.Min.Y), float32(x), float32(bounds.Max.Y), 2*width, accent, false)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/guigui/layout"
+)
+
+// testTableColumns are the columns for the table tests.
+var testTableColumns = []TableColumn{
+	{Title: "A", MinWidth: 20, Sortable: true},
+	{Title: "B", MinWidth: 40, Sortable: true},
+	{Title: "C", MinWidth: 10},
+}
+
+func TestTableResizeColumn(t *testing.T) {
+	var table Table[int]
+	table.SetColumns(testTableColumns)
+	testCases := []struct {
+		column     int
+		startWidth int
+		dx         int
+		want       int
+	}{
+		{column: 0, startWidth: 100, dx: 30, want: 130},
+		{column: 0, startWidth: 100, dx: -50, want: 50},
+		{column: 0, startWidth: 100, dx: -80, want: 20},
+		{column: 0, startWidth: 100, dx: -200, want: 20},
+		{column: 1, startWidth: 100, dx: -70, want: 40},
+	}
+	for _, tc := range testCases {
+		if got := table.resizedColumnWidth(nil, tc.column, tc.startWidth, tc.dx); got != tc.want {
+			t.Errorf("resizedColumnWidth(%d, %d, %d): got: %d, want: %d", tc.column, tc.startWidth, tc.dx, got, tc.want)
+		}
+	}
+
+	table.SetColumnWidth(1, 50)
+	if got, want := table.columnWidthsPlus1[1]-1, 50; got != want {
+		t.Errorf("column width: got: %d, want: %d", got, want)
+	}
+	// An out-of-range column is ignored.
+	table.SetColumnWidth(3, 50)
+	table.SetColumnWidth(-1, 50)
+	// A negative width resets the width.
+	table.SetColumnWidth(1, -10)
+	if got := table.columnWidthsPlus1[1]; got != 0 {
+		t.Errorf("column width after reset: got: %d, want: unset", got-1)
+	}
+}
+
+func TestTableMoveColumn(t *testing.T) {
+	testCases := []struct {
+		from      int
+		to        int
+		want      []int
+		wantMoved bool
+	}{
+		{from: 0, to: 3, want: []int{1, 2, 0}, wantMoved: true},
+		{from: 2, to: 0, want: []int{2, 0, 1}, wantMoved: true},
+		{from: 0, to: 2, want: []int{1, 0, 2}, wantMoved: true},
+		// Moving to the same position does nothing.
+		{from: 1, to: 1, want: []int{0, 1, 2}, wantMoved: false},
+		{from: 1, to: 2, want: []int{0, 1, 2}, wantMoved: false},
+		// Out of range.
+		{from: -1, to: 0, want: []int{0, 1, 2}, wantMoved: false},
+		{from: 3, to: 0, want: []int{0, 1, 2}, wantMoved: false},
+		{from: 0, to: 4, want: []int{0, 1, 2}, wantMoved: false},
+	}
+	for _, tc := range testCases {
+		var table Table[int]
+		table.SetColumns(testTableColumns)
+		var moved []int
+		table.SetOnColumnsReordered(func(order []int) {
+			moved = order
+		})
+		table.moveColumn(tc.from, tc.to)
+		if got := table.AppendColumnOrder(nil); !slices.Equal(got, tc.want) {
+			t.Errorf("moveColumn(%d, %d): got: %v, want: %v", tc.from, tc.to, got, tc.want)
+		}
+		if got := moved != nil; got != tc.wantMoved {
+			t.Errorf("moveColumn(%d, %d): reordered: got: %t, want: %t", tc.from, tc.to, got, tc.wantMoved)
+		}
+		if tc.wantMoved && !slices.Equal(moved, tc.want) {
+			t.Errorf("moveColumn(%d, %d): reordered order: got: %v, want: %v", tc.from, tc.to, moved, tc.want)
+		}
+	}
+}
+
+func TestTableSetColumnOrder(t *testing.T) {
+	testCases := []struct {
+		order []int
+		want  []int
+	}{
+		{order: []int{2, 0, 1}, want: []int{2, 0, 1}},
+		{order: []int{0, 1, 2}, want: []int{0, 1, 2}},
+		// Invalid orders are ignored.
+		{order: []int{0, 1}, want: []int{0, 1, 2}},
+		{order: []int{0, 1, 2, 3}, want: []int{0, 1, 2}},
+		{order: []int{0, 0, 1}, want: []int{0, 1, 2}},
+		{order: []int{0, 1, 3}, want: []int{0, 1, 2}},
+		{order: nil, want: []int{0, 1, 2}},
+	}
+	for _, tc := range testCases {
+		var table Table[int]
+		table.SetColumns(testTableColumns)
+		table.SetColumnOrder(tc.order)
+		if got := table.AppendColumnOrder(nil); !slices.Equal(got, tc.want) {
+			t.Errorf("SetColumnOrder(%v): got: %v, want: %v", tc.order, got, tc.want)
+		}
+	}
+
+	// The order is kept as much as possible when the columns are changed.
+	var table Table[int]
+	table.SetColumns(testTableColumns)
+	table.SetColumnOrder([]int{2, 0, 1})
+	table.SetColumns([]TableColumn{
+		{Title: "A"},
+		{Title: "B"},
+	})
+	if got, want := table.AppendColumnOrder(nil), []int{0, 1}; !slices.Equal(got, want) {
+		t.Errorf("AppendColumnOrder after SetColumns: got: %v, want: %v", got, want)
+	}
+}
+
+func TestTableToggleSort(t *testing.T) {
+	type sort struct {
+		column int
+		order  TableSortOrder
+	}
+	steps := []struct {
+		column int
+		want   sort
+	}{
+		{column: 0, want: sort{column: 0, order: TableSortOrderAscending}},
+		{column: 0, want: sort{column: 0, order: TableSortOrderDescending}},
+		{column: 0, want: sort{column: 0, order: TableSortOrderAscending}},
+		// Another column starts from the ascending order.
+		{column: 1, want: sort{column: 1, order: TableSortOrderAscending}},
+		{column: 1, want: sort{column: 1, order: TableSortOrderDescending}},
+		{column: 0, want: sort{column: 0, order: TableSortOrderAscending}},
+	}
+	var table Table[int]
+	table.SetColumns(testTableColumns)
+	var got sort
+	table.SetOnSortChanged(func(column int, order TableSortOrder) {
+		got = sort{column: column, order: order}
+	})
+	for i, s := range steps {
+		table.toggleSort(s.column)
+		if got != s.want {
+			t.Errorf("step %d: OnSortChanged: got: %+v, want: %+v", i, got, s.want)
+		}
+		if column, order := table.Sort(); column != s.want.column || order != s.want.order {
+			t.Errorf("step %d: Sort: got: %d, %v, want: %d, %v", i, column, order, s.want.column, s.want.order)
+		}
+	}
+}
+
+func TestTableColumnsEqual(t *testing.T) {
+	lazy := layout.LazySize(func(int) layout.Size {
+		return layout.FixedSize(10)
+	})
+	testCases := []struct {
+		a    TableColumn
+		b    TableColumn
+		want bool
+	}{
+		{a: TableColumn{Title: "A"}, b: TableColumn{Title: "A"}, want: true},
+		{a: TableColumn{Title: "A"}, b: TableColumn{Title: "B"}, want: false},
+		{a: TableColumn{Width: layout.FixedSize(10)}, b: TableColumn{Width: layout.FixedSize(10)}, want: true},
+		{a: TableColumn{Width: layout.FixedSize(10)}, b: TableColumn{Width: layout.FixedSize(20)}, want: false},
+		{a: TableColumn{Width: layout.FixedSize(1)}, b: TableColumn{Width: layout.FlexibleSize(1)}, want: false},
+		{a: TableColumn{Width: lazy}, b: TableColumn{Width: lazy}, want: false},
+		{a: TableColumn{MinWidth: 10}, b: TableColumn{MinWidth: 20}, want: false},
+		{a: TableColumn{Sortable: true}, b: TableColumn{}, want: false},
+		{a: TableColumn{Fixed: true}, b: TableColumn{}, want: false},
+	}
+	for i, tc := range testCases {
+		if got := tc.a.equal(tc.b); got != tc.want {
+			t.Errorf("%d: got: %t, want: %t", i, got, tc.want)
+		}
+	}
+}
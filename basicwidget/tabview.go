@@ -0,0 +1,725 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"log/slog"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+)
+
+type TabViewItem[T comparable] struct {
+	Text string
+	Icon *ebiten.Image
+
+	// Content is the pane shown while the tab is selected.
+	// Content is built only while the tab is selected, and keeps its state while the tab is hidden.
+	Content guigui.Widget
+
+	// Closable reports whether the tab has a close button.
+	Closable bool
+
+	// Dirty reports whether the content has unsaved changes. A dirty tab shows a dot instead of the close button unless hovered.
+	Dirty bool
+
+	Disabled bool
+	Movable  bool
+	ID       T
+}
+
+// TabView is a tab strip with content panes.
+//
+// Tabs are identified by their IDs, so the IDs must be unique.
+// The selection follows the ID when the items are reordered, and moves to a neighbor when the selected tab is removed.
+//
+// When the tabs don't fit in the strip, an overflow button listing all the tabs is shown.
+// Ctrl+Tab and Ctrl+Shift+Tab switch the tabs while the focus is in the tab view.
+type TabView[T comparable] struct {
+	guigui.DefaultWidget
+
+	strip tabViewStrip
+	items []TabViewItem[T]
+
+	selectedIndexPlus1 int
+
+	tmpStripItems []tabViewStripItem
+
+	onItemSelected       func(index int)
+	onItemCloseRequested func(index int)
+	onItemsMoved         func(from, count, to int)
+}
+
+// SetOnItemSelected sets the function called when a tab is selected by the user.
+func (t *TabView[T]) SetOnItemSelected(f func(index int)) {
+	t.onItemSelected = f
+}
+
+// SetOnItemCloseRequested sets the function called when the close button of a tab is pressed, or a closable tab is clicked with the middle button.
+// The function should remove the item by SetItems, possibly after a confirmation for a dirty tab.
+func (t *TabView[T]) SetOnItemCloseRequested(f func(index int)) {
+	t.onItemCloseRequested = f
+}
+
+// SetOnItemsMoved sets the function called when a movable tab is dragged to another position.
+// The function should move the items, e.g. by MoveItemsInSlice, and set them by SetItems.
+func (t *TabView[T]) SetOnItemsMoved(f func(from, count, to int)) {
+	t.onItemsMoved = f
+}
+
+func (t *TabView[T]) SetItems(items []TabViewItem[T]) {
+	var selectedID T
+	var hasSelectedID bool
+	if index := t.selectedIndexPlus1 - 1; index >= 0 && index < len(t.items) {
+		selectedID = t.items[index].ID
+		hasSelectedID = true
+	}
+
+	t.items = adjustSliceSize(t.items, len(items))
+	copy(t.items, items)
+
+	if hasSelectedID {
+		for i, item := range t.items {
+			if item.ID == selectedID {
+				t.selectedIndexPlus1 = i + 1
+				return
+			}
+		}
+	}
+
+	// The selected tab is removed, or nothing is selected yet. Select the nearest enabled tab.
+	index := min(max(t.selectedIndexPlus1-1, 0), len(t.items)-1)
+	if index >= 0 && t.items[index].Disabled {
+		if next := t.nextEnabledIndex(index, -1, false); next >= 0 {
+			index = next
+		} else {
+			index = t.nextEnabledIndex(index, 1, false)
+		}
+	}
+	t.selectedIndexPlus1 = index + 1
+}
+
+func (t *TabView[T]) ItemCount() int {
+	return len(t.items)
+}
+
+func (t *TabView[T]) ItemByIndex(index int) (TabViewItem[T], bool) {
+	if index < 0 || index >= len(t.items) {
+		return TabViewItem[T]{}, false
+	}
+	return t.items[index], true
+}
+
+func (t *TabView[T]) SelectedItem() (TabViewItem[T], bool) {
+	return t.ItemByIndex(t.SelectedItemIndex())
+}
+
+func (t *TabView[T]) SelectedItemIndex() int {
+	return t.selectedIndexPlus1 - 1
+}
+
+func (t *TabView[T]) SelectItemByIndex(index int) {
+	if index < 0 || index >= len(t.items) {
+		index = -1
+	}
+	if t.selectedIndexPlus1 == index+1 {
+		return
+	}
+	t.selectedIndexPlus1 = index + 1
+	guigui.RequestRedraw(t)
+}
+
+func (t *TabView[T]) SelectItemByID(id T) {
+	for i, item := range t.items {
+		if item.ID == id {
+			t.SelectItemByIndex(i)
+			return
+		}
+	}
+}
+
+func (t *TabView[T]) selectItemByUser(index int) {
+	if index < 0 || index >= len(t.items) || t.items[index].Disabled {
+		return
+	}
+	if t.selectedIndexPlus1 == index+1 {
+		return
+	}
+	t.SelectItemByIndex(index)
+	if t.onItemSelected != nil {
+		t.onItemSelected(index)
+	}
+}
+
+// nextEnabledIndex returns the index of the next enabled tab from index in the direction dir, or -1.
+func (t *TabView[T]) nextEnabledIndex(index int, dir int, wrap bool) int {
+	n := len(t.items)
+	for i := 1; i <= n; i++ {
+		j := index + dir*i
+		if wrap {
+			j = ((j % n) + n) % n
+		} else if j < 0 || j >= n {
+			return -1
+		}
+		if !t.items[j].Disabled {
+			return j
+		}
+	}
+	return -1
+}
+
+func (t *TabView[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	t.tmpStripItems = adjustSliceSize(t.tmpStripItems, len(t.items))
+	for i, item := range t.items {
+		t.tmpStripItems[i] = tabViewStripItem{
+			text:     item.Text,
+			icon:     item.Icon,
+			closable: item.Closable,
+			dirty:    item.Dirty,
+			disabled: item.Disabled,
+			movable:  item.Movable,
+		}
+	}
+	t.strip.setItems(t.tmpStripItems)
+	t.strip.selectedIndex = t.SelectedItemIndex()
+	t.strip.onTabSelected = t.selectItemByUser
+	t.strip.onTabCloseRequested = func(index int) {
+		if t.onItemCloseRequested != nil {
+			t.onItemCloseRequested(index)
+		}
+	}
+	t.strip.onTabsMoved = t.onItemsMoved
+
+	b := context.Bounds(t)
+	h := tabViewStripHeight(context)
+	appender.AppendChildWidgetWithBounds(&t.strip, image.Rect(b.Min.X, b.Min.Y, b.Max.X, b.Min.Y+h))
+
+	// Only the selected content is in the tree. The other contents keep their states as they are not reset.
+	if item, ok := t.SelectedItem(); ok && item.Content != nil {
+		appender.AppendChildWidgetWithBounds(item.Content, image.Rect(b.Min.X, b.Min.Y+h, b.Max.X, b.Max.Y))
+	}
+
+	return nil
+}
+
+// HandleButtonInput switches the tabs by Ctrl+Tab and Ctrl+Shift+Tab, and by Ctrl+PageDown and Ctrl+PageUp.
+func (t *TabView[T]) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !ebiten.IsKeyPressed(ebiten.KeyControl) {
+		return guigui.HandleInputResult{}
+	}
+	dir := 0
+	switch {
+	case isKeyRepeating(ebiten.KeyTab) && ebiten.IsKeyPressed(ebiten.KeyShift):
+		dir = -1
+	case isKeyRepeating(ebiten.KeyTab):
+		dir = 1
+	case isKeyRepeating(ebiten.KeyPageUp):
+		dir = -1
+	case isKeyRepeating(ebiten.KeyPageDown):
+		dir = 1
+	default:
+		return guigui.HandleInputResult{}
+	}
+	if next := t.nextEnabledIndex(t.SelectedItemIndex(), dir, true); next >= 0 {
+		t.selectItemByUser(next)
+	}
+	return guigui.HandleInputByWidget(t)
+}
+
+func (t *TabView[T]) DefaultSize(context *guigui.Context) image.Point {
+	u := UnitSize(context)
+	return image.Pt(12*u, tabViewStripHeight(context)+6*u)
+}
+
+func tabViewStripHeight(context *guigui.Context) int {
+	return UnitSize(context) * 3 / 2
+}
+
+func tabViewTabPadding(context *guigui.Context) int {
+	return UnitSize(context) / 2
+}
+
+func tabViewTabGap(context *guigui.Context) int {
+	return UnitSize(context) / 4
+}
+
+func tabViewCloseButtonSize(context *guigui.Context) int {
+	return UnitSize(context) * 3 / 4
+}
+
+func tabViewTabMinWidth(context *guigui.Context) int {
+	return 2 * UnitSize(context)
+}
+
+func tabViewTabMaxWidth(context *guigui.Context) int {
+	return 10 * UnitSize(context)
+}
+
+type tabViewStripItem struct {
+	text     string
+	icon     *ebiten.Image
+	closable bool
+	dirty    bool
+	disabled bool
+	movable  bool
+}
+
+// tabViewStrip is the row of the tabs.
+type tabViewStrip struct {
+	guigui.DefaultWidget
+
+	tabs           []tabViewTab
+	items          []tabViewStripItem
+	tabBounds      []image.Rectangle
+	overflowButton Button
+	overflowMenu   PopupMenu[int]
+	menuItems      []PopupMenuItem[int]
+	tmpWidths      []int
+
+	selectedIndex int
+
+	// startIndex is the first visible tab when the tabs overflow.
+	startIndex int
+	endIndex   int
+
+	pressedIndexPlus1 int
+	pressStartX       int
+	dragging          bool
+	dropIndexPlus1    int
+
+	onTabSelected       func(index int)
+	onTabCloseRequested func(index int)
+	onTabsMoved         func(from, count, to int)
+}
+
+func (t *tabViewStrip) setItems(items []tabViewStripItem) {
+	t.items = adjustSliceSize(t.items, len(items))
+	copy(t.items, items)
+	t.tabs = adjustSliceSize(t.tabs, len(items))
+}
+
+func (t *tabViewStrip) tabWidth(context *guigui.Context, index int) int {
+	tab := &t.tabs[index]
+	tab.strip = t
+	tab.index = index
+	tab.item = t.items[index]
+	return tab.defaultWidth(context)
+}
+
+// visibleTabRange returns the range of the tabs fitting in width, including the selected tab.
+// start is the first visible tab previously.
+func visibleTabRange(widths []int, width int, start int, selected int) (int, int) {
+	n := len(widths)
+	end := func(start int) int {
+		var w int
+		for i := start; i < n; i++ {
+			w += widths[i]
+			if w > width {
+				// Show at least one tab.
+				return max(i, start+1)
+			}
+		}
+		return n
+	}
+
+	start = min(max(start, 0), max(n-1, 0))
+	if selected >= 0 && selected < start {
+		start = selected
+	}
+	for start < selected && selected >= end(start) {
+		start++
+	}
+	// Fill the space after the last tab with the tabs before start.
+	for start > 0 && end(start-1) == n {
+		start--
+	}
+	return start, end(start)
+}
+
+func (t *tabViewStrip) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	context.SetFocusable(t, true)
+
+	b := context.Bounds(t)
+	u := UnitSize(context)
+
+	t.tmpWidths = adjustSliceSize(t.tmpWidths, len(t.items))
+	widths := t.tmpWidths
+	var total int
+	for i := range t.items {
+		widths[i] = t.tabWidth(context, i)
+		total += widths[i]
+	}
+	overflow := total > b.Dx()
+	width := b.Dx()
+	if overflow {
+		width -= u
+	}
+	t.startIndex, t.endIndex = visibleTabRange(widths, width, t.startIndex, t.selectedIndex)
+
+	t.tabBounds = adjustSliceSize(t.tabBounds, len(t.items))
+	x := b.Min.X
+	for i := range t.items {
+		if i < t.startIndex || i >= t.endIndex {
+			t.tabBounds[i] = image.Rectangle{}
+			continue
+		}
+		w := min(widths[i], b.Max.X-x)
+		if overflow {
+			w = min(w, b.Max.X-u-x)
+		}
+		t.tabBounds[i] = image.Rect(x, b.Min.Y, x+w, b.Max.Y)
+		tab := &t.tabs[i]
+		tab.selected = i == t.selectedIndex
+		context.SetEnabled(tab, !t.items[i].disabled)
+		appender.AppendChildWidgetWithBounds(tab, t.tabBounds[i])
+		x += w
+	}
+
+	if !overflow {
+		t.overflowMenu.Close()
+		return nil
+	}
+
+	img, err := theResourceImages.Get("keyboard_arrow_down", context.ColorMode())
+	if err != nil {
+		return err
+	}
+	t.overflowButton.SetIcon(img)
+	t.overflowButton.SetOnDown(func() {
+		t.overflowMenu.Open(context)
+	})
+	t.overflowButton.setKeepPressed(t.overflowMenu.IsOpen())
+	bb := image.Rect(b.Max.X-u, b.Min.Y+(b.Dy()-u)/2, b.Max.X, b.Min.Y+(b.Dy()+u)/2)
+	appender.AppendChildWidgetWithBounds(&t.overflowButton, bb)
+
+	t.menuItems = adjustSliceSize(t.menuItems, len(t.items))
+	for i, item := range t.items {
+		t.menuItems[i] = PopupMenuItem[int]{
+			Text:     item.text,
+			Icon:     item.icon,
+			Disabled: item.disabled,
+			Checked:  i == t.selectedIndex,
+			ID:       i,
+		}
+	}
+	t.overflowMenu.SetItems(t.menuItems)
+	t.overflowMenu.SetOnItemSelected(func(index int) {
+		if t.onTabSelected != nil {
+			t.onTabSelected(index)
+		}
+	})
+	appender.AppendChildWidgetWithPosition(&t.overflowMenu, image.Pt(bb.Max.X-t.overflowMenu.list.DefaultSize(context).X, bb.Max.Y))
+
+	return nil
+}
+
+// tabIndexAt returns the index of the visible tab at pt, or -1.
+func (t *tabViewStrip) tabIndexAt(pt image.Point) int {
+	for i := t.startIndex; i < t.endIndex && i < len(t.tabBounds); i++ {
+		if pt.In(t.tabBounds[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// dropIndexAt returns the index where the dragged tab is inserted for x.
+func (t *tabViewStrip) dropIndexAt(x int) int {
+	for i := t.startIndex; i < t.endIndex && i < len(t.tabBounds); i++ {
+		if b := t.tabBounds[i]; x < (b.Min.X+b.Max.X)/2 {
+			return i
+		}
+	}
+	return t.endIndex
+}
+
+func (t *tabViewStrip) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	pt := image.Pt(ebiten.CursorPosition())
+
+	if context.HasPointerCapture(t) {
+		index := t.pressedIndexPlus1 - 1
+		if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			context.ReleasePointer(t)
+			if t.dragging && t.dropIndexPlus1 > 0 && index >= 0 {
+				if to := t.dropIndexPlus1 - 1; to != index && to != index+1 && t.onTabsMoved != nil {
+					t.onTabsMoved(index, 1, to)
+				}
+			}
+			t.pressedIndexPlus1 = 0
+			t.dragging = false
+			t.dropIndexPlus1 = 0
+			guigui.RequestRedraw(t)
+			return guigui.HandleInputByWidget(t)
+		}
+		if index >= 0 && index < len(t.items) && t.items[index].movable && t.onTabsMoved != nil && abs(pt.X-t.pressStartX) >= UnitSize(context)/4 {
+			t.dragging = true
+		}
+		if t.dragging {
+			if i := t.dropIndexAt(pt.X); t.dropIndexPlus1 != i+1 {
+				t.dropIndexPlus1 = i + 1
+				guigui.RequestRedraw(t)
+			}
+		}
+		return guigui.HandleInputByWidget(t)
+	}
+
+	if !context.IsWidgetHitAtCursor(t) {
+		return guigui.HandleInputResult{}
+	}
+	index := t.tabIndexAt(pt)
+	if index < 0 || t.items[index].disabled {
+		return guigui.HandleInputResult{}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle) && t.items[index].closable {
+		if t.onTabCloseRequested != nil {
+			t.onTabCloseRequested(index)
+		}
+		return guigui.HandleInputByWidget(t)
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		context.SetFocused(t, true)
+		if t.onTabSelected != nil {
+			t.onTabSelected(index)
+		}
+		// Keep receiving the pointing input while dragging, even when the cursor is outside the strip.
+		context.CapturePointer(t)
+		t.pressedIndexPlus1 = index + 1
+		t.pressStartX = pt.X
+		return guigui.HandleInputByWidget(t)
+	}
+	return guigui.HandleInputResult{}
+}
+
+// HandleButtonInput selects the previous or next tab by the left and right keys.
+func (t *tabViewStrip) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsFocused(t) {
+		return guigui.HandleInputResult{}
+	}
+	var index int
+	switch {
+	case isKeyRepeating(ebiten.KeyLeft):
+		index = t.nextEnabledIndex(t.selectedIndex, -1)
+	case isKeyRepeating(ebiten.KeyRight):
+		index = t.nextEnabledIndex(t.selectedIndex, 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		index = t.nextEnabledIndex(-1, 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		index = t.nextEnabledIndex(len(t.items), -1)
+	default:
+		return guigui.HandleInputResult{}
+	}
+	if index >= 0 && t.onTabSelected != nil {
+		t.onTabSelected(index)
+	}
+	return guigui.HandleInputByWidget(t)
+}
+
+func (t *tabViewStrip) nextEnabledIndex(index int, dir int) int {
+	for i := index + dir; i >= 0 && i < len(t.items); i += dir {
+		if !t.items[i].disabled {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *tabViewStrip) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(t)
+	clr := draw.Color2(context.ColorMode(), draw.ColorTypeBase, 0.85, 0.2)
+	w := float32(1 * context.Scale())
+	vector.StrokeLine(dst, float32(b.Min.X), float32(b.Max.Y)-w/2, float32(b.Max.X), float32(b.Max.Y)-w/2, w, clr, false)
+
+	// Draw a dropping guideline.
+	if t.dropIndexPlus1 > 0 {
+		i := t.dropIndexPlus1 - 1
+		var x int
+		if i < t.endIndex && i < len(t.tabBounds) {
+			x = t.tabBounds[i].Min.X
+		} else if t.endIndex > 0 && t.endIndex-1 < len(t.tabBounds) {
+			x = t.tabBounds[t.endIndex-1].Max.X
+		}
+		lw := float32(2 * context.Scale())
+		clr := draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5)
+		vector.StrokeLine(dst, float32(x), float32(b.Min.Y), float32(x), float32(b.Max.Y), lw, clr, false)
+	}
+}
+
+type tabViewTab struct {
+	guigui.DefaultWidget
+
+	strip       *tabViewStrip
+	index       int
+	item        tabViewStripItem
+	selected    bool
+	prevHovered bool
+
+	icon        Image
+	text        Text
+	closeButton tabViewCloseButton
+}
+
+func (t *tabViewTab) hasCloseArea() bool {
+	return t.item.closable || t.item.dirty
+}
+
+func (t *tabViewTab) defaultWidth(context *guigui.Context) int {
+	t.text.SetValue(t.item.text)
+	w := 2*tabViewTabPadding(context) + t.text.TextSize(context).X
+	if t.item.icon != nil {
+		w += defaultIconSize(context) + tabViewTabGap(context)
+	}
+	if t.hasCloseArea() {
+		w += tabViewTabGap(context) + tabViewCloseButtonSize(context)
+	}
+	return min(max(w, tabViewTabMinWidth(context)), tabViewTabMaxWidth(context))
+}
+
+func (t *tabViewTab) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	b := context.Bounds(t)
+	x := b.Min.X + tabViewTabPadding(context)
+	maxX := b.Max.X - tabViewTabPadding(context)
+
+	if t.item.icon != nil {
+		s := defaultIconSize(context)
+		t.icon.SetImage(t.item.icon)
+		y := b.Min.Y + (b.Dy()-s)/2
+		appender.AppendChildWidgetWithBounds(&t.icon, image.Rect(x, y, x+s, y+s))
+		x += s + tabViewTabGap(context)
+	}
+
+	if t.hasCloseArea() {
+		s := tabViewCloseButtonSize(context)
+		t.closeButton.tab = t
+		y := b.Min.Y + (b.Dy()-s)/2
+		appender.AppendChildWidgetWithBounds(&t.closeButton, image.Rect(maxX-s, y, maxX, y+s))
+		maxX -= s + tabViewTabGap(context)
+	}
+
+	t.text.SetValue(t.item.text)
+	t.text.SetVerticalAlign(VerticalAlignMiddle)
+	if t.selected {
+		t.text.SetColor(draw.TextColor(context.ColorMode(), context.IsEnabled(t)))
+	} else {
+		t.text.SetColor(draw.ScaleAlpha(draw.TextColor(context.ColorMode(), context.IsEnabled(t)), 0.7))
+	}
+	appender.AppendChildWidgetWithBounds(&t.text, image.Rect(x, b.Min.Y, max(x, maxX), b.Max.Y))
+
+	return nil
+}
+
+func (t *tabViewTab) isHovered(context *guigui.Context) bool {
+	return context.IsEnabled(t) && context.IsWidgetHitAtCursor(t)
+}
+
+func (t *tabViewTab) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	// Only track the hover state. Pressing is handled by the strip.
+	if hovered := t.isHovered(context); t.prevHovered != hovered {
+		t.prevHovered = hovered
+		guigui.RequestRedraw(t)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (t *tabViewTab) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(t)
+	cm := context.ColorMode()
+	r := RoundedCornerRadius(context)
+	inset := int(2 * context.Scale())
+	bb := image.Rect(b.Min.X+inset, b.Min.Y+inset, b.Max.X-inset, b.Max.Y)
+
+	switch {
+	case t.selected:
+		draw.DrawRoundedRectWithSharpenCorners(context, dst, bb, draw.Color(cm, draw.ColorTypeBase, 1), r, draw.SharpenCorners{
+			LowerStart: true,
+			LowerEnd:   true,
+		})
+	case t.isHovered(context) && !t.strip.dragging:
+		draw.DrawRoundedRectWithSharpenCorners(context, dst, bb, draw.Color2(cm, draw.ColorTypeBase, 0.9, 0.15), r, draw.SharpenCorners{
+			LowerStart: true,
+			LowerEnd:   true,
+		})
+	}
+
+	if t.selected {
+		h := int(2 * context.Scale())
+		ib := image.Rect(bb.Min.X, b.Max.Y-h, bb.Max.X, b.Max.Y)
+		draw.DrawRoundedRect(context, dst, ib, draw.Color(cm, draw.ColorTypeAccent, 0.5), 0)
+		if context.IsFocusVisible(t.strip) {
+			drawFocusRing(context, dst, bb, r)
+		}
+	}
+}
+
+// tabViewCloseButton is the close button of a tab, which shows a dot instead for a dirty tab unless hovered.
+type tabViewCloseButton struct {
+	guigui.DefaultWidget
+
+	tab         *tabViewTab
+	prevHovered bool
+}
+
+func (t *tabViewCloseButton) isHovered(context *guigui.Context) bool {
+	return t.tab.item.closable && context.IsEnabled(t) && context.IsWidgetHitAtCursor(t)
+}
+
+func (t *tabViewCloseButton) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if hovered := t.isHovered(context); t.prevHovered != hovered {
+		t.prevHovered = hovered
+		guigui.RequestRedraw(t)
+	}
+	if !t.isHovered(context) {
+		return guigui.HandleInputResult{}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if f := t.tab.strip.onTabCloseRequested; f != nil {
+			f(t.tab.index)
+		}
+		return guigui.HandleInputByWidget(t)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (t *tabViewCloseButton) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	if t.isHovered(context) {
+		return ebiten.CursorShapePointer, true
+	}
+	return 0, false
+}
+
+func (t *tabViewCloseButton) Draw(context *guigui.Context, dst *ebiten.Image) {
+	b := context.Bounds(t)
+	cm := context.ColorMode()
+	hovered := t.isHovered(context)
+	tabHovered := t.tab.isHovered(context)
+
+	if t.tab.item.dirty && !tabHovered && !hovered {
+		cx := float32(b.Min.X+b.Max.X) / 2
+		cy := float32(b.Min.Y+b.Max.Y) / 2
+		vector.DrawFilledCircle(dst, cx, cy, float32(b.Dx())/6, draw.TextColor(cm, context.IsEnabled(t)), true)
+		return
+	}
+	if !t.tab.item.closable || !t.tab.selected && !tabHovered {
+		return
+	}
+
+	if hovered {
+		draw.DrawRoundedRect(context, dst, b, draw.Color2(cm, draw.ColorTypeBase, 0.8, 0.3), RoundedCornerRadius(context)/2)
+	}
+	img, err := theResourceImages.Get("close", cm)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+	s := float64(b.Dx()) * 2 / 3
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(s/float64(img.Bounds().Dx()), s/float64(img.Bounds().Dy()))
+	op.GeoM.Translate(float64(b.Min.X)+(float64(b.Dx())-s)/2, float64(b.Min.Y)+(float64(b.Dy())-s)/2)
+	op.Filter = ebiten.FilterLinear
+	dst.DrawImage(img, op)
+}
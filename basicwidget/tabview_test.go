@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func TestVisibleTabRange(t *testing.T) {
+	testCases := []struct {
+		Widths    []int
+		Width     int
+		Start     int
+		Selected  int
+		WantStart int
+		WantEnd   int
+	}{
+		{Widths: nil, Width: 100, Start: 0, Selected: -1, WantStart: 0, WantEnd: 0},
+		{Widths: []int{10, 10, 10}, Width: 100, Start: 0, Selected: 2, WantStart: 0, WantEnd: 3},
+		{Widths: []int{40, 40, 40, 40}, Width: 100, Start: 0, Selected: 0, WantStart: 0, WantEnd: 2},
+		// The selected tab is scrolled into the view.
+		{Widths: []int{40, 40, 40, 40}, Width: 100, Start: 0, Selected: 3, WantStart: 2, WantEnd: 4},
+		{Widths: []int{40, 40, 40, 40}, Width: 100, Start: 2, Selected: 1, WantStart: 1, WantEnd: 3},
+		// The previous start is kept while the selected tab is visible.
+		{Widths: []int{40, 40, 40, 40, 40}, Width: 100, Start: 1, Selected: 2, WantStart: 1, WantEnd: 3},
+		// The space after the last tab is filled.
+		{Widths: []int{40, 40, 40, 40}, Width: 100, Start: 3, Selected: 3, WantStart: 2, WantEnd: 4},
+		// A tab wider than the strip is still shown.
+		{Widths: []int{40, 200, 40}, Width: 100, Start: 0, Selected: 1, WantStart: 1, WantEnd: 2},
+	}
+	for _, tc := range testCases {
+		start, end := basicwidget.VisibleTabRange(tc.Widths, tc.Width, tc.Start, tc.Selected)
+		if start != tc.WantStart || end != tc.WantEnd {
+			t.Errorf("VisibleTabRange(%v, %d, %d, %d): got: [%d, %d), want: [%d, %d)", tc.Widths, tc.Width, tc.Start, tc.Selected, start, end, tc.WantStart, tc.WantEnd)
+		}
+	}
+}
+
+func TestTabViewSelection(t *testing.T) {
+	var tv basicwidget.TabView[string]
+	items := []basicwidget.TabViewItem[string]{
+		{Text: "A", ID: "a", Disabled: true},
+		{Text: "B", ID: "b"},
+		{Text: "C", ID: "c"},
+		{Text: "D", ID: "d"},
+	}
+
+	// The first enabled tab is selected by default.
+	tv.SetItems(items)
+	if got, want := tv.SelectedItemIndex(), 1; got != want {
+		t.Errorf("SelectedItemIndex: got: %d, want: %d", got, want)
+	}
+
+	// The selection follows the ID when the tabs are reordered.
+	tv.SelectItemByID("c")
+	basicwidget.MoveItemsInSlice(items, 2, 1, 0)
+	tv.SetItems(items)
+	if item, _ := tv.SelectedItem(); item.ID != "c" {
+		t.Errorf("SelectedItem after moving: got: %q, want: %q", item.ID, "c")
+	}
+	if got, want := tv.SelectedItemIndex(), 0; got != want {
+		t.Errorf("SelectedItemIndex after moving: got: %d, want: %d", got, want)
+	}
+
+	// The neighbor is selected when the selected tab is removed.
+	tv.SelectItemByID("b")
+	items = []basicwidget.TabViewItem[string]{
+		{Text: "C", ID: "c"},
+		{Text: "A", ID: "a", Disabled: true},
+		{Text: "D", ID: "d"},
+	}
+	tv.SetItems(items)
+	if item, _ := tv.SelectedItem(); item.ID != "d" {
+		t.Errorf("SelectedItem after removing: got: %q, want: %q", item.ID, "d")
+	}
+
+	// The previous enabled tab is selected when the last tab is removed.
+	tv.SetItems(items[:2])
+	if item, _ := tv.SelectedItem(); item.ID != "c" {
+		t.Errorf("SelectedItem after removing the last: got: %q, want: %q", item.ID, "c")
+	}
+
+	tv.SetItems(nil)
+	if got, want := tv.SelectedItemIndex(), -1; got != want {
+		t.Errorf("SelectedItemIndex after removing all: got: %d, want: %d", got, want)
+	}
+}
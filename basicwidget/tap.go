@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/gesture"
+)
+
+// tapRecognizer recognizes taps on a widget so that the widget can be pressed by a touch as well as the mouse.
+//
+// Pans are not handled by tapRecognizer, so that the touches can scroll the ancestor scroll overlays.
+type tapRecognizer struct {
+	recognizer gesture.Recognizer
+
+	tapped    bool
+	doubleTap bool
+	position  image.Point
+}
+
+// handleTouchInput returns the position of a tap on widget, and whether the tap is the second tap of a double-tap.
+// handleTouchInput should be called at every HandlePointingInput of widget.
+func (t *tapRecognizer) handleTouchInput(context *guigui.Context, widget guigui.Widget) (position image.Point, doubleTap bool, ok bool) {
+	t.recognizer.SetOnTap(func(position image.Point) {
+		t.tapped = true
+		t.doubleTap = false
+		t.position = position
+	})
+	t.recognizer.SetOnDoubleTap(func(position image.Point) {
+		t.tapped = true
+		t.doubleTap = true
+		t.position = position
+	})
+	t.tapped = false
+	t.recognizer.HandleTouchInput(context, widget)
+	if !t.tapped {
+		return image.Point{}, false, false
+	}
+	// The widget might have been moved or hidden while being touched.
+	if !t.position.In(context.VisibleBounds(widget)) {
+		return image.Point{}, false, false
+	}
+	return t.position, t.doubleTap, true
+}
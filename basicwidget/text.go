@@ -23,7 +23,8 @@ import (
 	"github.com/hajimehoshi/guigui"
 	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
 	"github.com/hajimehoshi/guigui/basicwidget/internal/textutil"
-	"github.com/hajimehoshi/guigui/internal/clipboard"
+	"github.com/hajimehoshi/guigui/clipboard"
+	"github.com/hajimehoshi/guigui/internal/inputrepeat"
 )
 
 type HorizontalAlign int
@@ -43,22 +44,11 @@ const (
 )
 
 func isMouseButtonRepeating(button ebiten.MouseButton) bool {
-	return repeat(inpututil.MouseButtonPressDuration(button))
+	return inputrepeat.IsMouseButtonRepeating(button)
 }
 
 func isKeyRepeating(key ebiten.Key) bool {
-	return repeat(inpututil.KeyPressDuration(key))
-}
-
-func repeat(duration int) bool {
-	if duration == 1 {
-		return true
-	}
-	delay := ebiten.TPS() * 24 / 60
-	if duration < delay {
-		return false
-	}
-	return (duration-delay)%4 == 0
+	return inputrepeat.IsKeyRepeating(key)
 }
 
 func findWordBoundaries(text string, idx int) (start, end int) {
@@ -119,13 +109,26 @@ type Text struct {
 	dragging    bool
 	prevFocused bool
 
+	// highlightStart and highlightEnd are the range highlighted regardless of the selection, e.g. a matched text.
+	highlightStart int
+	highlightEnd   int
+
+	// keyHandler handles key inputs before the text does while editing, and reports whether the key inputs are handled.
+	keyHandler func(context *guigui.Context) bool
+
 	clickCount         int
 	lastClickTick      int64
 	lastClickTextIndex int
 
 	cursor textCursor
 
-	tmpClipboard string
+	// killBuffer is the text killed by Ctrl+K in the Emacs keybindings.
+	// Like macOS, the kill buffer is separated from the system clipboard.
+	killBuffer string
+
+	// undoManager is created lazily with the maximum depth. Use ensureUndoManager.
+	undoManager   *guigui.UndoManager
+	editMergeable bool
 
 	cachedTextSize map[textSizeCacheKey]image.Point
 	lastFace       text.Face
@@ -161,6 +164,8 @@ func (t *Text) resetAutoWrapCachedTextSize() {
 }
 
 func (t *Text) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	context.SetFocusable(t, t.editable)
+
 	if f := t.face(context, false); t.lastFace != f {
 		t.lastFace = f
 		t.resetCachedTextSize()
@@ -243,6 +248,11 @@ func (t *Text) ForceSetValue(text string) {
 }
 
 func (t *Text) setText(text string) {
+	// The edit history no longer matches the text set programmatically.
+	if t.undoManager != nil {
+		t.undoManager.Clear()
+	}
+
 	start, end := t.field.Selection()
 	start = min(start, len(text))
 	end = min(end, len(text))
@@ -251,6 +261,16 @@ func (t *Text) setText(text string) {
 	t.nextTextSet = false
 }
 
+// setHighlight sets the range of the text highlighted while the text is not focused.
+func (t *Text) setHighlight(start, end int) {
+	if t.highlightStart == start && t.highlightEnd == end {
+		return
+	}
+	t.highlightStart = start
+	t.highlightEnd = end
+	guigui.RequestRedraw(t)
+}
+
 func (t *Text) selectAll() {
 	t.setTextAndSelection(t.field.Text(), 0, len(t.field.Text()), -1)
 }
@@ -595,6 +615,23 @@ func (t *Text) compositionSelectionToDraw(context *guigui.Context) (uStart, cSta
 }
 
 func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	if !t.editable {
+		return t.handleButtonInput(context)
+	}
+
+	if r, ok := t.handleUndoInput(); ok {
+		return r
+	}
+
+	origText := t.field.Text()
+	origStart, origEnd := t.field.Selection()
+	t.editMergeable = false
+	r := t.handleButtonInput(context)
+	t.pushEditIfChanged(origText, origStart, origEnd, t.editMergeable)
+	return r
+}
+
+func (t *Text) handleButtonInput(context *guigui.Context) guigui.HandleInputResult {
 	if !t.selectable && !t.editable {
 		return guigui.HandleInputResult{}
 	}
@@ -612,6 +649,7 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 			}
 		}
 		if processed {
+			t.editMergeable = true
 			guigui.RequestRedraw(t)
 			// Reset the cache size before adjust the scroll offset in order to get the correct text size.
 			t.resetCachedTextSize()
@@ -628,6 +666,10 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 			return guigui.HandleInputByWidget(t)
 		}
 
+		if t.keyHandler != nil && t.keyHandler(context) {
+			return guigui.HandleInputByWidget(t)
+		}
+
 		// For Windows key binds, see:
 		// https://support.microsoft.com/en-us/windows/keyboard-shortcuts-in-windows-dcc61a57-8ff0-cffe-9796-cb9706c75eec#textediting
 
@@ -648,6 +690,7 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 			return guigui.HandleInputByWidget(t)
 		case isKeyRepeating(ebiten.KeyBackspace) ||
 			useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyH):
+			t.editMergeable = true
 			start, end := t.field.Selection()
 			if start != end {
 				text := t.field.Text()[:start] + t.field.Text()[end:]
@@ -670,6 +713,7 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 			}
 			return guigui.HandleInputByWidget(t)
 		case isKeyRepeating(ebiten.KeyDelete):
+			t.editMergeable = true
 			// Delete one cluster
 			if _, end := t.field.Selection(); end < len(t.field.Text()) {
 				text, pos := textutil.DeleteOnGraphemes(t.field.Text(), end)
@@ -678,28 +722,17 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 			return guigui.HandleInputByWidget(t)
 		case !useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyX) ||
 			useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyMeta) && isKeyRepeating(ebiten.KeyX):
-			// Cut
-			start, end := t.field.Selection()
-			if start != end {
-				if err := clipboard.WriteAll(t.field.Text()[start:end]); err != nil {
-					slog.Error(err.Error())
-					return guigui.AbortHandlingInputByWidget(t)
-				}
-				text := t.field.Text()[:start] + t.field.Text()[end:]
-				t.setTextAndSelection(text, start, start, -1)
+			if err := t.cut(); err != nil {
+				slog.Error(err.Error())
+				return guigui.AbortHandlingInputByWidget(t)
 			}
 			return guigui.HandleInputByWidget(t)
 		case !useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyV) ||
 			useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyMeta) && isKeyRepeating(ebiten.KeyV):
-			// Paste
-			start, end := t.field.Selection()
-			ct, err := clipboard.ReadAll()
-			if err != nil {
+			if err := t.paste(); err != nil {
 				slog.Error(err.Error())
 				return guigui.AbortHandlingInputByWidget(t)
 			}
-			text := t.field.Text()[:start] + ct + t.field.Text()[end:]
-			t.setTextAndSelection(text, start+len(ct), start+len(ct), -1)
 			return guigui.HandleInputByWidget(t)
 		}
 	}
@@ -825,13 +858,9 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 		return guigui.HandleInputByWidget(t)
 	case !useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyC) ||
 		useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyMeta) && isKeyRepeating(ebiten.KeyC):
-		// Copy
-		start, end := t.field.Selection()
-		if start != end {
-			if err := clipboard.WriteAll(t.field.Text()[start:end]); err != nil {
-				slog.Error(err.Error())
-				return guigui.AbortHandlingInputByWidget(t)
-			}
+		if err := t.copy(); err != nil {
+			slog.Error(err.Error())
+			return guigui.AbortHandlingInputByWidget(t)
 		}
 		return guigui.HandleInputByWidget(t)
 	case useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyK):
@@ -847,16 +876,16 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 				end += start
 			}
 		}
-		t.tmpClipboard = t.field.Text()[start:end]
+		t.killBuffer = t.field.Text()[start:end]
 		text := t.field.Text()[:start] + t.field.Text()[end:]
 		t.setTextAndSelection(text, start, start, -1)
 		return guigui.HandleInputByWidget(t)
 	case useEmacsKeybind() && ebiten.IsKeyPressed(ebiten.KeyControl) && isKeyRepeating(ebiten.KeyY):
 		// 'Yank' the killed text.
-		if t.tmpClipboard != "" {
+		if t.killBuffer != "" {
 			start, _ := t.field.Selection()
-			text := t.field.Text()[:start] + t.tmpClipboard + t.field.Text()[start:]
-			t.setTextAndSelection(text, start+len(t.tmpClipboard), start+len(t.tmpClipboard), -1)
+			text := t.field.Text()[:start] + t.killBuffer + t.field.Text()[start:]
+			t.setTextAndSelection(text, start+len(t.killBuffer), start+len(t.killBuffer), -1)
 		}
 		return guigui.HandleInputByWidget(t)
 	}
@@ -864,6 +893,43 @@ func (t *Text) HandleButtonInput(context *guigui.Context) guigui.HandleInputResu
 	return guigui.HandleInputResult{}
 }
 
+func (t *Text) hasSelection() bool {
+	start, end := t.field.Selection()
+	return start != end
+}
+
+func (t *Text) cut() error {
+	start, end := t.field.Selection()
+	if start == end {
+		return nil
+	}
+	if err := clipboard.WriteText(t.field.Text()[start:end]); err != nil {
+		return err
+	}
+	text := t.field.Text()[:start] + t.field.Text()[end:]
+	t.setTextAndSelection(text, start, start, -1)
+	return nil
+}
+
+func (t *Text) copy() error {
+	start, end := t.field.Selection()
+	if start == end {
+		return nil
+	}
+	return clipboard.WriteText(t.field.Text()[start:end])
+}
+
+func (t *Text) paste() error {
+	start, end := t.field.Selection()
+	ct, err := clipboard.ReadText()
+	if err != nil {
+		return err
+	}
+	text := t.field.Text()[:start] + ct + t.field.Text()[end:]
+	t.setTextAndSelection(text, start+len(ct), start+len(ct), -1)
+	return nil
+}
+
 func (t *Text) commit() {
 	if t.onValueChanged != nil {
 		t.onValueChanged(t.field.Text(), true)
@@ -909,6 +975,12 @@ func (t *Text) Draw(context *guigui.Context, dst *ebiten.Image) {
 			op.DrawSelection = false
 		}
 	}
+	if !op.DrawSelection && t.highlightStart < t.highlightEnd {
+		op.DrawSelection = true
+		op.SelectionStart = t.highlightStart
+		op.SelectionEnd = t.highlightEnd
+		op.SelectionColor = draw.ScaleAlpha(draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.8), 0.5)
+	}
 	if uStart, cStart, cEnd, uEnd, ok := t.compositionSelectionToDraw(context); ok {
 		op.DrawComposition = true
 		op.CompositionStart = uStart
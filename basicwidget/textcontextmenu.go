@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"log/slog"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// commandShortcutText returns a hint text of a shortcut with the platform's command modifier key.
+func commandShortcutText(key string) string {
+	if useEmacsKeybind() {
+		return "Cmd+" + key
+	}
+	return "Ctrl+" + key
+}
+
+func redoShortcutText() string {
+	if useEmacsKeybind() {
+		return "Shift+Cmd+Z"
+	}
+	return "Ctrl+Y"
+}
+
+// AppendContextMenuItems appends the default items of a text: Undo, Redo, Cut, Copy, Paste and Select All.
+// No items are appended if the text is neither selectable nor editable.
+//
+// AppendContextMenuItems implements ContextMenuProvider.
+func (t *Text) AppendContextMenuItems(context *guigui.Context, items []ContextMenuItem) []ContextMenuItem {
+	if !t.selectable && !t.editable {
+		return items
+	}
+
+	if t.editable {
+		items = append(items,
+			ContextMenuItem{
+				Text:       "Undo",
+				Shortcut:   commandShortcutText("Z"),
+				Disabled:   !t.CanUndo(),
+				OnSelected: t.Undo,
+			},
+			ContextMenuItem{
+				Text:       "Redo",
+				Shortcut:   redoShortcutText(),
+				Disabled:   !t.CanRedo(),
+				OnSelected: t.Redo,
+			},
+			ContextMenuItem{
+				Border: true,
+			},
+			ContextMenuItem{
+				Text:     "Cut",
+				Shortcut: commandShortcutText("X"),
+				Disabled: !t.hasSelection(),
+				OnSelected: func() {
+					if err := t.editWithUndo(t.cut); err != nil {
+						slog.Error(err.Error())
+					}
+				},
+			})
+	}
+	items = append(items, ContextMenuItem{
+		Text:     "Copy",
+		Shortcut: commandShortcutText("C"),
+		Disabled: !t.hasSelection(),
+		OnSelected: func() {
+			if err := t.copy(); err != nil {
+				slog.Error(err.Error())
+			}
+		},
+	})
+	if t.editable {
+		items = append(items, ContextMenuItem{
+			Text:     "Paste",
+			Shortcut: commandShortcutText("V"),
+			OnSelected: func() {
+				if err := t.editWithUndo(t.paste); err != nil {
+					slog.Error(err.Error())
+				}
+			},
+		})
+	}
+	items = append(items,
+		ContextMenuItem{
+			Border: true,
+		},
+		ContextMenuItem{
+			Text:       "Select All",
+			Shortcut:   commandShortcutText("A"),
+			OnSelected: t.selectAll,
+		})
+	return items
+}
+
+func (t *Text) contextMenuPosition(context *guigui.Context) image.Point {
+	if t.editable {
+		if b := t.cursorBounds(context); !b.Empty() {
+			return image.Pt(b.Min.X, b.Max.Y)
+		}
+	}
+	b := context.VisibleBounds(t)
+	return image.Pt(b.Min.X, b.Max.Y)
+}
+
+// AppendContextMenuItems implements ContextMenuProvider.
+func (t *TextInput) AppendContextMenuItems(context *guigui.Context, items []ContextMenuItem) []ContextMenuItem {
+	return t.text.AppendContextMenuItems(context, items)
+}
+
+func (t *TextInput) contextMenuPosition(context *guigui.Context) image.Point {
+	return t.text.contextMenuPosition(context)
+}
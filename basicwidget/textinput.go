@@ -40,7 +40,10 @@ type TextInput struct {
 	prevStart   int
 	prevEnd     int
 
+	onValueChanged            func(text string, committed bool)
 	onTextAndSelectionChanged func(text string, start, end int)
+
+	valueBinding binding[string]
 }
 
 func (t *TextInput) SetOnEnterPressed(f func(text string)) {
@@ -48,13 +51,34 @@ func (t *TextInput) SetOnEnterPressed(f func(text string)) {
 }
 
 func (t *TextInput) SetOnValueChanged(f func(text string, committed bool)) {
-	t.text.SetOnValueChanged(f)
+	t.onValueChanged = f
+	t.text.SetOnValueChanged(t.textValueChanged)
+}
+
+// BindValue binds the text input's value to value in both directions.
+// value is updated whenever the text is edited.
+func (t *TextInput) BindValue(value *guigui.Observable[string]) {
+	t.valueBinding.bind(value)
+	t.text.SetOnValueChanged(t.textValueChanged)
+}
+
+func (t *TextInput) textValueChanged(text string, committed bool) {
+	t.valueBinding.push(text)
+	if t.onValueChanged != nil {
+		t.onValueChanged(text, committed)
+	}
 }
 
 func (t *TextInput) SetOnTextAndSelectionChanged(f func(text string, start, end int)) {
 	t.onTextAndSelectionChanged = f
 }
 
+// setKeyHandler sets the function to handle key inputs before the text input does while editing.
+// The function reports whether the key inputs are handled.
+func (t *TextInput) setKeyHandler(f func(context *guigui.Context) bool) {
+	t.text.keyHandler = f
+}
+
 func (t *TextInput) Value() string {
 	return t.text.Value()
 }
@@ -67,6 +91,22 @@ func (t *TextInput) ForceSetValue(text string) {
 	t.text.ForceSetValue(text)
 }
 
+func (t *TextInput) CanUndo() bool {
+	return t.text.CanUndo()
+}
+
+func (t *TextInput) CanRedo() bool {
+	return t.text.CanRedo()
+}
+
+func (t *TextInput) Undo() {
+	t.text.Undo()
+}
+
+func (t *TextInput) Redo() {
+	t.text.Redo()
+}
+
 func (t *TextInput) SetMultiline(multiline bool) {
 	t.text.SetMultiline(multiline)
 }
@@ -157,6 +197,10 @@ func (t *TextInput) scrollContentSize(context *guigui.Context) image.Point {
 }
 
 func (t *TextInput) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	if value, ok := t.valueBinding.pull(context); ok {
+		t.text.SetValue(value)
+	}
+
 	if t.prevFocused != (context.IsFocused(t) || context.IsFocused(&t.text)) {
 		t.prevFocused = (context.IsFocused(t) || context.IsFocused(&t.text))
 		guigui.RequestRedraw(t)
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+const textUndoMaxDepth = 100
+
+type textEdit struct {
+	text *Text
+
+	oldText  string
+	oldStart int
+	oldEnd   int
+	newText  string
+	newStart int
+	newEnd   int
+
+	// mergeable reports whether the edit is typing or deleting, which can be merged with the next edit.
+	mergeable bool
+}
+
+func (t *textEdit) Do() {
+	t.text.setTextAndSelection(t.newText, t.newStart, t.newEnd, -1)
+}
+
+func (t *textEdit) Undo() {
+	t.text.setTextAndSelection(t.oldText, t.oldStart, t.oldEnd, -1)
+}
+
+func (t *textEdit) MergeUndoCommand(next guigui.UndoCommand) bool {
+	n, ok := next.(*textEdit)
+	if !ok {
+		return false
+	}
+	if n.text != t.text || !t.mergeable || !n.mergeable {
+		return false
+	}
+	// Merge only continuous edits.
+	if t.newText != n.oldText || t.newStart != n.oldStart || t.newEnd != n.oldEnd {
+		return false
+	}
+	t.newText = n.newText
+	t.newStart = n.newStart
+	t.newEnd = n.newEnd
+	return true
+}
+
+func (t *Text) ensureUndoManager() *guigui.UndoManager {
+	if t.undoManager == nil {
+		t.undoManager = &guigui.UndoManager{}
+		t.undoManager.SetMaxDepth(textUndoMaxDepth)
+	}
+	return t.undoManager
+}
+
+// pushEditIfChanged records the edit from the old state to the current state if the text has changed.
+func (t *Text) pushEditIfChanged(oldText string, oldStart, oldEnd int, mergeable bool) {
+	text := t.field.Text()
+	if text == oldText {
+		return
+	}
+	start, end := t.field.Selection()
+	t.ensureUndoManager().Push(&textEdit{
+		text:      t,
+		oldText:   oldText,
+		oldStart:  oldStart,
+		oldEnd:    oldEnd,
+		newText:   text,
+		newStart:  start,
+		newEnd:    end,
+		mergeable: mergeable,
+	})
+}
+
+// editWithUndo calls f, which edits the text, and records the edit.
+func (t *Text) editWithUndo(f func() error) error {
+	oldText := t.field.Text()
+	oldStart, oldEnd := t.field.Selection()
+	err := f()
+	t.pushEditIfChanged(oldText, oldStart, oldEnd, false)
+	return err
+}
+
+type textUndoAction int
+
+const (
+	textUndoActionNone textUndoAction = iota
+	textUndoActionUndo
+	textUndoActionRedo
+)
+
+// textUndoActionByKeys returns the action of the shortcut keys.
+// pressed reports whether a key is pressed, and repeating reports whether a key is just pressed or repeating.
+func textUndoActionByKeys(pressed, repeating func(key ebiten.Key) bool, emacs bool) textUndoAction {
+	shift := pressed(ebiten.KeyShift)
+	if emacs {
+		if !pressed(ebiten.KeyMeta) || !repeating(ebiten.KeyZ) {
+			return textUndoActionNone
+		}
+		if shift {
+			return textUndoActionRedo
+		}
+		return textUndoActionUndo
+	}
+	if !pressed(ebiten.KeyControl) {
+		return textUndoActionNone
+	}
+	switch {
+	case repeating(ebiten.KeyZ) && shift:
+		return textUndoActionRedo
+	case repeating(ebiten.KeyZ):
+		return textUndoActionUndo
+	case repeating(ebiten.KeyY):
+		return textUndoActionRedo
+	}
+	return textUndoActionNone
+}
+
+func (t *Text) handleUndoInput() (guigui.HandleInputResult, bool) {
+	// Do not accept key inputs when compositing.
+	if _, _, ok := t.field.CompositionSelection(); ok {
+		return guigui.HandleInputResult{}, false
+	}
+	switch textUndoActionByKeys(ebiten.IsKeyPressed, isKeyRepeating, useEmacsKeybind()) {
+	case textUndoActionUndo:
+		t.ensureUndoManager().Undo()
+		return guigui.HandleInputByWidget(t), true
+	case textUndoActionRedo:
+		t.ensureUndoManager().Redo()
+		return guigui.HandleInputByWidget(t), true
+	}
+	return guigui.HandleInputResult{}, false
+}
+
+// CanUndo reports whether the text has an edit to undo.
+func (t *Text) CanUndo() bool {
+	return t.ensureUndoManager().CanUndo()
+}
+
+// CanRedo reports whether the text has an edit to redo.
+func (t *Text) CanRedo() bool {
+	return t.ensureUndoManager().CanRedo()
+}
+
+func (t *Text) Undo() {
+	t.ensureUndoManager().Undo()
+}
+
+func (t *Text) Redo() {
+	t.ensureUndoManager().Redo()
+}
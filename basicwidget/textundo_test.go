@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestTextUndoActionByKeys(t *testing.T) {
+	testCases := []struct {
+		pressed   []ebiten.Key
+		repeating []ebiten.Key
+		emacs     bool
+		want      textUndoAction
+	}{
+		{pressed: []ebiten.Key{ebiten.KeyControl}, repeating: []ebiten.Key{ebiten.KeyZ}, want: textUndoActionUndo},
+		{pressed: []ebiten.Key{ebiten.KeyControl, ebiten.KeyShift}, repeating: []ebiten.Key{ebiten.KeyZ}, want: textUndoActionRedo},
+		{pressed: []ebiten.Key{ebiten.KeyControl}, repeating: []ebiten.Key{ebiten.KeyY}, want: textUndoActionRedo},
+		{pressed: []ebiten.Key{ebiten.KeyControl}, want: textUndoActionNone},
+		{repeating: []ebiten.Key{ebiten.KeyZ}, want: textUndoActionNone},
+		{pressed: []ebiten.Key{ebiten.KeyMeta}, repeating: []ebiten.Key{ebiten.KeyZ}, want: textUndoActionNone},
+		{pressed: []ebiten.Key{ebiten.KeyMeta}, repeating: []ebiten.Key{ebiten.KeyZ}, emacs: true, want: textUndoActionUndo},
+		{pressed: []ebiten.Key{ebiten.KeyMeta, ebiten.KeyShift}, repeating: []ebiten.Key{ebiten.KeyZ}, emacs: true, want: textUndoActionRedo},
+		{pressed: []ebiten.Key{ebiten.KeyControl}, repeating: []ebiten.Key{ebiten.KeyZ}, emacs: true, want: textUndoActionNone},
+		{pressed: []ebiten.Key{ebiten.KeyMeta}, repeating: []ebiten.Key{ebiten.KeyY}, emacs: true, want: textUndoActionNone},
+	}
+	for _, tc := range testCases {
+		pressed := func(key ebiten.Key) bool {
+			return slices.Contains(tc.pressed, key)
+		}
+		repeating := func(key ebiten.Key) bool {
+			return slices.Contains(tc.repeating, key)
+		}
+		if got := textUndoActionByKeys(pressed, repeating, tc.emacs); got != tc.want {
+			t.Errorf("textUndoActionByKeys(%v, %v, %t): got: %d, want: %d", tc.pressed, tc.repeating, tc.emacs, got, tc.want)
+		}
+	}
+}
+
+// typeText replaces the selection with str and records the edit as typing does.
+func typeText(text *Text, str string) {
+	oldText := text.field.Text()
+	start, end := text.field.Selection()
+	text.setTextAndSelection(oldText[:start]+str+oldText[end:], start+len(str), start+len(str), -1)
+	text.pushEditIfChanged(oldText, start, end, true)
+}
+
+func TestTextUndoMergesTyping(t *testing.T) {
+	var text Text
+	text.SetEditable(true)
+	typeText(&text, "a")
+	typeText(&text, "b")
+	typeText(&text, "c")
+
+	// A non-typing edit is not merged.
+	oldText := text.field.Text()
+	text.setTextAndSelection("abc!", 4, 4, -1)
+	text.pushEditIfChanged(oldText, 3, 3, false)
+	typeText(&text, "d")
+
+	for _, want := range []string{"abc!", "abc", ""} {
+		text.Undo()
+		if got := text.field.Text(); got != want {
+			t.Errorf("after Undo: got: %q, want: %q", got, want)
+		}
+	}
+	if text.CanUndo() {
+		t.Errorf("CanUndo: got: true, want: false")
+	}
+
+	text.Redo()
+	if got, want := text.field.Text(), "abc"; got != want {
+		t.Errorf("after Redo: got: %q, want: %q", got, want)
+	}
+	if start, end := text.field.Selection(); start != 3 || end != 3 {
+		t.Errorf("selection after Redo: got: (%d, %d), want: (3, 3)", start, end)
+	}
+}
+
+func TestTextUndoMaxDepth(t *testing.T) {
+	var text Text
+	text.SetEditable(true)
+	for range textUndoMaxDepth + 10 {
+		typeText(&text, "a")
+		// Break the merge so that every typing is an entry.
+		text.ensureUndoManager().BreakMerge()
+	}
+	var count int
+	for text.CanUndo() {
+		text.Undo()
+		count++
+	}
+	if got, want := count, textUndoMaxDepth; got != want {
+		t.Errorf("undone entries: got: %d, want: %d", got, want)
+	}
+}
+
+func TestTextSetValueClearsUndoHistory(t *testing.T) {
+	var text Text
+	text.SetEditable(true)
+	typeText(&text, "a")
+	if !text.CanUndo() {
+		t.Fatalf("CanUndo after typing: got: false, want: true")
+	}
+	text.ForceSetValue("b")
+	if text.CanUndo() {
+		t.Errorf("CanUndo after ForceSetValue: got: true, want: false")
+	}
+	text.Undo()
+	if got, want := text.field.Text(), "b"; got != want {
+		t.Errorf("Undo after ForceSetValue: got: %q, want: %q", got, want)
+	}
+}
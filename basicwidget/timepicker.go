@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+
+	"golang.org/x/text/language"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// TimePicker is a widget to input a time of a day with hours, minutes and optionally seconds.
+//
+// The 12-hour clock with AM and PM is used when the locale prefers it.
+type TimePicker struct {
+	guigui.DefaultWidget
+
+	hourInput   NumberInput
+	minuteInput NumberInput
+	secondInput NumberInput
+	separators  [2]Text
+	amPMControl SegmentedControl[int]
+	amPMItems   [2]SegmentedControlItem[int]
+
+	hour   int
+	minute int
+	second int
+
+	twelveHour  bool
+	showSeconds bool
+
+	tmpLocales []language.Tag
+
+	onValueChanged func(hour, minute, second int)
+}
+
+// SetOnValueChanged sets the function called when the time is changed by the user.
+// hour is from 0 to 23 regardless of the clock of the locale.
+func (t *TimePicker) SetOnValueChanged(f func(hour, minute, second int)) {
+	t.onValueChanged = f
+}
+
+// Hour returns the hour from 0 to 23.
+func (t *TimePicker) Hour() int {
+	return t.hour
+}
+
+func (t *TimePicker) Minute() int {
+	return t.minute
+}
+
+func (t *TimePicker) Second() int {
+	return t.second
+}
+
+// SetTime sets the time. Out-of-range values are clamped.
+func (t *TimePicker) SetTime(hour, minute, second int) {
+	hour = min(max(hour, 0), 23)
+	minute = min(max(minute, 0), 59)
+	second = min(max(second, 0), 59)
+	if t.hour == hour && t.minute == minute && t.second == second {
+		return
+	}
+	t.hour = hour
+	t.minute = minute
+	t.second = second
+	guigui.RequestRedraw(t)
+}
+
+// SetSecondsVisible sets whether the seconds can be input.
+func (t *TimePicker) SetSecondsVisible(visible bool) {
+	if t.showSeconds == visible {
+		return
+	}
+	t.showSeconds = visible
+	guigui.RequestRedraw(t)
+}
+
+func (t *TimePicker) setTimeByUser(hour, minute, second int) {
+	if t.hour == hour && t.minute == minute && t.second == second {
+		return
+	}
+	t.SetTime(hour, minute, second)
+	if t.onValueChanged != nil {
+		t.onValueChanged(t.hour, t.minute, t.second)
+	}
+}
+
+// displayedHour returns the hour shown in the hour input.
+func (t *TimePicker) displayedHour() int {
+	if !t.twelveHour {
+		return t.hour
+	}
+	if h := t.hour % 12; h != 0 {
+		return h
+	}
+	return 12
+}
+
+func (t *TimePicker) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	var l calendarLocaleInfo
+	l, t.tmpLocales = calendarLocaleForContext(context, t.tmpLocales)
+	t.twelveHour = l.twelveHour
+
+	// Updating the ranges and the values fires the callbacks. Unset them not to change the time unexpectedly.
+	t.hourInput.SetOnValueChangedInt64(nil)
+	t.minuteInput.SetOnValueChangedInt64(nil)
+	t.secondInput.SetOnValueChangedInt64(nil)
+	t.amPMControl.SetOnItemSelected(nil)
+
+	if t.twelveHour {
+		t.hourInput.SetMinimumValueInt64(1)
+		t.hourInput.SetMaximumValueInt64(12)
+	} else {
+		t.hourInput.SetMinimumValueInt64(0)
+		t.hourInput.SetMaximumValueInt64(23)
+	}
+	t.hourInput.SetValueInt64(int64(t.displayedHour()))
+	for _, n := range []*NumberInput{&t.minuteInput, &t.secondInput} {
+		n.SetMinimumValueInt64(0)
+		n.SetMaximumValueInt64(59)
+	}
+	t.minuteInput.SetValueInt64(int64(t.minute))
+	t.secondInput.SetValueInt64(int64(t.second))
+
+	t.amPMItems = [2]SegmentedControlItem[int]{
+		{Text: l.amPM[0], ID: 0},
+		{Text: l.amPM[1], ID: 1},
+	}
+	t.amPMControl.SetItems(t.amPMItems[:])
+	t.amPMControl.SelectItemByIndex(t.hour / 12)
+
+	t.hourInput.SetOnValueChangedInt64(func(value int64) {
+		h := int(value)
+		if t.twelveHour {
+			h = h%12 + t.hour/12*12
+		}
+		t.setTimeByUser(h, t.minute, t.second)
+	})
+	t.minuteInput.SetOnValueChangedInt64(func(value int64) {
+		t.setTimeByUser(t.hour, int(value), t.second)
+	})
+	t.secondInput.SetOnValueChangedInt64(func(value int64) {
+		t.setTimeByUser(t.hour, t.minute, int(value))
+	})
+	t.amPMControl.SetOnItemSelected(func(index int) {
+		t.setTimeByUser(t.hour%12+index*12, t.minute, t.second)
+	})
+
+	u := UnitSize(context)
+	b := context.Bounds(t)
+	inputWidth := timePickerInputWidth(context)
+	sepWidth := u / 2
+
+	x := b.Min.X
+	appender.AppendChildWidgetWithBounds(&t.hourInput, image.Rect(x, b.Min.Y, x+inputWidth, b.Max.Y))
+	x += inputWidth
+	inputs := []*NumberInput{&t.minuteInput}
+	if t.showSeconds {
+		inputs = append(inputs, &t.secondInput)
+	}
+	for i, n := range inputs {
+		t.separators[i].SetValue(":")
+		t.separators[i].SetHorizontalAlign(HorizontalAlignCenter)
+		t.separators[i].SetVerticalAlign(VerticalAlignMiddle)
+		appender.AppendChildWidgetWithBounds(&t.separators[i], image.Rect(x, b.Min.Y, x+sepWidth, b.Max.Y))
+		x += sepWidth
+		appender.AppendChildWidgetWithBounds(n, image.Rect(x, b.Min.Y, x+inputWidth, b.Max.Y))
+		x += inputWidth
+	}
+
+	if t.twelveHour {
+		x += u / 4
+		w := t.amPMControl.DefaultSize(context).X
+		appender.AppendChildWidgetWithBounds(&t.amPMControl, image.Rect(x, b.Min.Y, x+w, b.Max.Y))
+	}
+
+	return nil
+}
+
+func timePickerInputWidth(context *guigui.Context) int {
+	return 2 * UnitSize(context)
+}
+
+func (t *TimePicker) DefaultSize(context *guigui.Context) image.Point {
+	u := UnitSize(context)
+	n := 2
+	if t.showSeconds {
+		n = 3
+	}
+	w := n*timePickerInputWidth(context) + (n-1)*u/2
+	if t.twelveHour {
+		w += u/4 + t.amPMControl.DefaultSize(context).X
+	}
+	return image.Pt(w, t.hourInput.DefaultSize(context).Y)
+}
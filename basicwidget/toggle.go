@@ -21,21 +21,34 @@ type Toggle struct {
 	value        bool
 	onceRendered bool
 	prevHovered  bool
+	tap          tapRecognizer
 
 	count int
 
 	onValueChanged func(value bool)
+
+	valueBinding binding[bool]
 }
 
 func (t *Toggle) SetOnValueChanged(f func(value bool)) {
 	t.onValueChanged = f
 }
 
+// BindValue binds the toggle's value to value in both directions.
+func (t *Toggle) BindValue(value *guigui.Observable[bool]) {
+	t.valueBinding.bind(value)
+}
+
 func (t *Toggle) Value() bool {
 	return t.value
 }
 
 func (t *Toggle) SetValue(value bool) {
+	t.setValue(value, true)
+}
+
+// setValue sets the value. If fireEvents is false, the bound value and the callback are not updated.
+func (t *Toggle) setValue(value bool, fireEvents bool) {
 	if t.value == value {
 		return
 	}
@@ -46,16 +59,30 @@ func (t *Toggle) SetValue(value bool) {
 	}
 	guigui.RequestRedraw(t)
 
+	if !fireEvents {
+		return
+	}
+	t.valueBinding.push(value)
 	if t.onValueChanged != nil {
 		t.onValueChanged(value)
 	}
 }
 
+// pullValueFromBinding updates the value with the bound observable's value if it is changed.
+func (t *Toggle) pullValueFromBinding(context *guigui.Context) {
+	if value, ok := t.valueBinding.pull(context); ok {
+		t.setValue(value, false)
+	}
+}
+
 func toggleMaxCount() int {
 	return ebiten.TPS() / 12
 }
 
 func (t *Toggle) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	context.SetFocusable(t, true)
+
+	t.pullValueFromBinding(context)
 	if hovered := t.isHovered(context); t.prevHovered != hovered {
 		t.prevHovered = hovered
 		guigui.RequestRedraw(t)
@@ -64,6 +91,11 @@ func (t *Toggle) Build(context *guigui.Context, appender *guigui.ChildWidgetAppe
 }
 
 func (t *Toggle) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if _, _, ok := t.tap.handleTouchInput(context, t); ok && context.IsEnabled(t) {
+		context.SetFocused(t, true)
+		t.SetValue(!t.value)
+		return guigui.HandleInputByWidget(t)
+	}
 	if context.IsEnabled(t) && t.isHovered(context) && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		context.SetFocused(t, true)
 		t.pressed = true
@@ -76,6 +108,11 @@ func (t *Toggle) HandlePointingInput(context *guigui.Context) guigui.HandleInput
 	return guigui.HandleInputResult{}
 }
 
+// HandleActivate implements guigui.ActivateHandler.
+func (t *Toggle) HandleActivate(context *guigui.Context) {
+	t.SetValue(!t.value)
+}
+
 func (t *Toggle) Tick(context *guigui.Context) error {
 	if t.count > 0 {
 		t.count--
@@ -143,6 +180,10 @@ func (t *Toggle) Draw(context *guigui.Context, dst *ebiten.Image) {
 	b.Min.Y = b.Max.Y - b.Dy()/2
 	draw.DrawRoundedRectBorder(context, dst.SubImage(b).(*ebiten.Image), bounds, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
 
+	if context.IsFocusVisible(t) {
+		drawFocusRing(context, dst, bounds, r)
+	}
+
 	t.onceRendered = true
 }
 
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+)
+
+// tooltipZ is higher than popupZ so that a tooltip is rendered above popups.
+const tooltipZ = 2 * popupZ
+
+const defaultTooltipDelay = 500 * time.Millisecond
+
+func tooltipMaxOpeningCount() int {
+	return ebiten.TPS() / 10
+}
+
+type TooltipPlacement int
+
+const (
+	TooltipPlacementBottom TooltipPlacement = iota
+	TooltipPlacementTop
+	TooltipPlacementStart
+	TooltipPlacementEnd
+)
+
+// Tooltip shows its content near the anchor widget while the cursor is hovering on the anchor.
+//
+// Append a Tooltip as a child widget anywhere in the tree. Its bounds are not used.
+type Tooltip struct {
+	guigui.DefaultWidget
+
+	content tooltipContent
+	text    Text
+
+	anchor      guigui.Widget
+	userContent guigui.Widget
+	placement   TooltipPlacement
+	delay       time.Duration
+	delaySet    bool
+
+	hoveringCount int
+	openingCount  int
+	shown         bool
+	suppressed    bool
+
+	tmpKeys []ebiten.Key
+}
+
+// SetAnchor sets the widget that shows the tooltip when hovered.
+func (t *Tooltip) SetAnchor(widget guigui.Widget) {
+	t.anchor = widget
+}
+
+// SetText sets the tooltip's content as a text.
+func (t *Tooltip) SetText(text string) {
+	t.text.SetValue(text)
+	t.userContent = nil
+}
+
+// SetContent sets the tooltip's content as an arbitrary widget.
+func (t *Tooltip) SetContent(widget guigui.Widget) {
+	t.userContent = widget
+}
+
+func (t *Tooltip) SetPlacement(placement TooltipPlacement) {
+	t.placement = placement
+}
+
+// SetDelay sets the duration of hovering before the tooltip appears.
+// The default delay is 500 milliseconds.
+func (t *Tooltip) SetDelay(delay time.Duration) {
+	t.delay = delay
+	t.delaySet = true
+}
+
+func (t *Tooltip) delayInTicks() int {
+	d := defaultTooltipDelay
+	if t.delaySet {
+		d = t.delay
+	}
+	return int(d.Seconds() * float64(ebiten.TPS()))
+}
+
+func (t *Tooltip) contentWidget() guigui.Widget {
+	if t.userContent != nil {
+		return t.userContent
+	}
+	return &t.text
+}
+
+func (t *Tooltip) openingRate() float64 {
+	return easeOutQuad(float64(t.openingCount) / float64(tooltipMaxOpeningCount()))
+}
+
+func (t *Tooltip) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	if t.openingCount == 0 || t.anchor == nil {
+		return nil
+	}
+
+	t.text.SetColor(draw.TextColor(context.ColorMode(), true))
+	t.text.SetMultiline(true)
+
+	t.content.tooltip = t
+	t.content.content = t.contentWidget()
+	padding := tooltipPadding(context)
+	s := context.Size(t.content.content).Add(image.Pt(2*padding, 2*padding))
+	bounds := tooltipBounds(context.Bounds(t.anchor), s, context.AppBounds(), t.placement, UnitSize(context)/4)
+	context.SetOpacity(&t.content, t.openingRate())
+	appender.AppendChildWidgetWithBounds(&t.content, bounds)
+	return nil
+}
+
+func (t *Tooltip) isAnchorHovered(context *guigui.Context) bool {
+	if t.anchor == nil {
+		return false
+	}
+	return context.IsVisible(t.anchor) && context.IsWidgetHitAtCursor(t.anchor)
+}
+
+func (t *Tooltip) shouldDismiss() bool {
+	if x, y := ebiten.Wheel(); x != 0 || y != 0 {
+		return true
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle) {
+		return true
+	}
+	t.tmpKeys = inpututil.AppendJustPressedKeys(t.tmpKeys[:0])
+	return len(t.tmpKeys) > 0
+}
+
+func (t *Tooltip) Tick(context *guigui.Context) error {
+	switch {
+	case !t.isAnchorHovered(context):
+		t.hoveringCount = 0
+		t.shown = false
+		t.suppressed = false
+	case t.shouldDismiss():
+		// Keep the tooltip dismissed until the cursor leaves the anchor.
+		t.hoveringCount = 0
+		t.shown = false
+		t.suppressed = true
+	case !t.suppressed:
+		if t.hoveringCount < t.delayInTicks() {
+			t.hoveringCount++
+		} else {
+			t.shown = true
+		}
+	}
+
+	if t.shown {
+		if t.openingCount < tooltipMaxOpeningCount() {
+			t.openingCount++
+			guigui.RequestRedraw(&t.content)
+		}
+	} else if t.openingCount > 0 {
+		// Hide the tooltip immediately.
+		t.openingCount = 0
+	}
+	return nil
+}
+
+func (t *Tooltip) PassThrough() bool {
+	return true
+}
+
+func tooltipPadding(context *guigui.Context) int {
+	return UnitSize(context) / 4
+}
+
+// tooltipBounds returns the bounds of a tooltip with the given size for the anchor.
+// If the tooltip does not fit in the app bounds at the placement, the tooltip is flipped to the opposite side.
+func tooltipBounds(anchor image.Rectangle, size image.Point, appBounds image.Rectangle, placement TooltipPlacement, gap int) image.Rectangle {
+	var pos image.Point
+	switch placement {
+	case TooltipPlacementBottom, TooltipPlacementTop:
+		pos.X = anchor.Min.X + (anchor.Dx()-size.X)/2
+		below := anchor.Max.Y + gap
+		above := anchor.Min.Y - gap - size.Y
+		if placement == TooltipPlacementBottom {
+			pos.Y = below
+			if below+size.Y > appBounds.Max.Y && above >= appBounds.Min.Y {
+				pos.Y = above
+			}
+		} else {
+			pos.Y = above
+			if above < appBounds.Min.Y && below+size.Y <= appBounds.Max.Y {
+				pos.Y = below
+			}
+		}
+	case TooltipPlacementStart, TooltipPlacementEnd:
+		pos.Y = anchor.Min.Y + (anchor.Dy()-size.Y)/2
+		end := anchor.Max.X + gap
+		start := anchor.Min.X - gap - size.X
+		if placement == TooltipPlacementEnd {
+			pos.X = end
+			if end+size.X > appBounds.Max.X && start >= appBounds.Min.X {
+				pos.X = start
+			}
+		} else {
+			pos.X = start
+			if start < appBounds.Min.X && end+size.X <= appBounds.Max.X {
+				pos.X = end
+			}
+		}
+	}
+
+	// Keep the tooltip in the app bounds.
+	pos.X = min(pos.X, appBounds.Max.X-size.X)
+	pos.X = max(pos.X, appBounds.Min.X)
+	pos.Y = min(pos.Y, appBounds.Max.Y-size.Y)
+	pos.Y = max(pos.Y, appBounds.Min.Y)
+
+	return image.Rectangle{
+		Min: pos,
+		Max: pos.Add(size),
+	}
+}
+
+type tooltipContent struct {
+	guigui.DefaultWidget
+
+	tooltip *Tooltip
+	content guigui.Widget
+}
+
+func (t *tooltipContent) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	if t.content != nil {
+		padding := tooltipPadding(context)
+		appender.AppendChildWidgetWithPosition(t.content, context.Position(t).Add(image.Pt(padding, padding)))
+	}
+	return nil
+}
+
+func (t *tooltipContent) Draw(context *guigui.Context, dst *ebiten.Image) {
+	bounds := context.Bounds(t)
+
+	shadowBounds := bounds
+	shadowBounds.Min.X -= int(4 * context.Scale())
+	shadowBounds.Max.X += int(4 * context.Scale())
+	shadowBounds.Max.Y += int(4 * context.Scale())
+	draw.DrawRoundedShadowRect(context, dst, shadowBounds, draw.ScaleAlpha(color.Black, 0.2), int(4*context.Scale())+RoundedCornerRadius(context))
+
+	clr := draw.Color(context.ColorMode(), draw.ColorTypeBase, 1)
+	draw.DrawRoundedRect(context, dst, bounds, clr, RoundedCornerRadius(context))
+
+	clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset, false)
+	draw.DrawRoundedRectBorder(context, dst, bounds, clr1, clr2, RoundedCornerRadius(context), float32(1*context.Scale()), draw.RoundedRectBorderTypeOutset)
+}
+
+func (t *tooltipContent) ZDelta() int {
+	return tooltipZ
+}
+
+func (t *tooltipContent) PassThrough() bool {
+	return true
+}
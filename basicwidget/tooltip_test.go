@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+func TestTooltipBounds(t *testing.T) {
+	appBounds := image.Rect(0, 0, 100, 100)
+	size := image.Pt(20, 10)
+	testCases := []struct {
+		anchor    image.Rectangle
+		placement basicwidget.TooltipPlacement
+		out       image.Rectangle
+	}{
+		{
+			anchor:    image.Rect(40, 40, 60, 50),
+			placement: basicwidget.TooltipPlacementBottom,
+			out:       image.Rect(40, 52, 60, 62),
+		},
+		{
+			anchor:    image.Rect(40, 40, 60, 50),
+			placement: basicwidget.TooltipPlacementTop,
+			out:       image.Rect(40, 28, 60, 38),
+		},
+		// Flipped at the bottom edge.
+		{
+			anchor:    image.Rect(40, 85, 60, 95),
+			placement: basicwidget.TooltipPlacementBottom,
+			out:       image.Rect(40, 73, 60, 83),
+		},
+		// Flipped at the top edge.
+		{
+			anchor:    image.Rect(40, 0, 60, 10),
+			placement: basicwidget.TooltipPlacementTop,
+			out:       image.Rect(40, 12, 60, 22),
+		},
+		// Flipped at the right edge.
+		{
+			anchor:    image.Rect(80, 40, 95, 50),
+			placement: basicwidget.TooltipPlacementEnd,
+			out:       image.Rect(58, 40, 78, 50),
+		},
+		// Clamped at the left edge.
+		{
+			anchor:    image.Rect(0, 40, 10, 50),
+			placement: basicwidget.TooltipPlacementBottom,
+			out:       image.Rect(0, 52, 20, 62),
+		},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.TooltipBounds(tc.anchor, size, appBounds, tc.placement, 2); got != tc.out {
+			t.Errorf("TooltipBounds(%v, %v): got: %v, want: %v", tc.anchor, tc.placement, got, tc.out)
+		}
+	}
+}
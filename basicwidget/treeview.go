@@ -0,0 +1,570 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image"
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
+)
+
+type TreeItem[T comparable] struct {
+	Text      string
+	TextColor color.Color
+	Content   guigui.Widget
+	Disabled  bool
+	Movable   bool
+	Icon      *ebiten.Image
+
+	Children []TreeItem[T]
+
+	// HasChildren reports whether the item has children that are not loaded yet.
+	// When such an item is expanded, the function set by SetOnChildrenRequested is called.
+	HasChildren bool
+
+	ID T
+}
+
+func (t *TreeItem[T]) listItem() ListItem[T] {
+	return ListItem[T]{
+		Text:      t.Text,
+		TextColor: t.TextColor,
+		Content:   t.Content,
+		Disabled:  t.Disabled,
+		Movable:   t.Movable,
+		Icon:      t.Icon,
+		ID:        t.ID,
+	}
+}
+
+// treeRow is a visible item in a tree.
+type treeRow[T comparable] struct {
+	item        *TreeItem[T]
+	depth       int
+	parentIndex int
+	expandable  bool
+	expanded    bool
+}
+
+type TreeView[T comparable] struct {
+	guigui.DefaultWidget
+
+	list          baseList[T]
+	items         []TreeItem[T]
+	rows          []treeRow[T]
+	rowWidgets    []treeItemWidget[T]
+	baseListItems []baseListItem[T]
+
+	expanded       map[T]struct{}
+	loadedChildren map[T][]TreeItem[T]
+	requested      map[T]struct{}
+	rowIndices     map[T]int
+
+	tmpIDs     []T
+	tmpIndices []int
+
+	onItemSelected      func(id T)
+	onChildrenRequested func(id T)
+	onItemsMoved        func(ids []T, parent T, hasParent bool, index int)
+}
+
+func treeItemIndent(context *guigui.Context) int {
+	return int(LineHeight(context))
+}
+
+func (t *TreeView[T]) SetItemBorderVisible(visible bool) {
+	t.list.SetStripeVisible(visible)
+}
+
+// SetItems sets the root items.
+func (t *TreeView[T]) SetItems(items []TreeItem[T]) {
+	t.items = adjustSliceSize(t.items, len(items))
+	copy(t.items, items)
+	t.updateRows()
+}
+
+// SetChildren sets the children of the item that are loaded lazily.
+// The children in TreeItem.Children take precedence.
+func (t *TreeView[T]) SetChildren(id T, children []TreeItem[T]) {
+	if t.loadedChildren == nil {
+		t.loadedChildren = map[T][]TreeItem[T]{}
+	}
+	t.loadedChildren[id] = slices.Clone(children)
+	delete(t.requested, id)
+	t.updateRows()
+}
+
+// SetOnChildrenRequested sets the function called when an item with HasChildren but without children is expanded.
+// The function should provide the children by SetChildren, or by SetItems with TreeItem.Children, possibly later.
+func (t *TreeView[T]) SetOnChildrenRequested(f func(id T)) {
+	t.onChildrenRequested = f
+}
+
+func (t *TreeView[T]) SetOnItemSelected(f func(id T)) {
+	t.onItemSelected = f
+}
+
+// SetOnItemsMoved sets the function called when items are dropped by dragging.
+//
+// ids are the moved items. parent is the new parent item, which is valid only when hasParent is true.
+// index is the position in the new parent's children where the items are inserted, counted before the items are removed.
+func (t *TreeView[T]) SetOnItemsMoved(f func(ids []T, parent T, hasParent bool, index int)) {
+	t.onItemsMoved = f
+}
+
+func (t *TreeView[T]) SetSelectionMode(mode ListSelectionMode) {
+	t.list.SetSelectionMode(mode)
+}
+
+func (t *TreeView[T]) children(item *TreeItem[T]) []TreeItem[T] {
+	if len(item.Children) > 0 {
+		return item.Children
+	}
+	return t.loadedChildren[item.ID]
+}
+
+func (t *TreeView[T]) IsExpanded(id T) bool {
+	_, ok := t.expanded[id]
+	return ok
+}
+
+// SetExpanded expands or collapses the item.
+func (t *TreeView[T]) SetExpanded(id T, expanded bool) {
+	if t.IsExpanded(id) == expanded {
+		return
+	}
+	if expanded {
+		if t.expanded == nil {
+			t.expanded = map[T]struct{}{}
+		}
+		t.expanded[id] = struct{}{}
+		t.requestChildrenIfNeeded(id)
+	} else {
+		delete(t.expanded, id)
+	}
+	t.updateRows()
+	guigui.RequestRedraw(t)
+}
+
+func (t *TreeView[T]) requestChildrenIfNeeded(id T) {
+	item, _ := t.findItem(t.items, id, nil)
+	if item == nil || !item.HasChildren || len(t.children(item)) > 0 {
+		return
+	}
+	if _, ok := t.requested[id]; ok {
+		return
+	}
+	if t.requested == nil {
+		t.requested = map[T]struct{}{}
+	}
+	t.requested[id] = struct{}{}
+	if t.onChildrenRequested != nil {
+		t.onChildrenRequested(id)
+	}
+}
+
+// findItem finds the item with the ID in the tree including the loaded children.
+// findItem also appends the IDs of the item's ancestors to ancestors.
+func (t *TreeView[T]) findItem(items []TreeItem[T], id T, ancestors []T) (*TreeItem[T], []T) {
+	for i := range items {
+		item := &items[i]
+		if item.ID == id {
+			return item, ancestors
+		}
+		if found, as := t.findItem(t.children(item), id, append(ancestors, item.ID)); found != nil {
+			return found, as
+		}
+	}
+	return nil, ancestors
+}
+
+// updateRows updates the visible items, keeping the selection by IDs.
+func (t *TreeView[T]) updateRows() {
+	t.tmpIDs = t.tmpIDs[:0]
+	for _, index := range t.list.AppendSelectedItemIndices(t.tmpIndices[:0]) {
+		if index < len(t.rows) {
+			t.tmpIDs = append(t.tmpIDs, t.rows[index].item.ID)
+		}
+	}
+
+	t.rows = t.rows[:0]
+	t.appendRows(t.items, 0, -1)
+
+	if t.rowIndices == nil {
+		t.rowIndices = map[T]int{}
+	}
+	clear(t.rowIndices)
+	for i, row := range t.rows {
+		t.rowIndices[row.item.ID] = i
+	}
+
+	t.rowWidgets = adjustSliceSize(t.rowWidgets, len(t.rows))
+	t.baseListItems = adjustSliceSize(t.baseListItems, len(t.rows))
+	var hasIcons bool
+	for _, row := range t.rows {
+		if row.item.Icon != nil {
+			hasIcons = true
+			break
+		}
+	}
+	for i := range t.rows {
+		w := &t.rowWidgets[i]
+		w.tree = t
+		w.row = t.rows[i]
+		w.content.setListItem(t.rows[i].item.listItem())
+		w.content.iconSpace = hasIcons
+		w.disclosure.tree = t
+		w.disclosure.id = t.rows[i].item.ID
+		w.disclosure.expanded = t.rows[i].expanded
+		t.baseListItems[i] = baseListItem[T]{
+			Content:    w,
+			Selectable: w.content.selectable(),
+			Movable:    t.rows[i].item.Movable,
+			ID:         t.rows[i].item.ID,
+			Text:       t.rows[i].item.Text,
+		}
+	}
+	t.list.SetItems(t.baseListItems)
+
+	// Restore the selection. An item hidden by collapsing is replaced with its nearest visible ancestor.
+	t.tmpIndices = t.tmpIndices[:0]
+	for _, id := range t.tmpIDs {
+		if index, ok := t.rowIndices[id]; ok {
+			t.tmpIndices = append(t.tmpIndices, index)
+			continue
+		}
+		_, ancestors := t.findItem(t.items, id, nil)
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			if index, ok := t.rowIndices[ancestors[i]]; ok {
+				t.tmpIndices = append(t.tmpIndices, index)
+				break
+			}
+		}
+	}
+	t.list.SelectItemsByIndices(t.tmpIndices)
+}
+
+func (t *TreeView[T]) appendRows(items []TreeItem[T], depth int, parentIndex int) {
+	for i := range items {
+		item := &items[i]
+		children := t.children(item)
+		expandable := len(children) > 0 || item.HasChildren
+		expanded := expandable && t.IsExpanded(item.ID)
+		index := len(t.rows)
+		t.rows = append(t.rows, treeRow[T]{
+			item:        item,
+			depth:       depth,
+			parentIndex: parentIndex,
+			expandable:  expandable,
+			expanded:    expanded,
+		})
+		if expanded {
+			t.appendRows(children, depth+1, index)
+		}
+	}
+}
+
+func (t *TreeView[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	t.list.SetOnItemSelected(func(index int) {
+		if t.onItemSelected != nil && index >= 0 && index < len(t.rows) {
+			t.onItemSelected(t.rows[index].item.ID)
+		}
+	})
+	t.list.onItemsDropped = t.dropItems
+	t.list.dropGuidelineIndent = func(context *guigui.Context, to int) int {
+		depth, _ := t.dropTarget(context, to)
+		return RoundedCornerRadius(context) + listItemPadding(context) + (depth+1)*treeItemIndent(context)
+	}
+
+	context.SetSize(&t.list, context.Size(t))
+	appender.AppendChildWidgetWithPosition(&t.list, context.Position(t))
+
+	for i := range t.rowWidgets {
+		w := &t.rowWidgets[i]
+		clr := t.itemTextColor(context, i)
+		w.content.text.SetColor(clr)
+		context.SetSize(w, image.Pt(guigui.DefaultSize, guigui.DefaultSize))
+	}
+
+	return nil
+}
+
+func (t *TreeView[T]) itemTextColor(context *guigui.Context, index int) color.Color {
+	item := t.rows[index].item
+	switch {
+	case t.list.IsItemSelected(index) && !item.Disabled:
+		return DefaultActiveListItemTextColor(context)
+	case item.Disabled:
+		return DefaultDisabledListItemTextColor(context)
+	case item.TextColor != nil:
+		return item.TextColor
+	default:
+		return draw.TextColor(context.ColorMode(), context.IsEnabled(t))
+	}
+}
+
+// HandleButtonInput expands the current item or moves to its first child by the right key,
+// and collapses the current item or moves to its parent by the left key.
+func (t *TreeView[T]) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	index := t.list.currentItemIndex(context)
+	if index < 0 || index >= len(t.rows) {
+		return guigui.HandleInputResult{}
+	}
+	row := t.rows[index]
+	switch {
+	case isKeyRepeating(ebiten.KeyRight):
+		if !row.expandable {
+			return guigui.HandleInputResult{}
+		}
+		if !row.expanded {
+			t.SetExpanded(row.item.ID, true)
+			return guigui.HandleInputByWidget(t)
+		}
+		if index+1 < len(t.rows) && t.rows[index+1].parentIndex == index {
+			t.list.moveCurrentItemIndex(context, index+1, false)
+			return guigui.HandleInputByWidget(t)
+		}
+	case isKeyRepeating(ebiten.KeyLeft):
+		if row.expanded {
+			t.SetExpanded(row.item.ID, false)
+			return guigui.HandleInputByWidget(t)
+		}
+		if row.parentIndex >= 0 {
+			t.list.moveCurrentItemIndex(context, row.parentIndex, false)
+			return guigui.HandleInputByWidget(t)
+		}
+	}
+	return guigui.HandleInputResult{}
+}
+
+// SelectItemByID selects the item, expanding its ancestors.
+func (t *TreeView[T]) SelectItemByID(id T) {
+	item, ancestors := t.findItem(t.items, id, t.tmpIDs[:0])
+	if item == nil {
+		return
+	}
+	var changed bool
+	for _, a := range ancestors {
+		if t.IsExpanded(a) {
+			continue
+		}
+		if t.expanded == nil {
+			t.expanded = map[T]struct{}{}
+		}
+		t.expanded[a] = struct{}{}
+		changed = true
+	}
+	if changed {
+		t.updateRows()
+	}
+	t.list.SelectItemByID(id)
+	if index, ok := t.rowIndices[id]; ok {
+		t.list.JumpToItemIndex(index)
+	}
+}
+
+// SelectedItemID returns the ID of the selected item with the smallest index.
+func (t *TreeView[T]) SelectedItemID() (T, bool) {
+	index := t.list.SelectedItemIndex()
+	if index < 0 || index >= len(t.rows) {
+		var id T
+		return id, false
+	}
+	return t.rows[index].item.ID, true
+}
+
+// AppendSelectedItemIDs appends the IDs of the selected items in the visible order to ids.
+func (t *TreeView[T]) AppendSelectedItemIDs(ids []T) []T {
+	t.tmpIndices = t.list.AppendSelectedItemIndices(t.tmpIndices[:0])
+	for _, index := range t.tmpIndices {
+		if index < len(t.rows) {
+			ids = append(ids, t.rows[index].item.ID)
+		}
+	}
+	return ids
+}
+
+// dropTarget returns the depth and the parent row index of the items dropped at the destination.
+// The depth is chosen by the cursor position among the possible depths, and the parent row index is -1 for the root.
+func (t *TreeView[T]) dropTarget(context *guigui.Context, to int) (int, int) {
+	x, _ := ebiten.CursorPosition()
+	x -= context.Position(&t.list).X + RoundedCornerRadius(context) + listItemPadding(context)
+	return t.dropTargetAtDepth(to, x/treeItemIndent(context))
+}
+
+// dropTargetAtDepth is dropTarget with the depth specified by the cursor.
+func (t *TreeView[T]) dropTargetAtDepth(to int, depth int) (int, int) {
+	above := to - 1
+	var minDepth, maxDepth int
+	if to < len(t.rows) {
+		minDepth = t.rows[to].depth
+	}
+	if above >= 0 {
+		maxDepth = t.rows[above].depth + 1
+	}
+	minDepth = min(minDepth, maxDepth)
+	depth = min(max(depth, minDepth), maxDepth)
+
+	parent := above
+	for parent >= 0 && t.rows[parent].depth >= depth {
+		parent = t.rows[parent].parentIndex
+	}
+	return depth, parent
+}
+
+func (t *TreeView[T]) dropItems(context *guigui.Context, indices []int, to int) {
+	_, parent := t.dropTarget(context, to)
+	t.dropItemsInto(indices, to, parent)
+}
+
+// dropItemsInto moves the items at the indices to the destination under the parent row.
+func (t *TreeView[T]) dropItemsInto(indices []int, to int, parent int) {
+	if t.onItemsMoved == nil {
+		return
+	}
+
+	// Do not drop items into themselves.
+	for p := parent; p >= 0; p = t.rows[p].parentIndex {
+		if slices.Contains(indices, p) {
+			return
+		}
+	}
+
+	// Skip the items whose ancestors are also moved, as they are moved with the ancestors.
+	t.tmpIDs = t.tmpIDs[:0]
+	for _, index := range indices {
+		var moved bool
+		for p := t.rows[index].parentIndex; p >= 0; p = t.rows[p].parentIndex {
+			if slices.Contains(indices, p) {
+				moved = true
+				break
+			}
+		}
+		if !moved {
+			t.tmpIDs = append(t.tmpIDs, t.rows[index].item.ID)
+		}
+	}
+
+	var index int
+	if parent >= 0 && !t.rows[parent].expanded {
+		index = len(t.children(t.rows[parent].item))
+	} else {
+		for i := parent + 1; i < to; i++ {
+			if t.rows[i].parentIndex == parent {
+				index++
+			}
+		}
+	}
+
+	// updateRows uses tmpIDs.
+	ids := slices.Clone(t.tmpIDs)
+	var parentID T
+	if parent >= 0 {
+		parentID = t.rows[parent].item.ID
+		if !t.IsExpanded(parentID) {
+			if t.expanded == nil {
+				t.expanded = map[T]struct{}{}
+			}
+			t.expanded[parentID] = struct{}{}
+			t.updateRows()
+			guigui.RequestRedraw(t)
+		}
+	}
+	t.onItemsMoved(ids, parentID, parent >= 0, index)
+}
+
+func (t *TreeView[T]) DefaultSize(context *guigui.Context) image.Point {
+	return t.list.DefaultSize(context)
+}
+
+type treeItemWidget[T comparable] struct {
+	guigui.DefaultWidget
+
+	tree       *TreeView[T]
+	row        treeRow[T]
+	disclosure treeDisclosure[T]
+	content    listItemWidget[T]
+}
+
+func (t *treeItemWidget[T]) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	bounds := context.Bounds(t)
+	indent := treeItemIndent(context)
+	x := bounds.Min.X + t.row.depth*indent
+	if t.row.expandable {
+		appender.AppendChildWidgetWithBounds(&t.disclosure, image.Rect(x, bounds.Min.Y, x+indent, bounds.Max.Y))
+	}
+	bounds.Min.X = x + indent
+	appender.AppendChildWidgetWithBounds(&t.content, bounds)
+	return nil
+}
+
+func (t *treeItemWidget[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
+	// Draw indentation guides.
+	bounds := context.Bounds(t)
+	indent := treeItemIndent(context)
+	clr := draw.Color(context.ColorMode(), draw.ColorTypeBase, 0.8)
+	width := float32(1 * context.Scale())
+	for d := range t.row.depth {
+		x := float32(bounds.Min.X+d*indent) + float32(indent)/2
+		vector.StrokeLine(dst, x, float32(bounds.Min.Y), x, float32(bounds.Max.Y), width, clr, false)
+	}
+}
+
+func (t *treeItemWidget[T]) DefaultSize(context *guigui.Context) image.Point {
+	s := t.content.DefaultSize(context)
+	s.X += (t.row.depth + 1) * treeItemIndent(context)
+	return s
+}
+
+type treeDisclosure[T comparable] struct {
+	guigui.DefaultWidget
+
+	tree     *TreeView[T]
+	id       T
+	expanded bool
+}
+
+func (t *treeDisclosure[T]) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if !context.IsWidgetHitAtCursor(t) {
+		return guigui.HandleInputResult{}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		t.tree.SetExpanded(t.id, !t.expanded)
+		return guigui.HandleInputByWidget(t)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (t *treeDisclosure[T]) CursorShape(context *guigui.Context) (ebiten.CursorShapeType, bool) {
+	return ebiten.CursorShapePointer, true
+}
+
+func (t *treeDisclosure[T]) Draw(context *guigui.Context, dst *ebiten.Image) {
+	bounds := context.Bounds(t)
+	cx := float32(bounds.Min.X+bounds.Max.X) / 2
+	cy := float32(bounds.Min.Y+bounds.Max.Y) / 2
+	r := float32(bounds.Dx()) / 5
+
+	// Draw a triangle pointing to the right when collapsed, and to the bottom when expanded.
+	var path vector.Path
+	if t.expanded {
+		path.MoveTo(cx-r, cy-r/2)
+		path.LineTo(cx+r, cy-r/2)
+		path.LineTo(cx, cy+r)
+	} else {
+		path.MoveTo(cx-r/2, cy-r)
+		path.LineTo(cx+r, cy)
+		path.LineTo(cx-r/2, cy+r)
+	}
+	path.Close()
+	clr := draw.ScaleAlpha(draw.TextColor(context.ColorMode(), context.IsEnabled(t)), 0.7)
+	vector.DrawFilledPath(dst, &path, clr, true, vector.FillRuleNonZero)
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package basicwidget
+
+import (
+	"slices"
+	"testing"
+)
+
+// testTreeItems are the items for the tree view tests. The visible items are the following when a is expanded:
+//
+//	a
+//	  a1
+//	  a2
+//	b
+//	c
+var testTreeItems = []TreeItem[string]{
+	{
+		ID: "a",
+		Children: []TreeItem[string]{
+			{ID: "a1"},
+			{ID: "a2"},
+		},
+	},
+	{
+		ID: "b",
+		Children: []TreeItem[string]{
+			{ID: "b1"},
+		},
+	},
+	{ID: "c"},
+}
+
+// visibleTreeItemIDs returns the IDs of the visible items in order.
+func visibleTreeItemIDs(tree *TreeView[string]) []string {
+	var ids []string
+	for _, row := range tree.rows {
+		ids = append(ids, row.item.ID)
+	}
+	return ids
+}
+
+func TestTreeViewDropTarget(t *testing.T) {
+	var tree TreeView[string]
+	tree.SetItems(testTreeItems)
+	tree.SetExpanded("a", true)
+	if got, want := visibleTreeItemIDs(&tree), []string{"a", "a1", "a2", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("visible items: got: %v, want: %v", got, want)
+	}
+
+	testCases := []struct {
+		to         int
+		depth      int
+		wantDepth  int
+		wantParent int
+	}{
+		// The top accepts only the root.
+		{to: 0, depth: 3, wantDepth: 0, wantParent: -1},
+		// Below a: into a as its first child.
+		{to: 1, depth: 0, wantDepth: 1, wantParent: 0},
+		{to: 1, depth: 5, wantDepth: 1, wantParent: 0},
+		// Below a1: into a1, or next to a1 in a.
+		{to: 2, depth: 2, wantDepth: 2, wantParent: 1},
+		{to: 2, depth: 1, wantDepth: 1, wantParent: 0},
+		{to: 2, depth: 0, wantDepth: 1, wantParent: 0},
+		// Below a2: into a2, next to a2 in a, or next to a in the root.
+		{to: 3, depth: 2, wantDepth: 2, wantParent: 2},
+		{to: 3, depth: 1, wantDepth: 1, wantParent: 0},
+		{to: 3, depth: 0, wantDepth: 0, wantParent: -1},
+		// The bottom: into c, or the root.
+		{to: 5, depth: 1, wantDepth: 1, wantParent: 4},
+		{to: 5, depth: 0, wantDepth: 0, wantParent: -1},
+	}
+	for _, tc := range testCases {
+		depth, parent := tree.dropTargetAtDepth(tc.to, tc.depth)
+		if depth != tc.wantDepth || parent != tc.wantParent {
+			t.Errorf("dropTargetAtDepth(%d, %d): got: %d, %d, want: %d, %d", tc.to, tc.depth, depth, parent, tc.wantDepth, tc.wantParent)
+		}
+	}
+}
+
+func TestTreeViewDropItems(t *testing.T) {
+	type moved struct {
+		ids       []string
+		parent    string
+		hasParent bool
+		index     int
+	}
+	testCases := []struct {
+		name    string
+		indices []int
+		to      int
+		depth   int
+		want    *moved
+	}{
+		{
+			name:    "reparent into a collapsed item",
+			indices: []int{4},
+			to:      4,
+			depth:   1,
+			// b is collapsed, so c is appended to its children.
+			want: &moved{ids: []string{"c"}, parent: "b", hasParent: true, index: 1},
+		},
+		{
+			name:    "reparent to the root",
+			indices: []int{2},
+			to:      5,
+			depth:   0,
+			want:    &moved{ids: []string{"a2"}, hasParent: false, index: 3},
+		},
+		{
+			name:    "move in the same parent",
+			indices: []int{2},
+			to:      1,
+			depth:   1,
+			want:    &moved{ids: []string{"a2"}, parent: "a", hasParent: true, index: 0},
+		},
+		{
+			name:    "children moved with their parent",
+			indices: []int{0, 1},
+			to:      5,
+			depth:   0,
+			want:    &moved{ids: []string{"a"}, hasParent: false, index: 3},
+		},
+		{
+			name:    "drop into itself",
+			indices: []int{0},
+			to:      1,
+			depth:   1,
+			want:    nil,
+		},
+		{
+			name:    "drop into its descendant",
+			indices: []int{0},
+			to:      2,
+			depth:   2,
+			want:    nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tree TreeView[string]
+			tree.SetItems(testTreeItems)
+			tree.SetExpanded("a", true)
+			var got *moved
+			tree.SetOnItemsMoved(func(ids []string, parent string, hasParent bool, index int) {
+				got = &moved{ids: ids, parent: parent, hasParent: hasParent, index: index}
+			})
+			_, parent := tree.dropTargetAtDepth(tc.to, tc.depth)
+			tree.dropItemsInto(tc.indices, tc.to, parent)
+			if tc.want == nil {
+				if got != nil {
+					t.Errorf("got: %+v, want: nil", *got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("got: nil, want: %+v", *tc.want)
+			}
+			if !slices.Equal(got.ids, tc.want.ids) || got.parent != tc.want.parent || got.hasParent != tc.want.hasParent || got.index != tc.want.index {
+				t.Errorf("got: %+v, want: %+v", *got, *tc.want)
+			}
+			if tc.want.hasParent && !tree.IsExpanded(tc.want.parent) {
+				t.Errorf("IsExpanded(%q): got: false, want: true", tc.want.parent)
+			}
+		})
+	}
+}
+
+func TestTreeViewRequestChildren(t *testing.T) {
+	var tree TreeView[string]
+	var requested []string
+	tree.SetOnChildrenRequested(func(id string) {
+		requested = append(requested, id)
+	})
+	tree.SetItems([]TreeItem[string]{
+		{ID: "a", HasChildren: true},
+		{ID: "b"},
+	})
+
+	tree.SetExpanded("a", true)
+	if want := []string{"a"}; !slices.Equal(requested, want) {
+		t.Errorf("requested: got: %v, want: %v", requested, want)
+	}
+	// The request is not repeated while the children are being loaded.
+	tree.SetExpanded("a", false)
+	tree.SetExpanded("a", true)
+	if want := []string{"a"}; !slices.Equal(requested, want) {
+		t.Errorf("requested: got: %v, want: %v", requested, want)
+	}
+	// Items without HasChildren don't request children.
+	tree.SetExpanded("b", true)
+	if want := []string{"a"}; !slices.Equal(requested, want) {
+		t.Errorf("requested: got: %v, want: %v", requested, want)
+	}
+
+	tree.SetChildren("a", []TreeItem[string]{
+		{ID: "a1"},
+	})
+	if got, want := visibleTreeItemIDs(&tree), []string{"a", "a1", "b"}; !slices.Equal(got, want) {
+		t.Errorf("visible items: got: %v, want: %v", got, want)
+	}
+	// The loaded children are not requested again.
+	tree.SetExpanded("a", false)
+	tree.SetExpanded("a", true)
+	if want := []string{"a"}; !slices.Equal(requested, want) {
+		t.Errorf("requested: got: %v, want: %v", requested, want)
+	}
+}
+
+func TestTreeViewSelectionAcrossCollapse(t *testing.T) {
+	var tree TreeView[string]
+	tree.SetItems(testTreeItems)
+
+	// Selecting a hidden item expands its ancestors.
+	tree.SelectItemByID("a2")
+	if !tree.IsExpanded("a") {
+		t.Errorf("IsExpanded(a): got: false, want: true")
+	}
+	if id, _ := tree.SelectedItemID(); id != "a2" {
+		t.Errorf("SelectedItemID: got: %q, want: %q", id, "a2")
+	}
+
+	// Collapsing a selected item's parent selects the parent.
+	tree.SetExpanded("a", false)
+	if id, _ := tree.SelectedItemID(); id != "a" {
+		t.Errorf("SelectedItemID: got: %q, want: %q", id, "a")
+	}
+
+	// Collapsing and expanding another item keeps the selection.
+	tree.SelectItemByID("c")
+	tree.SetExpanded("b", true)
+	tree.SetExpanded("b", false)
+	if id, _ := tree.SelectedItemID(); id != "c" {
+		t.Errorf("SelectedItemID: got: %q, want: %q", id, "c")
+	}
+}
@@ -4,11 +4,13 @@
 package basicwidget
 
 import (
+	"image"
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget/internal/draw"
 )
 
 func adjustSliceSize[T any](slice []T, size int) []T {
@@ -47,10 +49,31 @@ func MoveItemsInSlice[T any](slice []T, from int, count int, to int) int {
 	return to
 }
 
+// isCommandKeyPressed reports whether the platform's command modifier key is pressed: Cmd on macOS, and Ctrl otherwise.
+func isCommandKeyPressed() bool {
+	if useEmacsKeybind() {
+		return ebiten.IsKeyPressed(ebiten.KeyMeta)
+	}
+	return ebiten.IsKeyPressed(ebiten.KeyControl)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
 func doubleClickLimitInTicks() int {
 	return ebiten.TPS() / 2
 }
 
+// drawFocusRing draws an indicator of the focus moved by directional focus navigation.
+func drawFocusRing(context *guigui.Context, dst *ebiten.Image, bounds image.Rectangle, radius int) {
+	clr := draw.Color(context.ColorMode(), draw.ColorTypeAccent, 0.5)
+	draw.DrawRoundedRectBorder(context, dst, bounds, clr, clr, radius, float32(2*context.Scale()), draw.RoundedRectBorderTypeRegular)
+}
+
 func defaultIconSize(context *guigui.Context) int {
 	return int(LineHeight(context))
 }
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+// Package clipboard provides access to the system clipboard with multiple data formats.
+package clipboard
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// Format is a MIME type of clipboard data.
+//
+// Custom app formats can be represented by arbitrary MIME types like "application/x-myapp-item".
+type Format string
+
+const (
+	FormatText Format = "text/plain"
+	FormatHTML Format = "text/html"
+	FormatPNG  Format = "image/png"
+)
+
+// ErrFormatUnavailable is returned when the clipboard content is not available in the requested format.
+var ErrFormatUnavailable = errors.New("clipboard: format unavailable")
+
+// Item is a representation of clipboard content in a format.
+type Item struct {
+	Format Format
+	Data   []byte
+}
+
+// Backend is an implementation of a clipboard.
+//
+// A Backend's methods must be safe for concurrent use.
+type Backend interface {
+	// Formats returns the formats in which the current content is available.
+	Formats() ([]Format, error)
+
+	// Read returns the current content in format.
+	// Read returns ErrFormatUnavailable if the content is not available in format.
+	Read(format Format) ([]byte, error)
+
+	// Write replaces the current content with items.
+	// Each item is a representation of the same content in a different format.
+	Write(items []Item) error
+}
+
+var (
+	theBackend  Backend
+	theBackendM sync.Mutex
+)
+
+// SetBackend replaces the clipboard backend and returns the previous one.
+// A nil backend represents the system backend.
+//
+// SetBackend is useful to install a MemoryBackend in tests.
+func SetBackend(backend Backend) Backend {
+	theBackendM.Lock()
+	defer theBackendM.Unlock()
+	prev := theBackend
+	theBackend = backend
+	return prev
+}
+
+func currentBackend() Backend {
+	theBackendM.Lock()
+	defer theBackendM.Unlock()
+	if theBackend == nil {
+		return systemBackend()
+	}
+	return theBackend
+}
+
+// Formats returns the formats in which the current content is available.
+func Formats() ([]Format, error) {
+	return currentBackend().Formats()
+}
+
+// Read returns the current content in format.
+func Read(format Format) ([]byte, error) {
+	return currentBackend().Read(format)
+}
+
+// ReadAsync reads the current content in format on another goroutine, and calls f with the result on that goroutine.
+//
+// Use guigui.Context.Post in f to hand the result to widgets.
+func ReadAsync(format Format, f func(data []byte, err error)) {
+	go func() {
+		f(Read(format))
+	}()
+}
+
+// Write replaces the current content with items.
+func Write(items ...Item) error {
+	return currentBackend().Write(items)
+}
+
+// ReadText returns the current content as text.
+// ReadText returns an empty string without an error if the content is not available as text.
+func ReadText() (string, error) {
+	data, err := Read(FormatText)
+	if errors.Is(err, ErrFormatUnavailable) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// WriteText replaces the current content with text.
+func WriteText(text string) error {
+	return Write(Item{
+		Format: FormatText,
+		Data:   []byte(text),
+	})
+}
+
+// MemoryBackend is a clipboard backend that stores the content in memory.
+//
+// The zero value is an empty clipboard.
+type MemoryBackend struct {
+	items []Item
+	m     sync.Mutex
+}
+
+func (m *MemoryBackend) Formats() ([]Format, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	formats := make([]Format, 0, len(m.items))
+	for _, item := range m.items {
+		formats = append(formats, item.Format)
+	}
+	return formats, nil
+}
+
+func (m *MemoryBackend) Read(format Format) ([]byte, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	idx := slices.IndexFunc(m.items, func(item Item) bool {
+		return item.Format == format
+	})
+	if idx < 0 {
+		return nil, ErrFormatUnavailable
+	}
+	return slices.Clone(m.items[idx].Data), nil
+}
+
+func (m *MemoryBackend) Write(items []Item) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.items = slices.Delete(m.items, 0, len(m.items))
+	for _, item := range items {
+		m.items = append(m.items, Item{
+			Format: item.Format,
+			Data:   slices.Clone(item.Data),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package clipboard
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego/objc"
+)
+
+var (
+	idNSPasteboard = objc.ID(objc.GetClass("NSPasteboard"))
+	idNSData       = objc.ID(objc.GetClass("NSData"))
+	idNSString     = objc.ID(objc.GetClass("NSString"))
+
+	selBytes               = objc.RegisterName("bytes")
+	selClearContents       = objc.RegisterName("clearContents")
+	selCount               = objc.RegisterName("count")
+	selDataForType         = objc.RegisterName("dataForType:")
+	selDataWithBytesLength = objc.RegisterName("dataWithBytes:length:")
+	selGeneralPasteboard   = objc.RegisterName("generalPasteboard")
+	selLength              = objc.RegisterName("length")
+	selObjectAtIndex       = objc.RegisterName("objectAtIndex:")
+	selSetDataForType      = objc.RegisterName("setData:forType:")
+	selStringWithUTF8      = objc.RegisterName("stringWithUTF8String:")
+	selTypes               = objc.RegisterName("types")
+	selUTF8String          = objc.RegisterName("UTF8String")
+)
+
+func systemBackend() Backend {
+	return darwinBackend{}
+}
+
+// formatToUTI converts a format to a pasteboard type.
+// Custom formats are used as pasteboard types as they are.
+func formatToUTI(format Format) string {
+	switch format {
+	case FormatText:
+		return "public.utf8-plain-text"
+	case FormatHTML:
+		return "public.html"
+	case FormatPNG:
+		return "public.png"
+	}
+	return string(format)
+}
+
+func utiToFormat(uti string) Format {
+	switch uti {
+	case "public.utf8-plain-text":
+		return FormatText
+	case "public.html":
+		return FormatHTML
+	case "public.png":
+		return FormatPNG
+	}
+	return Format(uti)
+}
+
+// darwinBackend is a backend using NSPasteboard.
+type darwinBackend struct{}
+
+// pasteboardM protects the general pasteboard, as the backend can be used from multiple goroutines.
+var pasteboardM sync.Mutex
+
+func generalPasteboard() objc.ID {
+	return idNSPasteboard.Send(selGeneralPasteboard)
+}
+
+func (darwinBackend) Formats() ([]Format, error) {
+	pasteboardM.Lock()
+	defer pasteboardM.Unlock()
+
+	types := generalPasteboard().Send(selTypes)
+	if types == 0 {
+		return nil, nil
+	}
+	n := objc.Send[uint](types, selCount)
+	var formats []Format
+	for i := range n {
+		uti := objc.Send[string](types.Send(selObjectAtIndex, i), selUTF8String)
+		if format := utiToFormat(uti); !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	return formats, nil
+}
+
+func (darwinBackend) Read(format Format) ([]byte, error) {
+	pasteboardM.Lock()
+	defer pasteboardM.Unlock()
+
+	uti := idNSString.Send(selStringWithUTF8, formatToUTI(format))
+	data := generalPasteboard().Send(selDataForType, uti)
+	if data == 0 {
+		return nil, ErrFormatUnavailable
+	}
+	n := objc.Send[uint](data, selLength)
+	if n == 0 {
+		return []byte{}, nil
+	}
+	ptr := objc.Send[unsafe.Pointer](data, selBytes)
+	return slices.Clone(unsafe.Slice((*byte)(ptr), n)), nil
+}
+
+func (darwinBackend) Write(items []Item) error {
+	pasteboardM.Lock()
+	defer pasteboardM.Unlock()
+
+	pasteboard := generalPasteboard()
+	pasteboard.Send(selClearContents)
+	for _, item := range items {
+		var ptr unsafe.Pointer
+		if len(item.Data) > 0 {
+			ptr = unsafe.Pointer(&item.Data[0])
+		}
+		data := idNSData.Send(selDataWithBytesLength, ptr, uint(len(item.Data)))
+		uti := idNSString.Send(selStringWithUTF8, formatToUTI(item.Format))
+		if !objc.Send[bool](pasteboard, selSetDataForType, data, uti) {
+			return errors.New("clipboard: setData:forType: failed")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package clipboard
+
+import (
+	"errors"
+	"slices"
+	"syscall/js"
+)
+
+func systemBackend() Backend {
+	return jsBackend{}
+}
+
+// jsBackend is a backend using the asynchronous Clipboard API.
+type jsBackend struct{}
+
+func await(promise js.Value) (js.Value, error) {
+	ch := make(chan js.Value, 1)
+	errCh := make(chan error, 1)
+	then := js.FuncOf(func(this js.Value, args []js.Value) any {
+		ch <- args[0]
+		return nil
+	})
+	defer then.Release()
+	catch := js.FuncOf(func(this js.Value, args []js.Value) any {
+		errCh <- errors.New("clipboard: " + args[0].Call("toString").String())
+		return nil
+	})
+	defer catch.Release()
+	promise.Call("then", then, catch)
+	select {
+	case v := <-ch:
+		return v, nil
+	case err := <-errCh:
+		return js.Undefined(), err
+	}
+}
+
+func clipboard() js.Value {
+	return js.Global().Get("navigator").Get("clipboard")
+}
+
+func (jsBackend) readItem() (js.Value, error) {
+	items, err := await(clipboard().Call("read"))
+	if err != nil {
+		return js.Undefined(), err
+	}
+	if items.Length() == 0 {
+		return js.Undefined(), nil
+	}
+	return items.Index(0), nil
+}
+
+func (j jsBackend) Formats() ([]Format, error) {
+	item, err := j.readItem()
+	if err != nil {
+		return nil, err
+	}
+	if item.IsUndefined() {
+		return nil, nil
+	}
+	types := item.Get("types")
+	var formats []Format
+	for i := range types.Length() {
+		format := Format(types.Index(i).String())
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	return formats, nil
+}
+
+func (j jsBackend) Read(format Format) ([]byte, error) {
+	if format == FormatText {
+		text, err := await(clipboard().Call("readText"))
+		if err != nil {
+			return nil, err
+		}
+		return []byte(text.String()), nil
+	}
+
+	item, err := j.readItem()
+	if err != nil {
+		return nil, err
+	}
+	if item.IsUndefined() {
+		return nil, ErrFormatUnavailable
+	}
+	if !item.Get("types").Call("includes", string(format)).Bool() {
+		return nil, ErrFormatUnavailable
+	}
+	blob, err := await(item.Call("getType", string(format)))
+	if err != nil {
+		return nil, err
+	}
+	buf, err := await(blob.Call("arrayBuffer"))
+	if err != nil {
+		return nil, err
+	}
+	u8 := js.Global().Get("Uint8Array").New(buf)
+	data := make([]byte, u8.Length())
+	js.CopyBytesToGo(data, u8)
+	return data, nil
+}
+
+func (jsBackend) Write(items []Item) error {
+	if len(items) == 1 && items[0].Format == FormatText {
+		_, err := await(clipboard().Call("writeText", string(items[0].Data)))
+		return err
+	}
+
+	obj := js.Global().Get("Object").New()
+	for _, item := range items {
+		u8 := js.Global().Get("Uint8Array").New(len(item.Data))
+		js.CopyBytesToJS(u8, item.Data)
+		blob := js.Global().Get("Blob").New([]any{u8}, map[string]any{
+			"type": string(item.Format),
+		})
+		obj.Set(string(item.Format), blob)
+	}
+	clipboardItem := js.Global().Get("ClipboardItem").New(obj)
+	_, err := await(clipboard().Call("write", []any{clipboardItem}))
+	return err
+}
@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+//go:build !darwin && !freebsd && !js && !(linux && !android) && !netbsd && !openbsd && !windows
+
+package clipboard
+
+var theMemoryBackend MemoryBackend
+
+// systemBackend returns a backend shared only in the process, as there is no system clipboard support on this platform.
+func systemBackend() Backend {
+	return &theMemoryBackend
+}
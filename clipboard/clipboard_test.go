@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package clipboard_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/guigui/clipboard"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	prev := clipboard.SetBackend(&clipboard.MemoryBackend{})
+	defer clipboard.SetBackend(prev)
+
+	if err := clipboard.Write(
+		clipboard.Item{Format: clipboard.FormatText, Data: []byte("hello")},
+		clipboard.Item{Format: clipboard.FormatHTML, Data: []byte("<b>hello</b>")},
+		clipboard.Item{Format: "application/x-guigui-test", Data: []byte{1, 2, 3}},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	formats, err := clipboard.Formats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := formats, []clipboard.Format{clipboard.FormatText, clipboard.FormatHTML, "application/x-guigui-test"}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	text, err := clipboard.ReadText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := text, "hello"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	data, err := clipboard.Read("application/x-guigui-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := data, []byte{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	if _, err := clipboard.Read(clipboard.FormatPNG); !errors.Is(err, clipboard.ErrFormatUnavailable) {
+		t.Errorf("got: %v, want: %v", err, clipboard.ErrFormatUnavailable)
+	}
+
+	// Writing replaces all the formats.
+	if err := clipboard.WriteText("world"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clipboard.Read(clipboard.FormatHTML); !errors.Is(err, clipboard.ErrFormatUnavailable) {
+		t.Errorf("got: %v, want: %v", err, clipboard.ErrFormatUnavailable)
+	}
+
+	ch := make(chan string)
+	clipboard.ReadAsync(clipboard.FormatText, func(data []byte, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		ch <- string(data)
+	})
+	if got, want := <-ch, "world"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
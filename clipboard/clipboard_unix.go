@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+//go:build (linux && !android) || freebsd || netbsd || openbsd
+
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var theSystemBackend = sync.OnceValue(func() Backend {
+	// Prefer Wayland, as an app on XWayland might not be able to read the clipboard without the focus.
+	w, werr := newWaylandBackend()
+	if werr == nil {
+		return w
+	}
+	x, xerr := newX11Backend()
+	if xerr == nil {
+		return x
+	}
+	err := errors.Join(werr, xerr)
+	slog.Error("clipboard: the system clipboard is not available", "error", err)
+	return unavailableBackend{err: err}
+})
+
+func systemBackend() Backend {
+	return theSystemBackend()
+}
+
+// unavailableBackend is a backend that always fails, used when neither Wayland nor X11 is available.
+type unavailableBackend struct {
+	err error
+}
+
+func (u unavailableBackend) Formats() ([]Format, error) {
+	return nil, fmt.Errorf("clipboard: the system clipboard is not available: %w", u.err)
+}
+
+func (u unavailableBackend) Read(format Format) ([]byte, error) {
+	return nil, fmt.Errorf("clipboard: the system clipboard is not available: %w", u.err)
+}
+
+func (u unavailableBackend) Write(items []Item) error {
+	return fmt.Errorf("clipboard: the system clipboard is not available: %w", u.err)
+}
+
+// textTargets are the X11 targets and the Wayland MIME types for FormatText in the order of preference.
+var textTargets = []string{"UTF8_STRING", "text/plain;charset=utf-8", "text/plain", "STRING", "TEXT"}
+
+func targetToFormat(target string) (Format, bool) {
+	if slices.Contains(textTargets, target) {
+		return FormatText, true
+	}
+	if strings.Contains(target, "/") {
+		return Format(target), true
+	}
+	return "", false
+}
+
+// formatToTargets returns the X11 targets or the Wayland MIME types to offer for format.
+func formatToTargets(format Format) []string {
+	if format == FormatText {
+		return textTargets
+	}
+	return []string{string(format)}
+}
@@ -0,0 +1,623 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+//go:build (linux && !android) || freebsd || netbsd || openbsd
+
+package clipboard
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const waylandTimeout = time.Second
+
+// waylandDisplayID is the object ID of wl_display, which always exists.
+const waylandDisplayID = 1
+
+// Opcodes of the Wayland requests and events used by waylandBackend.
+//
+// ext_data_control_v1 and zwlr_data_control_v1 have the same requests and events in the same order.
+const (
+	wlDisplayRequestSync        = 0
+	wlDisplayRequestGetRegistry = 1
+	wlDisplayEventError         = 0
+
+	wlRegistryRequestBind = 0
+	wlRegistryEventGlobal = 0
+
+	wlCallbackEventDone = 0
+
+	dataControlManagerRequestCreateDataSource = 0
+	dataControlManagerRequestGetDataDevice    = 1
+
+	dataControlDeviceRequestSetSelection = 0
+	dataControlDeviceEventDataOffer      = 0
+	dataControlDeviceEventSelection      = 1
+	dataControlDeviceEventFinished       = 2
+
+	dataControlSourceRequestOffer   = 0
+	dataControlSourceRequestDestroy = 1
+	dataControlSourceEventSend      = 0
+	dataControlSourceEventCancelled = 1
+
+	dataControlOfferRequestReceive = 0
+	dataControlOfferRequestDestroy = 1
+	dataControlOfferEventOffer     = 0
+)
+
+// dataControlManagers are the interface names of the data control managers in the order of preference.
+var dataControlManagers = []string{"ext_data_control_manager_v1", "zwlr_data_control_manager_v1"}
+
+// waylandFD is a file descriptor argument of a Wayland request.
+// File descriptors are sent as ancillary data instead of the message body.
+type waylandFD int
+
+func encodeWaylandMessage(object uint32, opcode uint16, args ...any) ([]byte, []int) {
+	data := make([]byte, 8, 32)
+	var fds []int
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case uint32:
+			data = binary.NativeEndian.AppendUint32(data, arg)
+		case string:
+			data = binary.NativeEndian.AppendUint32(data, uint32(len(arg)+1))
+			data = append(data, arg...)
+			data = append(data, 0)
+			for len(data)%4 != 0 {
+				data = append(data, 0)
+			}
+		case waylandFD:
+			fds = append(fds, int(arg))
+		default:
+			panic(fmt.Sprintf("clipboard: unexpected Wayland argument type: %T", arg))
+		}
+	}
+	binary.NativeEndian.PutUint32(data[0:], object)
+	binary.NativeEndian.PutUint32(data[4:], uint32(len(data))<<16|uint32(opcode))
+	return data, fds
+}
+
+// waylandArgs decodes the arguments of a Wayland event.
+type waylandArgs struct {
+	data []byte
+	err  error
+}
+
+func (w *waylandArgs) uint32() uint32 {
+	if len(w.data) < 4 {
+		w.err = errors.New("clipboard: Wayland message too short")
+		return 0
+	}
+	v := binary.NativeEndian.Uint32(w.data)
+	w.data = w.data[4:]
+	return v
+}
+
+func (w *waylandArgs) string() string {
+	n := int(w.uint32())
+	if n == 0 {
+		return ""
+	}
+	padded := (n + 3) &^ 3
+	if len(w.data) < padded {
+		w.err = errors.New("clipboard: Wayland message too short")
+		return ""
+	}
+	s := string(w.data[:n-1])
+	w.data = w.data[padded:]
+	return s
+}
+
+type waylandGlobal struct {
+	name    uint32
+	iface   string
+	version uint32
+}
+
+// waylandBackend is a backend using the data control protocol of Wayland directly.
+//
+// The data control protocol (ext-data-control-v1 or wlr-data-control-unstable-v1) lets a client without a surface access the clipboard.
+// The core wl_data_device protocol is not used, as it requires the keyboard focus of a surface owned by the connection.
+type waylandBackend struct {
+	conn   *net.UnixConn
+	writeM sync.Mutex
+
+	// The fields below are protected by m.
+	m          sync.Mutex
+	nextID     uint32
+	registryID uint32
+	deviceID   uint32
+	managerID  uint32
+	globals    []waylandGlobal
+	callbacks  map[uint32]chan struct{}
+	offers     map[uint32][]string
+	selection  uint32
+	sources    map[uint32][]Item
+	sourceID   uint32
+	fds        []int
+	err        error
+}
+
+func waylandSocketPath() (string, error) {
+	display := os.Getenv("WAYLAND_DISPLAY")
+	if display == "" {
+		return "", errors.New("clipboard: WAYLAND_DISPLAY is not set")
+	}
+	if filepath.IsAbs(display) {
+		return display, nil
+	}
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		return "", errors.New("clipboard: XDG_RUNTIME_DIR is not set")
+	}
+	return filepath.Join(dir, display), nil
+}
+
+func newWaylandBackend() (*waylandBackend, error) {
+	path, err := waylandSocketPath()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	w, err := newWaylandBackendWithConn(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func newWaylandBackendWithConn(conn *net.UnixConn) (*waylandBackend, error) {
+	w := &waylandBackend{
+		conn:      conn,
+		nextID:    waylandDisplayID + 1,
+		callbacks: map[uint32]chan struct{}{},
+		offers:    map[uint32][]string{},
+		sources:   map[uint32][]Item{},
+	}
+	go w.loop()
+
+	w.m.Lock()
+	w.registryID = w.newID()
+	err := w.request(waylandDisplayID, wlDisplayRequestGetRegistry, w.registryID)
+	w.m.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.roundtrip(); err != nil {
+		return nil, err
+	}
+
+	w.m.Lock()
+	seat := slices.IndexFunc(w.globals, func(g waylandGlobal) bool {
+		return g.iface == "wl_seat"
+	})
+	manager := -1
+	for _, name := range dataControlManagers {
+		if manager = slices.IndexFunc(w.globals, func(g waylandGlobal) bool {
+			return g.iface == name
+		}); manager >= 0 {
+			break
+		}
+	}
+	if seat < 0 || manager < 0 {
+		w.m.Unlock()
+		return nil, errors.New("clipboard: the Wayland compositor does not support the data control protocol")
+	}
+	seatID, err := w.bind(&w.globals[seat])
+	if err != nil {
+		w.m.Unlock()
+		return nil, err
+	}
+	if w.managerID, err = w.bind(&w.globals[manager]); err != nil {
+		w.m.Unlock()
+		return nil, err
+	}
+	w.deviceID = w.newID()
+	err = w.request(w.managerID, dataControlManagerRequestGetDataDevice, w.deviceID, seatID)
+	w.m.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Receive the current selection.
+	if err := w.roundtrip(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// newID returns a new object ID. newID must be called with m locked.
+func (w *waylandBackend) newID() uint32 {
+	id := w.nextID
+	w.nextID++
+	return id
+}
+
+// bind binds the global with version 1 and returns the new object ID. bind must be called with m locked.
+func (w *waylandBackend) bind(global *waylandGlobal) (uint32, error) {
+	id := w.newID()
+	if err := w.request(w.registryID, wlRegistryRequestBind, global.name, global.iface, uint32(1), id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (w *waylandBackend) request(object uint32, opcode uint16, args ...any) error {
+	data, fds := encodeWaylandMessage(object, opcode, args...)
+	var oob []byte
+	if len(fds) > 0 {
+		oob = unix.UnixRights(fds...)
+	}
+	w.writeM.Lock()
+	defer w.writeM.Unlock()
+	if _, _, err := w.conn.WriteMsgUnix(data, oob, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// roundtrip waits until the compositor processes all the requests sent so far.
+func (w *waylandBackend) roundtrip() error {
+	ch := make(chan struct{})
+	w.m.Lock()
+	if w.err != nil {
+		err := w.err
+		w.m.Unlock()
+		return err
+	}
+	id := w.newID()
+	w.callbacks[id] = ch
+	err := w.request(waylandDisplayID, wlDisplayRequestSync, id)
+	w.m.Unlock()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ch:
+	case <-time.After(waylandTimeout):
+		w.m.Lock()
+		delete(w.callbacks, id)
+		w.m.Unlock()
+		return errors.New("clipboard: timed out to wait for the Wayland compositor")
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.err
+}
+
+func (w *waylandBackend) loop() {
+	var buf []byte
+	data := make([]byte, 4096)
+	oob := make([]byte, unix.CmsgSpace(28*4))
+	for {
+		n, oobn, _, _, err := w.conn.ReadMsgUnix(data, oob)
+		if err != nil {
+			w.fail(err)
+			return
+		}
+		if oobn > 0 {
+			if err := w.appendFDs(oob[:oobn]); err != nil {
+				w.fail(err)
+				return
+			}
+		}
+		buf = append(buf, data[:n]...)
+
+		for len(buf) >= 8 {
+			size := int(binary.NativeEndian.Uint32(buf[4:]) >> 16)
+			if size < 8 {
+				w.fail(errors.New("clipboard: invalid Wayland message size"))
+				return
+			}
+			if len(buf) < size {
+				break
+			}
+			object := binary.NativeEndian.Uint32(buf[0:])
+			opcode := uint16(binary.NativeEndian.Uint32(buf[4:]))
+			if err := w.handleEvent(object, opcode, &waylandArgs{data: buf[8:size]}); err != nil {
+				w.fail(err)
+				return
+			}
+			buf = buf[size:]
+		}
+		buf = slices.Clip(buf)
+	}
+}
+
+func (w *waylandBackend) appendFDs(oob []byte) error {
+	msgs, err := unix.ParseSocketControlMessage(oob)
+	if err != nil {
+		return err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	for _, msg := range msgs {
+		fds, err := unix.ParseUnixRights(&msg)
+		if err != nil {
+			return err
+		}
+		w.fds = append(w.fds, fds...)
+	}
+	return nil
+}
+
+// fail records the fatal error and stops the pending roundtrips.
+func (w *waylandBackend) fail(err error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.err == nil {
+		w.err = err
+	}
+	for id, ch := range w.callbacks {
+		close(ch)
+		delete(w.callbacks, id)
+	}
+	for _, fd := range w.fds {
+		unix.Close(fd)
+	}
+	w.fds = nil
+}
+
+func (w *waylandBackend) handleEvent(object uint32, opcode uint16, args *waylandArgs) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	_, isOffer := w.offers[object]
+	_, isSource := w.sources[object]
+
+	switch {
+	case object == waylandDisplayID:
+		if opcode == wlDisplayEventError {
+			id := args.uint32()
+			code := args.uint32()
+			msg := args.string()
+			return fmt.Errorf("clipboard: Wayland error: object: %d, code: %d, message: %s", id, code, msg)
+		}
+		// Object IDs are never reused, and delete_id can be ignored.
+
+	case object == w.registryID:
+		if opcode == wlRegistryEventGlobal {
+			name := args.uint32()
+			iface := args.string()
+			version := args.uint32()
+			w.globals = append(w.globals, waylandGlobal{
+				name:    name,
+				iface:   iface,
+				version: version,
+			})
+		}
+
+	case w.callbacks[object] != nil:
+		if opcode == wlCallbackEventDone {
+			close(w.callbacks[object])
+			delete(w.callbacks, object)
+		}
+
+	case object == w.deviceID:
+		switch opcode {
+		case dataControlDeviceEventDataOffer:
+			w.offers[args.uint32()] = nil
+		case dataControlDeviceEventSelection:
+			id := args.uint32()
+			if w.selection != 0 && w.selection != id {
+				delete(w.offers, w.selection)
+				if err := w.request(w.selection, dataControlOfferRequestDestroy); err != nil {
+					return err
+				}
+			}
+			w.selection = id
+		case dataControlDeviceEventFinished:
+			return errors.New("clipboard: the Wayland data device is no longer valid")
+		}
+
+	case isOffer:
+		if opcode == dataControlOfferEventOffer {
+			w.offers[object] = append(w.offers[object], args.string())
+		}
+
+	case isSource:
+		switch opcode {
+		case dataControlSourceEventSend:
+			mime := args.string()
+			if len(w.fds) == 0 {
+				return errors.New("clipboard: a file descriptor is missing")
+			}
+			fd := w.fds[0]
+			w.fds = w.fds[1:]
+			data, ok := itemData(w.sources[object], mime)
+			go func() {
+				f := os.NewFile(uintptr(fd), "clipboard")
+				defer f.Close()
+				if !ok {
+					return
+				}
+				if _, err := f.Write(data); err != nil {
+					slog.Error(err.Error())
+				}
+			}()
+		case dataControlSourceEventCancelled:
+			delete(w.sources, object)
+			if w.sourceID == object {
+				w.sourceID = 0
+			}
+			if err := w.request(object, dataControlSourceRequestDestroy); err != nil {
+				return err
+			}
+		}
+	}
+
+	return args.err
+}
+
+// itemData returns the data of the item for the MIME type.
+func itemData(items []Item, mime string) ([]byte, bool) {
+	format, ok := targetToFormat(mime)
+	if !ok {
+		return nil, false
+	}
+	idx := slices.IndexFunc(items, func(item Item) bool {
+		return item.Format == format
+	})
+	if idx < 0 {
+		return nil, false
+	}
+	return items[idx].Data, true
+}
+
+// ownedItems returns the items written by the backend if the backend owns the clipboard.
+// ownedItems must be called with m locked.
+func (w *waylandBackend) ownedItems() ([]Item, bool) {
+	if w.sourceID == 0 {
+		return nil, false
+	}
+	return w.sources[w.sourceID], true
+}
+
+func (w *waylandBackend) Formats() ([]Format, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	if items, ok := w.ownedItems(); ok {
+		formats := make([]Format, 0, len(items))
+		for _, item := range items {
+			formats = append(formats, item.Format)
+		}
+		return formats, nil
+	}
+
+	var formats []Format
+	for _, mime := range w.offers[w.selection] {
+		format, ok := targetToFormat(mime)
+		if !ok || slices.Contains(formats, format) {
+			continue
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+func (w *waylandBackend) Read(format Format) ([]byte, error) {
+	w.m.Lock()
+	if w.err != nil {
+		err := w.err
+		w.m.Unlock()
+		return nil, err
+	}
+
+	if items, ok := w.ownedItems(); ok {
+		defer w.m.Unlock()
+		data, ok := itemData(items, string(format))
+		if !ok {
+			return nil, ErrFormatUnavailable
+		}
+		return slices.Clone(data), nil
+	}
+
+	mimes := w.offers[w.selection]
+	var mime string
+	if format == FormatText {
+		for _, t := range textTargets {
+			if slices.Contains(mimes, t) {
+				mime = t
+				break
+			}
+		}
+	} else if slices.Contains(mimes, string(format)) {
+		mime = string(format)
+	}
+	if mime == "" {
+		w.m.Unlock()
+		return nil, ErrFormatUnavailable
+	}
+
+	var p [2]int
+	if err := unix.Pipe2(p[:], unix.O_CLOEXEC); err != nil {
+		w.m.Unlock()
+		return nil, err
+	}
+	// Only the reading end is non-blocking, so that the reading can time out.
+	if err := unix.SetNonblock(p[0], true); err != nil {
+		unix.Close(p[0])
+		unix.Close(p[1])
+		w.m.Unlock()
+		return nil, err
+	}
+	f := os.NewFile(uintptr(p[0]), "clipboard")
+	defer f.Close()
+
+	// Send the request with m locked, so that the offer is not destroyed in between.
+	err := w.request(w.selection, dataControlOfferRequestReceive, mime, waylandFD(p[1]))
+	w.m.Unlock()
+	unix.Close(p[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.SetReadDeadline(time.Now().Add(waylandTimeout)); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return nil, errors.New("clipboard: timed out to read the clipboard")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (w *waylandBackend) Write(items []Item) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+
+	cloned := make([]Item, 0, len(items))
+	for _, item := range items {
+		cloned = append(cloned, Item{
+			Format: item.Format,
+			Data:   slices.Clone(item.Data),
+		})
+	}
+
+	id := w.newID()
+	if err := w.request(w.managerID, dataControlManagerRequestCreateDataSource, id); err != nil {
+		return err
+	}
+	for _, item := range cloned {
+		for _, mime := range formatToTargets(item.Format) {
+			if err := w.request(id, dataControlSourceRequestOffer, mime); err != nil {
+				return err
+			}
+		}
+	}
+	if err := w.request(w.deviceID, dataControlDeviceRequestSetSelection, id); err != nil {
+		return err
+	}
+	// The previous source is cancelled by the compositor.
+	w.sources[id] = cloned
+	w.sourceID = id
+	return nil
+}
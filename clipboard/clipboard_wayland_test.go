@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+//go:build linux && !android
+
+package clipboard
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// fakeCompositor is a Wayland compositor that implements only the data control protocol.
+type fakeCompositor struct {
+	t    *testing.T
+	conn *net.UnixConn
+
+	m           sync.Mutex
+	registryID  uint32
+	managerID   uint32
+	deviceID    uint32
+	sourceMIMEs map[uint32][]string
+	sourceID    uint32
+	offerData   map[uint32]map[string]string
+	nextOfferID uint32
+}
+
+func newFakeCompositor(t *testing.T) (*fakeCompositor, *net.UnixConn) {
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newConn := func(fd int) *net.UnixConn {
+		f := os.NewFile(uintptr(fd), "wayland")
+		defer f.Close()
+		c, err := net.FileConn(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.(*net.UnixConn)
+	}
+	f := &fakeCompositor{
+		t:           t,
+		conn:        newConn(fds[0]),
+		sourceMIMEs: map[uint32][]string{},
+		offerData:   map[uint32]map[string]string{},
+		nextOfferID: 0xff000000,
+	}
+	client := newConn(fds[1])
+	t.Cleanup(func() {
+		f.conn.Close()
+		client.Close()
+	})
+	go f.loop()
+	return f, client
+}
+
+func (f *fakeCompositor) send(object uint32, opcode uint16, args ...any) {
+	data, fds := encodeWaylandMessage(object, opcode, args...)
+	var oob []byte
+	if len(fds) > 0 {
+		oob = unix.UnixRights(fds...)
+	}
+	if _, _, err := f.conn.WriteMsgUnix(data, oob, nil); err != nil {
+		f.t.Error(err)
+	}
+}
+
+// setSelection makes a new offer with the MIME types and the data, and sets it as the selection.
+// setSelection must be called with m locked.
+func (f *fakeCompositor) setSelection(data map[string]string) {
+	id := f.nextOfferID
+	f.nextOfferID++
+	f.offerData[id] = data
+	f.send(f.deviceID, dataControlDeviceEventDataOffer, id)
+	mimes := make([]string, 0, len(data))
+	for mime := range data {
+		mimes = append(mimes, mime)
+	}
+	slices.Sort(mimes)
+	for _, mime := range mimes {
+		f.send(id, dataControlOfferEventOffer, mime)
+	}
+	f.send(f.deviceID, dataControlDeviceEventSelection, id)
+}
+
+// paste requests the data of the current source as another client does.
+func (f *fakeCompositor) paste(mime string) string {
+	var p [2]int
+	if err := unix.Pipe2(p[:], unix.O_CLOEXEC); err != nil {
+		f.t.Fatal(err)
+	}
+	r := os.NewFile(uintptr(p[0]), "r")
+	defer r.Close()
+
+	f.m.Lock()
+	f.send(f.sourceID, dataControlSourceEventSend, mime, waylandFD(p[1]))
+	f.m.Unlock()
+	unix.Close(p[1])
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return string(data)
+}
+
+func (f *fakeCompositor) loop() {
+	var pending []byte
+	buf := make([]byte, 4096)
+	oob := make([]byte, unix.CmsgSpace(4))
+	for {
+		n, oobn, _, _, err := f.conn.ReadMsgUnix(buf, oob)
+		if err != nil {
+			return
+		}
+		var fds []int
+		if oobn > 0 {
+			msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+			if err != nil {
+				f.t.Error(err)
+				return
+			}
+			for _, msg := range msgs {
+				rights, err := unix.ParseUnixRights(&msg)
+				if err != nil {
+					f.t.Error(err)
+					return
+				}
+				fds = append(fds, rights...)
+			}
+		}
+		pending = append(pending, buf[:n]...)
+		data := pending
+		for len(data) >= 8 {
+			size := int(binary.NativeEndian.Uint32(data[4:]) >> 16)
+			if len(data) < size {
+				break
+			}
+			object := binary.NativeEndian.Uint32(data[0:])
+			opcode := uint16(binary.NativeEndian.Uint32(data[4:]))
+			fds = f.handleRequest(object, opcode, &waylandArgs{data: data[8:size]}, fds)
+			data = data[size:]
+		}
+		pending = slices.Clone(data)
+	}
+}
+
+func (f *fakeCompositor) handleRequest(object uint32, opcode uint16, args *waylandArgs, fds []int) []int {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	switch {
+	case object == waylandDisplayID && opcode == wlDisplayRequestGetRegistry:
+		f.registryID = args.uint32()
+		f.send(f.registryID, wlRegistryEventGlobal, uint32(1), "wl_seat", uint32(7))
+		f.send(f.registryID, wlRegistryEventGlobal, uint32(2), "ext_data_control_manager_v1", uint32(1))
+	case object == waylandDisplayID && opcode == wlDisplayRequestSync:
+		f.send(args.uint32(), wlCallbackEventDone, uint32(0))
+	case object == f.registryID && opcode == wlRegistryRequestBind:
+		_ = args.uint32()
+		iface := args.string()
+		_ = args.uint32()
+		id := args.uint32()
+		if iface == "ext_data_control_manager_v1" {
+			f.managerID = id
+		}
+	case object == f.managerID && opcode == dataControlManagerRequestGetDataDevice:
+		f.deviceID = args.uint32()
+		f.setSelection(map[string]string{
+			"text/plain;charset=utf-8": "foreign",
+			"image/png":                "png",
+		})
+	case object == f.managerID && opcode == dataControlManagerRequestCreateDataSource:
+		f.sourceMIMEs[args.uint32()] = []string{}
+	case f.sourceMIMEs[object] != nil && opcode == dataControlSourceRequestOffer:
+		f.sourceMIMEs[object] = append(f.sourceMIMEs[object], args.string())
+	case object == f.deviceID && opcode == dataControlDeviceRequestSetSelection:
+		id := args.uint32()
+		if f.sourceID != 0 {
+			f.send(f.sourceID, dataControlSourceEventCancelled)
+		}
+		f.sourceID = id
+		// The compositor notifies the new selection to all the data devices including the source's.
+		data := map[string]string{}
+		for _, mime := range f.sourceMIMEs[id] {
+			data[mime] = ""
+		}
+		f.setSelection(data)
+	case f.offerData[object] != nil && opcode == dataControlOfferRequestReceive:
+		mime := args.string()
+		fd := fds[0]
+		fds = fds[1:]
+		w := os.NewFile(uintptr(fd), "w")
+		if _, err := w.WriteString(f.offerData[object][mime]); err != nil {
+			f.t.Error(err)
+		}
+		w.Close()
+	}
+	if args.err != nil {
+		f.t.Error(args.err)
+	}
+	return fds
+}
+
+func TestWaylandBackend(t *testing.T) {
+	f, conn := newFakeCompositor(t)
+	w, err := newWaylandBackendWithConn(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Read the content of another client.
+	formats, err := w.Formats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := formats, []Format{FormatPNG, FormatText}; !slices.Equal(got, want) {
+		t.Errorf("Formats: got: %v, want: %v", got, want)
+	}
+	data, err := w.Read(FormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "foreign"; got != want {
+		t.Errorf("Read: got: %q, want: %q", got, want)
+	}
+
+	// Write the content, and paste it from another client.
+	if err := w.Write([]Item{
+		{Format: FormatText, Data: []byte("hello")},
+		{Format: "application/x-guigui-test", Data: []byte("custom")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.roundtrip(); err != nil {
+		t.Fatal(err)
+	}
+	f.m.Lock()
+	mimes := slices.Clone(f.sourceMIMEs[f.sourceID])
+	f.m.Unlock()
+	if want := append(slices.Clone(textTargets), "application/x-guigui-test"); !slices.Equal(mimes, want) {
+		t.Errorf("offered MIME types: got: %v, want: %v", mimes, want)
+	}
+	if got, want := f.paste("text/plain;charset=utf-8"), "hello"; got != want {
+		t.Errorf("pasted text: got: %q, want: %q", got, want)
+	}
+	if got, want := f.paste("application/x-guigui-test"), "custom"; got != want {
+		t.Errorf("pasted custom data: got: %q, want: %q", got, want)
+	}
+	if got, want := f.paste("image/png"), ""; got != want {
+		t.Errorf("pasted unavailable data: got: %q, want: %q", got, want)
+	}
+	data, err = w.Read("application/x-guigui-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "custom"; got != want {
+		t.Errorf("Read: got: %q, want: %q", got, want)
+	}
+
+	// Another client takes the clipboard.
+	f.m.Lock()
+	f.send(f.sourceID, dataControlSourceEventCancelled)
+	f.sourceID = 0
+	f.setSelection(map[string]string{
+		"UTF8_STRING": "another",
+	})
+	f.m.Unlock()
+	if err := w.roundtrip(); err != nil {
+		t.Fatal(err)
+	}
+	data, err = w.Read(FormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "another"; got != want {
+		t.Errorf("Read: got: %q, want: %q", got, want)
+	}
+	if _, err := w.Read(FormatPNG); err != ErrFormatUnavailable {
+		t.Errorf("Read: got: %v, want: %v", err, ErrFormatUnavailable)
+	}
+}
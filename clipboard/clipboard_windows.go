@@ -0,0 +1,307 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package clipboard
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	cfText        = 1
+	cfOEMText     = 7
+	cfUnicodeText = 13
+
+	gmemMoveable = 0x0002
+)
+
+var (
+	user32                       = windows.NewLazySystemDLL("user32.dll")
+	procOpenClipboard            = user32.NewProc("OpenClipboard")
+	procCloseClipboard           = user32.NewProc("CloseClipboard")
+	procEmptyClipboard           = user32.NewProc("EmptyClipboard")
+	procGetClipboardData         = user32.NewProc("GetClipboardData")
+	procSetClipboardData         = user32.NewProc("SetClipboardData")
+	procEnumClipboardFormats     = user32.NewProc("EnumClipboardFormats")
+	procGetClipboardFormatNameW  = user32.NewProc("GetClipboardFormatNameW")
+	procRegisterClipboardFormatW = user32.NewProc("RegisterClipboardFormatW")
+
+	kernel32          = windows.NewLazySystemDLL("kernel32.dll")
+	procGlobalAlloc   = kernel32.NewProc("GlobalAlloc")
+	procGlobalFree    = kernel32.NewProc("GlobalFree")
+	procGlobalLock    = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock  = kernel32.NewProc("GlobalUnlock")
+	procGlobalSize    = kernel32.NewProc("GlobalSize")
+	procRtlMoveMemory = kernel32.NewProc("RtlMoveMemory")
+)
+
+// Names of the registered clipboard formats that other apps use.
+const (
+	windowsHTMLFormatName = "HTML Format"
+	windowsPNGFormatName  = "PNG"
+)
+
+func systemBackend() Backend {
+	return windowsBackend{}
+}
+
+// windowsBackend is a backend using the Win32 clipboard API.
+//
+// FormatHTML is converted to and from the CF_HTML format, and other formats than FormatText are registered by their names.
+type windowsBackend struct{}
+
+// withClipboard opens the clipboard, calls f, and closes the clipboard.
+func withClipboard(f func() error) error {
+	// The clipboard must be closed by the thread that opens it.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Another app might have opened the clipboard.
+	var err error
+	for range 100 {
+		var r uintptr
+		r, _, err = procOpenClipboard.Call(0)
+		if r != 0 {
+			err = nil
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		return fmt.Errorf("clipboard: OpenClipboard failed: %w", err)
+	}
+	defer procCloseClipboard.Call()
+
+	return f()
+}
+
+func windowsFormatID(format Format) (uint32, error) {
+	var name string
+	switch format {
+	case FormatText:
+		return cfUnicodeText, nil
+	case FormatHTML:
+		name = windowsHTMLFormatName
+	case FormatPNG:
+		name = windowsPNGFormatName
+	default:
+		name = string(format)
+	}
+	p, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	r, _, err := procRegisterClipboardFormatW.Call(uintptr(unsafe.Pointer(p)))
+	if r == 0 {
+		return 0, fmt.Errorf("clipboard: RegisterClipboardFormatW failed: %w", err)
+	}
+	return uint32(r), nil
+}
+
+func windowsFormat(id uint32) (Format, bool) {
+	switch id {
+	case cfText, cfOEMText, cfUnicodeText:
+		return FormatText, true
+	}
+	buf := make([]uint16, 256)
+	n, _, _ := procGetClipboardFormatNameW.Call(uintptr(id), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if n == 0 {
+		return "", false
+	}
+	switch name := string(utf16.Decode(buf[:n])); name {
+	case windowsHTMLFormatName:
+		return FormatHTML, true
+	case windowsPNGFormatName:
+		return FormatPNG, true
+	default:
+		if !strings.Contains(name, "/") {
+			return "", false
+		}
+		return Format(name), true
+	}
+}
+
+func (windowsBackend) Formats() ([]Format, error) {
+	var formats []Format
+	if err := withClipboard(func() error {
+		var id uintptr
+		for {
+			var err error
+			id, _, err = procEnumClipboardFormats.Call(id)
+			if id == 0 {
+				if !errors.Is(err, windows.ERROR_SUCCESS) {
+					return fmt.Errorf("clipboard: EnumClipboardFormats failed: %w", err)
+				}
+				return nil
+			}
+			format, ok := windowsFormat(uint32(id))
+			if !ok || slices.Contains(formats, format) {
+				continue
+			}
+			formats = append(formats, format)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return formats, nil
+}
+
+func (windowsBackend) Read(format Format) ([]byte, error) {
+	id, err := windowsFormatID(format)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if err := withClipboard(func() error {
+		h, _, _ := procGetClipboardData.Call(uintptr(id))
+		if h == 0 {
+			return ErrFormatUnavailable
+		}
+		size, _, _ := procGlobalSize.Call(h)
+		p, _, err := procGlobalLock.Call(h)
+		if p == 0 {
+			return fmt.Errorf("clipboard: GlobalLock failed: %w", err)
+		}
+		defer procGlobalUnlock.Call(h)
+		data = make([]byte, size)
+		if size > 0 {
+			procRtlMoveMemory.Call(uintptr(unsafe.Pointer(&data[0])), p, size)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatText:
+		u := make([]uint16, len(data)/2)
+		for i := range u {
+			u[i] = binary.LittleEndian.Uint16(data[2*i:])
+		}
+		if i := slices.Index(u, 0); i >= 0 {
+			u = u[:i]
+		}
+		return []byte(string(utf16.Decode(u))), nil
+	case FormatHTML:
+		return decodeCFHTML(data), nil
+	}
+	return data, nil
+}
+
+func (windowsBackend) Write(items []Item) error {
+	return withClipboard(func() error {
+		if r, _, err := procEmptyClipboard.Call(); r == 0 {
+			return fmt.Errorf("clipboard: EmptyClipboard failed: %w", err)
+		}
+		for _, item := range items {
+			id, err := windowsFormatID(item.Format)
+			if err != nil {
+				return err
+			}
+
+			data := item.Data
+			switch item.Format {
+			case FormatText:
+				u := utf16.Encode([]rune(string(data)))
+				u = append(u, 0)
+				data = make([]byte, 2*len(u))
+				for i, c := range u {
+					binary.LittleEndian.PutUint16(data[2*i:], c)
+				}
+			case FormatHTML:
+				data = encodeCFHTML(data)
+			}
+
+			h, err := globalAlloc(data)
+			if err != nil {
+				return err
+			}
+			// The system owns the memory after SetClipboardData succeeds.
+			if r, _, err := procSetClipboardData.Call(uintptr(id), h); r == 0 {
+				procGlobalFree.Call(h)
+				return fmt.Errorf("clipboard: SetClipboardData failed: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// globalAlloc returns a movable global memory object with a copy of data, as SetClipboardData requires.
+func globalAlloc(data []byte) (uintptr, error) {
+	h, _, err := procGlobalAlloc.Call(gmemMoveable, uintptr(max(len(data), 1)))
+	if h == 0 {
+		return 0, fmt.Errorf("clipboard: GlobalAlloc failed: %w", err)
+	}
+	p, _, err := procGlobalLock.Call(h)
+	if p == 0 {
+		procGlobalFree.Call(h)
+		return 0, fmt.Errorf("clipboard: GlobalLock failed: %w", err)
+	}
+	if len(data) > 0 {
+		procRtlMoveMemory.Call(p, uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)))
+	}
+	procGlobalUnlock.Call(h)
+	return h, nil
+}
+
+const (
+	cfHTMLHeader         = "Version:0.9\r\nStartHTML:%010d\r\nEndHTML:%010d\r\nStartFragment:%010d\r\nEndFragment:%010d\r\n"
+	cfHTMLFragmentPrefix = "<html><body><!--StartFragment-->"
+	cfHTMLFragmentSuffix = "<!--EndFragment--></body></html>"
+)
+
+// encodeCFHTML wraps an HTML fragment with the header of the CF_HTML format.
+func encodeCFHTML(html []byte) []byte {
+	startHTML := len(fmt.Sprintf(cfHTMLHeader, 0, 0, 0, 0))
+	startFragment := startHTML + len(cfHTMLFragmentPrefix)
+	endFragment := startFragment + len(html)
+	endHTML := endFragment + len(cfHTMLFragmentSuffix)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, cfHTMLHeader, startHTML, endHTML, startFragment, endFragment)
+	buf.WriteString(cfHTMLFragmentPrefix)
+	buf.Write(html)
+	buf.WriteString(cfHTMLFragmentSuffix)
+	return buf.Bytes()
+}
+
+// decodeCFHTML returns the HTML fragment in data of the CF_HTML format.
+func decodeCFHTML(data []byte) []byte {
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		data = data[:i]
+	}
+	offset := func(key string) (int, bool) {
+		i := bytes.Index(data, []byte(key+":"))
+		if i < 0 {
+			return 0, false
+		}
+		v := data[i+len(key)+1:]
+		if j := bytes.IndexAny(v, "\r\n"); j >= 0 {
+			v = v[:j]
+		}
+		n, err := strconv.Atoi(string(bytes.TrimSpace(v)))
+		if err != nil || n < 0 || n > len(data) {
+			return 0, false
+		}
+		return n, true
+	}
+	start, ok1 := offset("StartFragment")
+	end, ok2 := offset("EndFragment")
+	if !ok1 || !ok2 || start > end {
+		return data
+	}
+	return data[start:end]
+}
@@ -0,0 +1,449 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+//go:build (linux && !android) || freebsd || netbsd || openbsd
+
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jezek/xgb"
+	"github.com/jezek/xgb/xproto"
+)
+
+const x11Timeout = time.Second
+
+// x11Backend is a backend using the X11 selection protocol directly.
+//
+// On Wayland, this works with XWayland, which bridges the X11 selection and the Wayland clipboard.
+type x11Backend struct {
+	conn   *xgb.Conn
+	window xproto.Window
+
+	atoms   map[string]xproto.Atom
+	atomsM  sync.Mutex
+	items   []Item
+	itemsM  sync.Mutex
+	readM   sync.Mutex
+	maxData int
+
+	selectionNotify chan xproto.SelectionNotifyEvent
+	propertyNotify  chan xproto.PropertyNotifyEvent
+
+	// incrs is the data being sent with the INCR protocol. incrs is accessed only in the event loop.
+	incrs map[x11IncrKey]*x11Incr
+}
+
+type x11IncrKey struct {
+	requestor xproto.Window
+	property  xproto.Atom
+}
+
+type x11Incr struct {
+	target   xproto.Atom
+	data     []byte
+	deadline time.Time
+}
+
+func newX11Backend() (*x11Backend, error) {
+	conn, err := xgb.NewConn()
+	if err != nil {
+		return nil, err
+	}
+
+	setup := xproto.Setup(conn)
+	screen := setup.DefaultScreen(conn)
+	window, err := xproto.NewWindowId(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := xproto.CreateWindowChecked(conn, screen.RootDepth, window, screen.Root, 0, 0, 1, 1, 0,
+		xproto.WindowClassInputOutput, screen.RootVisual,
+		xproto.CwEventMask, []uint32{xproto.EventMaskPropertyChange}).Check(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	b := &x11Backend{
+		conn:   conn,
+		window: window,
+		atoms:  map[string]xproto.Atom{},
+		// Leave some room for the ChangeProperty request header.
+		maxData:         int(setup.MaximumRequestLength)*4 - 64,
+		selectionNotify: make(chan xproto.SelectionNotifyEvent, 1),
+		propertyNotify:  make(chan xproto.PropertyNotifyEvent, 16),
+		incrs:           map[x11IncrKey]*x11Incr{},
+	}
+	go b.loop()
+	return b, nil
+}
+
+func (x *x11Backend) atom(name string) (xproto.Atom, error) {
+	x.atomsM.Lock()
+	defer x.atomsM.Unlock()
+	if atom, ok := x.atoms[name]; ok {
+		return atom, nil
+	}
+	r, err := xproto.InternAtom(x.conn, false, uint16(len(name)), name).Reply()
+	if err != nil {
+		return 0, err
+	}
+	x.atoms[name] = r.Atom
+	return r.Atom, nil
+}
+
+func (x *x11Backend) mustAtom(name string) xproto.Atom {
+	atom, err := x.atom(name)
+	if err != nil {
+		slog.Error(err.Error())
+		return xproto.AtomNone
+	}
+	return atom
+}
+
+func (x *x11Backend) atomName(atom xproto.Atom) (string, error) {
+	r, err := xproto.GetAtomName(x.conn, atom).Reply()
+	if err != nil {
+		return "", err
+	}
+	return r.Name, nil
+}
+
+func (x *x11Backend) loop() {
+	for {
+		ev, err := x.conn.WaitForEvent()
+		if ev == nil && err == nil {
+			// The connection is closed.
+			return
+		}
+		if err != nil {
+			slog.Error(err.Error())
+			continue
+		}
+		switch ev := ev.(type) {
+		case xproto.SelectionRequestEvent:
+			x.handleSelectionRequest(ev)
+		case xproto.SelectionClearEvent:
+			if ev.Selection == x.mustAtom("CLIPBOARD") {
+				x.itemsM.Lock()
+				x.items = nil
+				x.itemsM.Unlock()
+			}
+		case xproto.SelectionNotifyEvent:
+			select {
+			case x.selectionNotify <- ev:
+			default:
+			}
+		case xproto.PropertyNotifyEvent:
+			if ev.State == xproto.PropertyDelete {
+				x.continueIncr(ev)
+				continue
+			}
+			if ev.Window != x.window || ev.State != xproto.PropertyNewValue {
+				continue
+			}
+			select {
+			case x.propertyNotify <- ev:
+			default:
+			}
+		}
+	}
+}
+
+func (x *x11Backend) handleSelectionRequest(ev xproto.SelectionRequestEvent) {
+	property := ev.Property
+	// Obsolete clients might specify None as the property.
+	if property == xproto.AtomNone {
+		property = ev.Target
+	}
+
+	if !x.setRequestedProperty(ev.Requestor, property, ev.Target) {
+		property = xproto.AtomNone
+	}
+
+	notify := xproto.SelectionNotifyEvent{
+		Time:      ev.Time,
+		Requestor: ev.Requestor,
+		Selection: ev.Selection,
+		Target:    ev.Target,
+		Property:  property,
+	}
+	xproto.SendEvent(x.conn, false, ev.Requestor, xproto.EventMaskNoEvent, string(notify.Bytes()))
+}
+
+func (x *x11Backend) setRequestedProperty(requestor xproto.Window, property, target xproto.Atom) bool {
+	x.itemsM.Lock()
+	defer x.itemsM.Unlock()
+
+	if len(x.items) == 0 {
+		return false
+	}
+
+	if target == x.mustAtom("TARGETS") {
+		targets := []xproto.Atom{x.mustAtom("TARGETS")}
+		for _, item := range x.items {
+			for _, name := range formatToTargets(item.Format) {
+				targets = append(targets, x.mustAtom(name))
+			}
+		}
+		data := make([]byte, 4*len(targets))
+		for i, t := range targets {
+			xgb.Put32(data[4*i:], uint32(t))
+		}
+		xproto.ChangeProperty(x.conn, xproto.PropModeReplace, requestor, property, xproto.AtomAtom, 32, uint32(len(targets)), data)
+		return true
+	}
+
+	name, err := x.atomName(target)
+	if err != nil {
+		slog.Error(err.Error())
+		return false
+	}
+	format, ok := targetToFormat(name)
+	if !ok {
+		return false
+	}
+	idx := slices.IndexFunc(x.items, func(item Item) bool {
+		return item.Format == format
+	})
+	if idx < 0 {
+		return false
+	}
+	data := x.items[idx].Data
+	if len(data) > x.maxData {
+		x.startIncr(requestor, property, target, data)
+		return true
+	}
+	xproto.ChangeProperty(x.conn, xproto.PropModeReplace, requestor, property, target, 8, uint32(len(data)), data)
+	return true
+}
+
+// startIncr starts sending data incrementally with the INCR protocol.
+// The requestor deletes the property to request each chunk.
+func (x *x11Backend) startIncr(requestor xproto.Window, property, target xproto.Atom, data []byte) {
+	// Forget the transfers abandoned by the requestors.
+	now := time.Now()
+	for key, incr := range x.incrs {
+		if now.After(incr.deadline) {
+			delete(x.incrs, key)
+		}
+	}
+
+	xproto.ChangeWindowAttributes(x.conn, requestor, xproto.CwEventMask, []uint32{xproto.EventMaskPropertyChange})
+	x.incrs[x11IncrKey{requestor: requestor, property: property}] = &x11Incr{
+		target:   target,
+		data:     data,
+		deadline: now.Add(x11Timeout),
+	}
+
+	// The value of INCR is the lower bound of the data size.
+	size := make([]byte, 4)
+	xgb.Put32(size, uint32(len(data)))
+	xproto.ChangeProperty(x.conn, xproto.PropModeReplace, requestor, property, x.mustAtom("INCR"), 32, 1, size)
+}
+
+// continueIncr sends the next chunk when the requestor deletes the property.
+func (x *x11Backend) continueIncr(ev xproto.PropertyNotifyEvent) {
+	key := x11IncrKey{requestor: ev.Window, property: ev.Atom}
+	incr, ok := x.incrs[key]
+	if !ok {
+		return
+	}
+	n := min(len(incr.data), x.maxData)
+	xproto.ChangeProperty(x.conn, xproto.PropModeReplace, ev.Window, ev.Atom, incr.target, 8, uint32(n), incr.data[:n])
+	if n == 0 {
+		// The empty chunk terminates the transfer.
+		delete(x.incrs, key)
+		xproto.ChangeWindowAttributes(x.conn, ev.Window, xproto.CwEventMask, []uint32{xproto.EventMaskNoEvent})
+		return
+	}
+	incr.data = incr.data[n:]
+	incr.deadline = time.Now().Add(x11Timeout)
+}
+
+func (x *x11Backend) isOwner() (bool, error) {
+	r, err := xproto.GetSelectionOwner(x.conn, x.mustAtom("CLIPBOARD")).Reply()
+	if err != nil {
+		return false, err
+	}
+	return r.Owner == x.window, nil
+}
+
+// convert requests the selection owner to convert the content to target, and returns the converted data.
+// convert returns ErrFormatUnavailable if the owner refuses the conversion.
+func (x *x11Backend) convert(target xproto.Atom) ([]byte, xproto.Atom, error) {
+	x.readM.Lock()
+	defer x.readM.Unlock()
+
+	property := x.mustAtom("GUIGUI_CLIPBOARD")
+
+	// Discard stale notifications.
+	for len(x.selectionNotify) > 0 {
+		<-x.selectionNotify
+	}
+	for len(x.propertyNotify) > 0 {
+		<-x.propertyNotify
+	}
+
+	xproto.ConvertSelection(x.conn, x.window, x.mustAtom("CLIPBOARD"), target, property, xproto.TimeCurrentTime)
+
+	var ev xproto.SelectionNotifyEvent
+	select {
+	case ev = <-x.selectionNotify:
+	case <-time.After(x11Timeout):
+		return nil, 0, errors.New("clipboard: timed out to read the clipboard")
+	}
+	if ev.Property == xproto.AtomNone {
+		return nil, 0, ErrFormatUnavailable
+	}
+
+	data, typ, err := x.readProperty(property)
+	if err != nil {
+		return nil, 0, err
+	}
+	if typ != x.mustAtom("INCR") {
+		return data, typ, nil
+	}
+
+	// Read the data incrementally. The owner writes the next chunk after the property is deleted.
+	data = data[:0]
+	for {
+		select {
+		case <-x.propertyNotify:
+		case <-time.After(x11Timeout):
+			return nil, 0, errors.New("clipboard: timed out to read the clipboard incrementally")
+		}
+		chunk, chunkType, err := x.readProperty(property)
+		if err != nil {
+			return nil, 0, err
+		}
+		// The notification might be stale and the property might not exist.
+		if chunkType == xproto.AtomNone {
+			continue
+		}
+		if len(chunk) == 0 {
+			return data, chunkType, nil
+		}
+		typ = chunkType
+		data = append(data, chunk...)
+	}
+}
+
+// readProperty reads and deletes the property of the backend's window.
+func (x *x11Backend) readProperty(property xproto.Atom) ([]byte, xproto.Atom, error) {
+	var data []byte
+	var typ xproto.Atom
+	var offset uint32
+	for {
+		r, err := xproto.GetProperty(x.conn, false, x.window, property, xproto.GetPropertyTypeAny, offset, 1<<16).Reply()
+		if err != nil {
+			return nil, 0, err
+		}
+		typ = r.Type
+		data = append(data, r.Value[:int(r.ValueLen)*int(r.Format/8)]...)
+		if r.BytesAfter == 0 {
+			break
+		}
+		offset += uint32(len(r.Value) / 4)
+	}
+	xproto.DeleteProperty(x.conn, x.window, property)
+	return data, typ, nil
+}
+
+func (x *x11Backend) Formats() ([]Format, error) {
+	if owner, err := x.isOwner(); err != nil {
+		return nil, err
+	} else if owner {
+		x.itemsM.Lock()
+		defer x.itemsM.Unlock()
+		formats := make([]Format, 0, len(x.items))
+		for _, item := range x.items {
+			formats = append(formats, item.Format)
+		}
+		return formats, nil
+	}
+
+	data, _, err := x.convert(x.mustAtom("TARGETS"))
+	if errors.Is(err, ErrFormatUnavailable) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var formats []Format
+	for i := 0; i+4 <= len(data); i += 4 {
+		name, err := x.atomName(xproto.Atom(xgb.Get32(data[i:])))
+		if err != nil {
+			return nil, err
+		}
+		format, ok := targetToFormat(name)
+		if !ok || slices.Contains(formats, format) {
+			continue
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+func (x *x11Backend) Read(format Format) ([]byte, error) {
+	if owner, err := x.isOwner(); err != nil {
+		return nil, err
+	} else if owner {
+		x.itemsM.Lock()
+		defer x.itemsM.Unlock()
+		idx := slices.IndexFunc(x.items, func(item Item) bool {
+			return item.Format == format
+		})
+		if idx < 0 {
+			return nil, ErrFormatUnavailable
+		}
+		return slices.Clone(x.items[idx].Data), nil
+	}
+
+	if format != FormatText {
+		data, _, err := x.convert(x.mustAtom(string(format)))
+		return data, err
+	}
+
+	for _, name := range formatToTargets(FormatText) {
+		data, _, err := x.convert(x.mustAtom(name))
+		if errors.Is(err, ErrFormatUnavailable) {
+			continue
+		}
+		return data, err
+	}
+	return nil, ErrFormatUnavailable
+}
+
+func (x *x11Backend) Write(items []Item) error {
+	x.itemsM.Lock()
+	x.items = x.items[:0]
+	for _, item := range items {
+		x.items = append(x.items, Item{
+			Format: item.Format,
+			Data:   slices.Clone(item.Data),
+		})
+	}
+	x.itemsM.Unlock()
+
+	clipboard := x.mustAtom("CLIPBOARD")
+	if err := xproto.SetSelectionOwnerChecked(x.conn, x.window, clipboard, xproto.TimeCurrentTime).Check(); err != nil {
+		return err
+	}
+	owner, err := x.isOwner()
+	if err != nil {
+		return err
+	}
+	if !owner {
+		return fmt.Errorf("clipboard: failed to own the clipboard")
+	}
+	return nil
+}
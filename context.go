@@ -6,6 +6,7 @@ package guigui
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"log/slog"
 	"os"
 	"slices"
@@ -338,11 +339,12 @@ func (c *Context) focus(widget Widget) {
 	if !ws.isInTree() {
 		return
 	}
-	if c.app.focusedWidgetState == widget.widgetState() {
+	if c.app.focusedWidget != nil && c.app.focusedWidget.widgetState() == widget.widgetState() {
 		return
 	}
 
-	c.app.focusedWidgetState = widget.widgetState()
+	c.app.focusedWidget = widget
+	c.app.focusVisible = false
 
 	// Rerender everything when a focus changes.
 	// A widget including a focused widget might be affected.
@@ -356,8 +358,8 @@ func (c *Context) blur(widget Widget) {
 	}
 	var unfocused bool
 	_ = traverseWidget(widget, func(w Widget) error {
-		if c.app.focusedWidgetState == w.widgetState() {
-			c.app.focusedWidgetState = c.app.root.widgetState()
+		if c.app.focusedWidget != nil && c.app.focusedWidget.widgetState() == w.widgetState() {
+			c.app.focusedWidget = c.app.root
 			unfocused = true
 			return skipTraverse
 		}
@@ -371,7 +373,16 @@ func (c *Context) blur(widget Widget) {
 }
 
 func (c *Context) IsFocused(widget Widget) bool {
-	return c.app.focusedWidgetState == widget.widgetState()
+	return c.app.focusedWidget != nil && c.app.focusedWidget.widgetState() == widget.widgetState()
+}
+
+// FocusedWidget returns the focused widget.
+// FocusedWidget returns the root widget when no other widget is focused.
+func (c *Context) FocusedWidget() Widget {
+	if c.app.focusedWidget == nil {
+		return c.app.root
+	}
+	return c.app.focusedWidget
 }
 
 func (c *Context) IsFocusedOrHasFocusedChild(widget Widget) bool {
@@ -379,14 +390,14 @@ func (c *Context) IsFocusedOrHasFocusedChild(widget Widget) bool {
 		panic("guigui: IsFocusedOrHasFocusedChild cannot be called in Build")
 	}
 
+	if c.app.focusedWidget == nil {
+		return false
+	}
 	if len(widget.widgetState().children) == 0 {
-		return c.app.focusedWidgetState == widget.widgetState()
+		return c.app.focusedWidget.widgetState() == widget.widgetState()
 	}
 
-	w := c.app.focusedWidgetState
-	if w == nil {
-		return false
-	}
+	w := c.app.focusedWidget.widgetState()
 	for {
 		widgetState := widget.widgetState()
 		if w == widgetState {
@@ -418,10 +429,58 @@ func (c *Context) IsWidgetHitAtCursor(widget Widget) bool {
 	return c.app.isWidgetHitAt(widget)
 }
 
+// AppendWidgetsAt appends the widgets at point to widgets in the order from the top to the bottom, and returns the result.
+// Widgets that pass through input are not included.
+func (c *Context) AppendWidgetsAt(widgets []Widget, point image.Point) []Widget {
+	start := len(widgets)
+	widgets = c.app.appendWidgetsAt(widgets, point, c.app.root, true)
+	slices.SortStableFunc(widgets[start:], func(a, b Widget) int {
+		return b.widgetState().z - a.widgetState().z
+	})
+	return widgets
+}
+
+// Parent returns the parent widget of widget.
+// Parent returns nil if widget is the root or not in the widget tree.
+func (c *Context) Parent(widget Widget) Widget {
+	return widget.widgetState().parent
+}
+
 func (c *Context) SetCustomDraw(widget Widget, customDraw CustomDrawFunc) {
 	widget.widgetState().customDraw = customDraw
 }
 
+// UndoManager returns the app's shared undo manager for model edits.
+func (c *Context) UndoManager() *UndoManager {
+	return &c.app.undoManager
+}
+
+// Post enqueues f to be called on the UI thread at the start of the next Update.
+// Post can be called from any goroutine, e.g. to hand the result of background work to widgets.
+//
+// If widget is not nil, f is dropped when widget is no longer in the widget tree at that time.
+func (c *Context) Post(widget Widget, f func(context *Context)) {
+	c.app.post(widget, f)
+}
+
+// RequestScreenColor requests to sample the color at point on the screen when the app is rendered next time.
+// The sampled color is available by ScreenColor after that.
+//
+// Sampling reads pixels from GPU and can be slow. Do not request it every tick.
+func (c *Context) RequestScreenColor(point image.Point) {
+	c.app.screenColorPoint = point
+	c.app.screenColorRequested = true
+	c.app.screenColorSampled = false
+}
+
+// ScreenColor returns the color at point sampled by the last RequestScreenColor, and reports whether the color is available.
+func (c *Context) ScreenColor(point image.Point) (color.Color, bool) {
+	if !c.app.screenColorSampled || c.app.screenColorPoint != point {
+		return nil, false
+	}
+	return c.app.screenColor, true
+}
+
 func (c *Context) clearVisibleBoundsCacheForWidget(widget Widget) {
 	widget.widgetState().hasVisibleBoundsCache = false
 	widget.widgetState().visibleBoundsCache = image.Rectangle{}
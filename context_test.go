@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+func TestScreenImagePoint(t *testing.T) {
+	testCases := []struct {
+		point        image.Point
+		appBounds    image.Rectangle
+		screenBounds image.Rectangle
+		want         image.Point
+	}{
+		{point: image.Pt(10, 20), appBounds: image.Rect(0, 0, 100, 100), screenBounds: image.Rect(0, 0, 100, 100), want: image.Pt(10, 20)},
+		{point: image.Pt(10, 20), appBounds: image.Rect(0, 0, 100, 100), screenBounds: image.Rect(0, 0, 200, 200), want: image.Pt(20, 40)},
+		{point: image.Pt(10, 20), appBounds: image.Rect(0, 0, 100, 100), screenBounds: image.Rect(5, 5, 105, 105), want: image.Pt(15, 25)},
+	}
+	for _, tc := range testCases {
+		if got := guigui.ScreenImagePoint(tc.point, tc.appBounds, tc.screenBounds); got != tc.want {
+			t.Errorf("ScreenImagePoint(%v, %v, %v): got: %v, want: %v", tc.point, tc.appBounds, tc.screenBounds, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+type errorCatcher interface {
+	Widget
+
+	// canCatchError reports whether the catcher can catch an error now.
+	canCatchError() bool
+
+	catchError(context *Context, widgetPath string, err error) bool
+}
+
+// ErrorBoundary catches errors and panics from its content subtree, and shows the fallback widget instead
+// until Reset is called.
+type ErrorBoundary struct {
+	DefaultWidget
+
+	content    Widget
+	fallback   Widget
+	err        error
+	widgetPath string
+
+	onError func(err error)
+}
+
+var _ errorCatcher = (*ErrorBoundary)(nil)
+
+func (e *ErrorBoundary) SetContent(widget Widget) {
+	e.content = widget
+}
+
+func (e *ErrorBoundary) SetFallback(widget Widget) {
+	e.fallback = widget
+}
+
+func (e *ErrorBoundary) SetOnError(f func(err error)) {
+	e.onError = f
+}
+
+// Err returns the caught error, or nil if the content is working.
+func (e *ErrorBoundary) Err() error {
+	return e.err
+}
+
+// FailedWidgetPath returns the type path of the widget that caused the caught error, e.g. "*main.Root > *main.Plugin".
+func (e *ErrorBoundary) FailedWidgetPath() string {
+	return e.widgetPath
+}
+
+// Reset clears the caught error so that the content is built again.
+func (e *ErrorBoundary) Reset() {
+	if e.err == nil {
+		return
+	}
+	e.err = nil
+	e.widgetPath = ""
+	RequestRedraw(e)
+}
+
+func (e *ErrorBoundary) Build(context *Context, appender *ChildWidgetAppender) error {
+	if e.err != nil {
+		if e.fallback != nil {
+			appender.AppendChildWidgetWithBounds(e.fallback, context.Bounds(e))
+		}
+		return nil
+	}
+	if e.content != nil {
+		appender.AppendChildWidgetWithBounds(e.content, context.Bounds(e))
+	}
+	return nil
+}
+
+func (e *ErrorBoundary) canCatchError() bool {
+	// If the fallback fails, let an outer boundary handle the error.
+	return e.err == nil
+}
+
+func (e *ErrorBoundary) catchError(context *Context, widgetPath string, err error) bool {
+	if !e.canCatchError() {
+		return false
+	}
+
+	e.err = err
+	e.widgetPath = widgetPath
+
+	var pErr *panicError
+	if errors.As(err, &pErr) {
+		slog.Error("guigui: panic caught by an error boundary", "widget", widgetPath, "error", err, "stack", string(pErr.stack))
+	} else {
+		slog.Error("guigui: error caught by an error boundary", "widget", widgetPath, "error", err)
+	}
+
+	context.blur(e)
+	RequestRedraw(e)
+
+	if e.onError != nil {
+		e.onError(err)
+	}
+	return true
+}
+
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func (p *panicError) Error() string {
+	return fmt.Sprintf("guigui: panic: %v", p.value)
+}
+
+func (p *panicError) Unwrap() error {
+	if err, ok := p.value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// callWithRecover calls f, and converts a panic in f to an error if catcher can catch it.
+//
+// Otherwise, the panic is not recovered so that it keeps its original value and stack.
+func callWithRecover(catcher errorCatcher, f func() error) (err error) {
+	defer func() {
+		if !catcher.canCatchError() {
+			return
+		}
+		if r := recover(); r != nil {
+			err = &panicError{
+				value: r,
+				stack: debug.Stack(),
+			}
+		}
+	}()
+	return f()
+}
+
+// callForWidget calls f on behalf of widget outside of the tree traversal.
+// A panic in f is caught by the nearest error boundary among widget's ancestors.
+func (a *app) callForWidget(widget Widget, f func()) {
+	a.widgetInProgress = widget
+	var catcher errorCatcher
+	for w := widget.widgetState().parent; w != nil; w = w.widgetState().parent {
+		if c, ok := w.(errorCatcher); ok && c.canCatchError() {
+			catcher = c
+			break
+		}
+	}
+	if catcher == nil {
+		f()
+		return
+	}
+	if err := callWithRecover(catcher, func() error {
+		f()
+		return nil
+	}); err != nil {
+		a.catchError(catcher, err)
+	}
+}
+
+func widgetPath(widget Widget) string {
+	var names []string
+	for w := widget; w != nil; w = w.widgetState().parent {
+		names = append(names, fmt.Sprintf("%T", w))
+	}
+	slices.Reverse(names)
+	return strings.Join(names, " > ")
+}
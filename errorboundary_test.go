@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"errors"
+	"image"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// panicWidget panics with value in the method specified by panicIn.
+type panicWidget struct {
+	guigui.DefaultWidget
+
+	panicIn string
+	value   any
+	built   int
+}
+
+func (p *panicWidget) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	p.built++
+	if p.panicIn == "Build" {
+		panic(p.value)
+	}
+	return nil
+}
+
+func (p *panicWidget) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	if p.panicIn == "HandlePointingInput" {
+		panic(p.value)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (p *panicWidget) Tick(context *guigui.Context) error {
+	if p.panicIn == "Tick" {
+		panic(p.value)
+	}
+	return nil
+}
+
+func (p *panicWidget) Draw(context *guigui.Context, dst *ebiten.Image) {
+	if p.panicIn == "Draw" {
+		panic(p.value)
+	}
+}
+
+var errTest = errors.New("test error")
+
+func TestErrorBoundaryCatchesPanic(t *testing.T) {
+	for _, method := range []string{"Build", "HandlePointingInput", "Tick", "Draw"} {
+		t.Run(method, func(t *testing.T) {
+			var content, fallback panicWidget
+			content.panicIn = method
+			content.value = errTest
+
+			var b guigui.ErrorBoundary
+			b.SetContent(&content)
+			b.SetFallback(&fallback)
+			var onErrorCalled int
+			b.SetOnError(func(err error) {
+				onErrorCalled++
+			})
+
+			a := guigui.NewTestApp(&b, image.Pt(100, 100))
+			dst := ebiten.NewImage(100, 100)
+			defer dst.Deallocate()
+
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+			a.HandlePointingInput()
+			if err := a.Tick(); err != nil {
+				t.Fatal(err)
+			}
+			a.Draw(dst)
+
+			if !errors.Is(b.Err(), errTest) {
+				t.Errorf("Err: got: %v, want: %v", b.Err(), errTest)
+			}
+			if got, want := b.FailedWidgetPath(), "*guigui.ErrorBoundary > *guigui_test.panicWidget"; got != want {
+				t.Errorf("FailedWidgetPath: got: %q, want: %q", got, want)
+			}
+			if got, want := onErrorCalled, 1; got != want {
+				t.Errorf("onError called: got: %d, want: %d", got, want)
+			}
+
+			// The fallback replaces the content.
+			content.built = 0
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := content.built, 0; got != want {
+				t.Errorf("content built: got: %d, want: %d", got, want)
+			}
+			if fallback.built == 0 {
+				t.Errorf("fallback is not built")
+			}
+			if !a.Context().IsVisible(&fallback) {
+				t.Errorf("fallback is not in the tree")
+			}
+
+			// Reset builds the content again.
+			content.panicIn = ""
+			b.Reset()
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+			if b.Err() != nil {
+				t.Errorf("Err after Reset: got: %v, want: nil", b.Err())
+			}
+			if got, want := content.built, 1; got != want {
+				t.Errorf("content built after Reset: got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
+// errorWidget returns an error from Build.
+type errorWidget struct {
+	guigui.DefaultWidget
+}
+
+func (e *errorWidget) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	return errTest
+}
+
+func TestErrorBoundaryCatchesError(t *testing.T) {
+	var b guigui.ErrorBoundary
+	b.SetContent(&errorWidget{})
+	a := guigui.NewTestApp(&b, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	if b.Err() != errTest {
+		t.Errorf("Err: got: %v, want: %v", b.Err(), errTest)
+	}
+}
+
+func TestErrorBoundaryFallbackFails(t *testing.T) {
+	content := panicWidget{panicIn: "Build", value: "content"}
+	fallback := panicWidget{panicIn: "Build", value: "fallback"}
+	var inner guigui.ErrorBoundary
+	inner.SetContent(&content)
+	inner.SetFallback(&fallback)
+
+	var outerFallback panicWidget
+	var outer guigui.ErrorBoundary
+	outer.SetContent(&inner)
+	outer.SetFallback(&outerFallback)
+
+	a := guigui.NewTestApp(&outer, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	if inner.Err() == nil || !strings.Contains(inner.Err().Error(), "content") {
+		t.Errorf("inner Err: got: %v, want: the content's panic", inner.Err())
+	}
+	if outer.Err() == nil || !strings.Contains(outer.Err().Error(), "fallback") {
+		t.Errorf("outer Err: got: %v, want: the fallback's panic", outer.Err())
+	}
+	if outerFallback.built == 0 {
+		t.Errorf("the outer fallback is not built")
+	}
+}
+
+func TestErrorBoundaryUncaughtPanic(t *testing.T) {
+	for _, method := range []string{"Build", "HandlePointingInput", "Tick", "Draw"} {
+		t.Run(method, func(t *testing.T) {
+			var content panicWidget
+			var fallback panicWidget
+			fallback.panicIn = method
+			fallback.value = errTest
+
+			var b guigui.ErrorBoundary
+			b.SetContent(&content)
+			b.SetFallback(&fallback)
+
+			a := guigui.NewTestApp(&b, image.Pt(100, 100))
+			dst := ebiten.NewImage(100, 100)
+			defer dst.Deallocate()
+
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+
+			// The fallback's panic is not caught, and keeps its original value and stack.
+			defer func() {
+				r := recover()
+				if r != errTest {
+					t.Errorf("recovered: got: %#v, want: %#v", r, errTest)
+				}
+				if stack := string(debug.Stack()); !strings.Contains(stack, "panicWidget)."+method) {
+					t.Errorf("the stack does not include the panicking method:\n%s", stack)
+				}
+			}()
+
+			// Make the boundary show the fallback.
+			content.panicIn = "Build"
+			content.value = "content"
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+			if b.Err() == nil {
+				t.Fatal("Err: got: nil, want: non-nil")
+			}
+			a.HandlePointingInput()
+			if err := a.Tick(); err != nil {
+				t.Fatal(err)
+			}
+			a.Draw(dst)
+		})
+	}
+}
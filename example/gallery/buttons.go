@@ -23,6 +23,7 @@ type Buttons struct {
 	textIconButton2       basicwidget.Button
 	imageButtonText       basicwidget.Text
 	imageButton           basicwidget.Button
+	imageButtonTooltip    basicwidget.Tooltip
 	segmentedControlHText basicwidget.Text
 	segmentedControlH     basicwidget.SegmentedControl[int]
 	segmentedControlVText basicwidget.Text
@@ -73,6 +74,8 @@ func (b *Buttons) Build(context *guigui.Context, appender *guigui.ChildWidgetApp
 	b.imageButton.SetIcon(img)
 	context.SetEnabled(&b.imageButton, b.model.Buttons().Enabled())
 	context.SetSize(&b.imageButton, image.Pt(2*u, 2*u))
+	b.imageButtonTooltip.SetAnchor(&b.imageButton)
+	b.imageButtonTooltip.SetText("Gopher")
 
 	b.segmentedControlHText.SetValue("Segmented control (Horizontal)")
 	b.segmentedControlH.SetItems([]basicwidget.SegmentedControlItem[int]{
@@ -162,6 +165,7 @@ func (b *Buttons) Build(context *guigui.Context, appender *guigui.ChildWidgetApp
 	}
 	appender.AppendChildWidgetWithBounds(&b.buttonsForm, gl.CellBounds(0, 0))
 	appender.AppendChildWidgetWithBounds(&b.configForm, gl.CellBounds(0, 2))
+	appender.AppendChildWidget(&b.imageButtonTooltip)
 
 	return nil
 }
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"fmt"
 	"image"
 	"slices"
 
@@ -19,11 +20,19 @@ type Lists struct {
 	listText basicwidget.Text
 	list     basicwidget.List[int]
 
+	virtualListText basicwidget.Text
+	virtualList     basicwidget.List[int]
+
+	treeText basicwidget.Text
+	tree     basicwidget.TreeView[string]
+
 	configForm       basicwidget.Form
 	showStripeText   basicwidget.Text
 	showStripeToggle basicwidget.Toggle
 	movableText      basicwidget.Text
 	movableToggle    basicwidget.Toggle
+	multipleText     basicwidget.Text
+	multipleToggle   basicwidget.Toggle
 	enabledText      basicwidget.Text
 	enabledToggle    basicwidget.Toggle
 
@@ -48,14 +57,52 @@ func (l *Lists) Build(context *guigui.Context, appender *guigui.ChildWidgetAppen
 	l.items = slices.Delete(l.items, 0, len(l.items))
 	l.items = l.model.lists.AppendListItems(l.items)
 	l.list.SetItems(l.items)
+	if l.model.Lists().MultipleSelection() {
+		l.list.SetSelectionMode(basicwidget.ListSelectionModeMultiple)
+	} else {
+		l.list.SetSelectionMode(basicwidget.ListSelectionModeSingle)
+	}
 	context.SetSize(&l.list, image.Pt(guigui.DefaultSize, 6*basicwidget.UnitSize(context)))
 	context.SetEnabled(&l.list, l.model.Lists().Enabled())
 
+	l.virtualListText.SetValue("Virtualized list")
+	l.virtualList.SetItemBorderVisible(l.model.Lists().IsStripeVisible())
+	l.virtualList.SetDataSource(numberedItems{})
+	context.SetSize(&l.virtualList, image.Pt(guigui.DefaultSize, 6*basicwidget.UnitSize(context)))
+	context.SetEnabled(&l.virtualList, l.model.Lists().Enabled())
+
+	l.treeText.SetValue("Tree view")
+	l.tree.SetItemBorderVisible(l.model.Lists().IsStripeVisible())
+	l.tree.SetItems(treeItems)
+	l.tree.SetOnChildrenRequested(func(id string) {
+		// Load the children lazily.
+		var children []basicwidget.TreeItem[string]
+		for i := range 3 {
+			text := fmt.Sprintf("%s %d", id, i+1)
+			children = append(children, basicwidget.TreeItem[string]{
+				Text:        text,
+				HasChildren: true,
+				ID:          text,
+			})
+		}
+		l.tree.SetChildren(id, children)
+	})
+	context.SetSize(&l.tree, image.Pt(guigui.DefaultSize, 6*basicwidget.UnitSize(context)))
+	context.SetEnabled(&l.tree, l.model.Lists().Enabled())
+
 	l.listForm.SetItems([]basicwidget.FormItem{
 		{
 			PrimaryWidget:   &l.listText,
 			SecondaryWidget: &l.list,
 		},
+		{
+			PrimaryWidget:   &l.virtualListText,
+			SecondaryWidget: &l.virtualList,
+		},
+		{
+			PrimaryWidget:   &l.treeText,
+			SecondaryWidget: &l.tree,
+		},
 	})
 
 	// Configurations
@@ -69,6 +116,11 @@ func (l *Lists) Build(context *guigui.Context, appender *guigui.ChildWidgetAppen
 	l.movableToggle.SetOnValueChanged(func(value bool) {
 		l.model.Lists().SetMovable(value)
 	})
+	l.multipleText.SetValue("Multiple selection")
+	l.multipleToggle.SetValue(l.model.Lists().MultipleSelection())
+	l.multipleToggle.SetOnValueChanged(func(value bool) {
+		l.model.Lists().SetMultipleSelection(value)
+	})
 	l.enabledText.SetValue("Enabled")
 	l.enabledToggle.SetOnValueChanged(func(value bool) {
 		l.model.Lists().SetEnabled(value)
@@ -84,6 +136,10 @@ func (l *Lists) Build(context *guigui.Context, appender *guigui.ChildWidgetAppen
 			PrimaryWidget:   &l.movableText,
 			SecondaryWidget: &l.movableToggle,
 		},
+		{
+			PrimaryWidget:   &l.multipleText,
+			SecondaryWidget: &l.multipleToggle,
+		},
 		{
 			PrimaryWidget:   &l.enabledText,
 			SecondaryWidget: &l.enabledToggle,
@@ -105,3 +161,42 @@ func (l *Lists) Build(context *guigui.Context, appender *guigui.ChildWidgetAppen
 
 	return nil
 }
+
+// numberedItems is a data source of many items, which are created only when they are visible.
+type numberedItems struct{}
+
+func (numberedItems) ItemCount() int {
+	return 100000
+}
+
+func (numberedItems) ItemByIndex(index int) basicwidget.ListItem[int] {
+	return basicwidget.ListItem[int]{
+		Text: fmt.Sprintf("Item %d", index+1),
+		ID:   index,
+	}
+}
+
+var treeItems = []basicwidget.TreeItem[string]{
+	{
+		Text: "Fruits",
+		ID:   "Fruits",
+		Children: []basicwidget.TreeItem[string]{
+			{Text: "Apple", ID: "Apple"},
+			{Text: "Banana", ID: "Banana"},
+			{Text: "Cherry", ID: "Cherry"},
+		},
+	},
+	{
+		Text: "Vegetables",
+		ID:   "Vegetables",
+		Children: []basicwidget.TreeItem[string]{
+			{Text: "Carrot", ID: "Carrot"},
+			{Text: "Onion", ID: "Onion"},
+		},
+	},
+	{
+		Text:        "Lazy",
+		HasChildren: true,
+		ID:          "Lazy",
+	},
+}
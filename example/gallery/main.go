@@ -30,7 +30,10 @@ type Root struct {
 	textInputs   TextInputs
 	numberInputs NumberInputs
 	lists        Lists
+	tables       Tables
 	popups       Popups
+	tabs         Tabs
+	dialogStack  basicwidget.DialogStack
 
 	model Model
 
@@ -58,6 +61,8 @@ func (r *Root) Build(context *guigui.Context, appender *guigui.ChildWidgetAppend
 	r.textInputs.SetModel(&r.model)
 	r.numberInputs.SetModel(&r.model)
 	r.lists.SetModel(&r.model)
+	r.popups.SetDialogStack(&r.dialogStack)
+	r.tabs.SetDialogStack(&r.dialogStack)
 
 	gl := layout.GridLayout{
 		Bounds: context.Bounds(r),
@@ -83,17 +88,24 @@ func (r *Root) Build(context *guigui.Context, appender *guigui.ChildWidgetAppend
 		appender.AppendChildWidgetWithBounds(&r.numberInputs, bounds)
 	case "lists":
 		appender.AppendChildWidgetWithBounds(&r.lists, bounds)
+	case "tables":
+		appender.AppendChildWidgetWithBounds(&r.tables, bounds)
 	case "popups":
 		appender.AppendChildWidgetWithBounds(&r.popups, bounds)
+	case "tabs":
+		appender.AppendChildWidgetWithBounds(&r.tabs, bounds)
 	}
 
+	appender.AppendChildWidget(&r.dialogStack)
+
 	return nil
 }
 
 func main() {
 	op := &guigui.RunOptions{
-		Title:      "Component Gallery",
-		WindowSize: image.Pt(800, 600),
+		Title:                  "Component Gallery",
+		WindowSize:             image.Pt(800, 600),
+		FocusNavigationEnabled: true,
 		RunGameOptions: &ebiten.RunGameOptions{
 			ApplePressAndHoldEnabled: true,
 		},
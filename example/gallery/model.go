@@ -5,7 +5,9 @@ package main
 
 import (
 	"fmt"
+	"image/color"
 	"math/big"
+	"time"
 
 	"github.com/hajimehoshi/guigui/basicwidget"
 )
@@ -229,6 +231,12 @@ type NumberInputsModel struct {
 	numberInputValue1 big.Int
 	numberInputValue2 uint64
 	numberInputValue3 int
+	color             color.Color
+	date              time.Time
+	hour              int
+	minute            int
+	rangeStart        time.Time
+	rangeEnd          time.Time
 
 	uneditable bool
 	disabled   bool
@@ -282,12 +290,50 @@ func (n *NumberInputsModel) SetNumberInputValue3(value int) {
 	n.numberInputValue3 = value
 }
 
+func (n *NumberInputsModel) Color() color.Color {
+	if n.color == nil {
+		return color.NRGBA{R: 0xff, G: 0x4b, B: 0x00, A: 0xff}
+	}
+	return n.color
+}
+
+func (n *NumberInputsModel) SetColor(clr color.Color) {
+	n.color = clr
+}
+
+func (n *NumberInputsModel) Date() time.Time {
+	return n.date
+}
+
+func (n *NumberInputsModel) SetDate(date time.Time) {
+	n.date = date
+}
+
+func (n *NumberInputsModel) Time() (hour, minute int) {
+	return n.hour, n.minute
+}
+
+func (n *NumberInputsModel) SetTime(hour, minute int) {
+	n.hour = hour
+	n.minute = minute
+}
+
+func (n *NumberInputsModel) DateRange() (start, end time.Time) {
+	return n.rangeStart, n.rangeEnd
+}
+
+func (n *NumberInputsModel) SetDateRange(start, end time.Time) {
+	n.rangeStart = start
+	n.rangeEnd = end
+}
+
 type ListsModel struct {
 	listItems []basicwidget.ListItem[int]
 
-	stripeVisible bool
-	unmovable     bool
-	disabled      bool
+	stripeVisible     bool
+	unmovable         bool
+	disabled          bool
+	multipleSelection bool
 }
 
 func (l *ListsModel) AppendListItems(items []basicwidget.ListItem[int]) []basicwidget.ListItem[int] {
@@ -324,6 +370,14 @@ func (l *ListsModel) SetMovable(movable bool) {
 	l.unmovable = !movable
 }
 
+func (l *ListsModel) MultipleSelection() bool {
+	return l.multipleSelection
+}
+
+func (l *ListsModel) SetMultipleSelection(multiple bool) {
+	l.multipleSelection = multiple
+}
+
 func (l *ListsModel) Enabled() bool {
 	return !l.disabled
 }
@@ -4,9 +4,12 @@
 package main
 
 import (
+	"fmt"
 	"image"
+	"image/color"
 	"math"
 	"math/big"
+	"time"
 
 	"github.com/hajimehoshi/guigui"
 	"github.com/hajimehoshi/guigui/basicwidget"
@@ -27,6 +30,16 @@ type NumberInputs struct {
 	slider                basicwidget.Slider
 	slierWithoutRangeText basicwidget.Text
 	sliderWithoutRange    basicwidget.Slider
+	colorText             basicwidget.Text
+	colorValueText        basicwidget.Text
+	datePickerText        basicwidget.Text
+	datePicker            basicwidget.DatePicker
+	timePickerText        basicwidget.Text
+	timePicker            basicwidget.TimePicker
+	calendarText          basicwidget.Text
+
+	calendar    basicwidget.Calendar
+	colorPicker basicwidget.ColorPicker
 
 	configForm     basicwidget.Form
 	editableText   basicwidget.Text
@@ -95,6 +108,39 @@ func (n *NumberInputs) Build(context *guigui.Context, appender *guigui.ChildWidg
 	context.SetEnabled(&n.sliderWithoutRange, n.model.NumberInputs().Enabled())
 	context.SetSize(&n.sliderWithoutRange, image.Pt(width, guigui.DefaultSize))
 
+	n.colorText.SetValue("Color picker")
+	n.colorPicker.SetOnValueChanged(func(value color.Color) {
+		n.model.NumberInputs().SetColor(value)
+	})
+	n.colorPicker.SetValue(n.model.NumberInputs().Color())
+	clr := color.NRGBAModel.Convert(n.model.NumberInputs().Color()).(color.NRGBA)
+	n.colorValueText.SetValue(fmt.Sprintf("#%02X%02X%02X%02X", clr.R, clr.G, clr.B, clr.A))
+	context.SetEnabled(&n.colorPicker, n.model.NumberInputs().Enabled())
+
+	n.datePickerText.SetValue("Date picker (Weekends disabled)")
+	n.datePicker.SetOnValueChanged(func(date time.Time) {
+		n.model.NumberInputs().SetDate(date)
+	})
+	n.datePicker.SetDateDisabledFunc(isWeekend)
+	n.datePicker.SetValue(n.model.NumberInputs().Date())
+	context.SetEnabled(&n.datePicker, n.model.NumberInputs().Enabled())
+
+	n.timePickerText.SetValue("Time picker")
+	n.timePicker.SetOnValueChanged(func(hour, minute, second int) {
+		n.model.NumberInputs().SetTime(hour, minute)
+	})
+	hour, minute := n.model.NumberInputs().Time()
+	n.timePicker.SetTime(hour, minute, 0)
+	context.SetEnabled(&n.timePicker, n.model.NumberInputs().Enabled())
+
+	n.calendar.SetRangeSelectionEnabled(true)
+	n.calendar.SetOnRangeChanged(func(start, end time.Time) {
+		n.model.NumberInputs().SetDateRange(start, end)
+	})
+	n.calendar.SetRange(n.model.NumberInputs().DateRange())
+	context.SetEnabled(&n.calendar, n.model.NumberInputs().Enabled())
+	n.calendarText.SetValue(dateRangeString(n.model.NumberInputs().DateRange()))
+
 	n.numberInputForm.SetItems([]basicwidget.FormItem{
 		{
 			PrimaryWidget:   &n.numberInput1Text,
@@ -116,6 +162,21 @@ func (n *NumberInputs) Build(context *guigui.Context, appender *guigui.ChildWidg
 			PrimaryWidget:   &n.slierWithoutRangeText,
 			SecondaryWidget: &n.sliderWithoutRange,
 		},
+		{
+			PrimaryWidget:   &n.colorText,
+			SecondaryWidget: &n.colorValueText,
+		},
+		{
+			PrimaryWidget:   &n.datePickerText,
+			SecondaryWidget: &n.datePicker,
+		},
+		{
+			PrimaryWidget:   &n.timePickerText,
+			SecondaryWidget: &n.timePicker,
+		},
+		{
+			PrimaryWidget: &n.calendarText,
+		},
 	})
 
 	// Configurations
@@ -152,7 +213,34 @@ func (n *NumberInputs) Build(context *guigui.Context, appender *guigui.ChildWidg
 		RowGap: u / 2,
 	}
 	appender.AppendChildWidgetWithBounds(&n.numberInputForm, gl.CellBounds(0, 0))
+	{
+		b := gl.CellBounds(0, 1)
+		b.Max.X = b.Min.X + n.calendar.DefaultSize(context).X
+		b.Max.Y = min(b.Max.Y, b.Min.Y+n.calendar.DefaultSize(context).Y)
+		appender.AppendChildWidgetWithBounds(&n.calendar, b)
+	}
+	{
+		b := gl.CellBounds(0, 1)
+		b.Min.X = b.Max.X - n.colorPicker.DefaultSize(context).X
+		b.Max.Y = min(b.Max.Y, b.Min.Y+n.colorPicker.DefaultSize(context).Y)
+		appender.AppendChildWidgetWithBounds(&n.colorPicker, b)
+	}
 	appender.AppendChildWidgetWithBounds(&n.configForm, gl.CellBounds(0, 2))
 
 	return nil
 }
+
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+func dateRangeString(start, end time.Time) string {
+	const layout = "2006-01-02"
+	switch {
+	case start.IsZero():
+		return "Calendar (Range selection)"
+	case end.IsZero():
+		return fmt.Sprintf("Calendar (Range: %s -)", start.Format(layout))
+	}
+	return fmt.Sprintf("Calendar (Range: %s - %s)", start.Format(layout), end.Format(layout))
+}
@@ -7,7 +7,6 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/hajimehoshi/guigui"
 	"github.com/hajimehoshi/guigui/basicwidget"
@@ -17,20 +16,46 @@ import (
 type Popups struct {
 	guigui.DefaultWidget
 
-	forms                        [2]basicwidget.Form
+	forms                        [3]basicwidget.Form
 	blurBackgroundText           basicwidget.Text
 	blurBackgroundToggle         basicwidget.Toggle
 	closeByClickingOutsideText   basicwidget.Text
 	closeByClickingOutsideToggle basicwidget.Toggle
 	showButton                   basicwidget.Button
 
-	contextMenuPopupText          basicwidget.Text
-	contextMenuPopupClickHereText basicwidget.Text
+	contextMenuPopupText basicwidget.Text
+	contextMenuArea      contextMenuArea
+	menuBarText          basicwidget.Text
+	menuBar              basicwidget.MenuBar
+	lastCommandText      basicwidget.Text
+	lastCommandValueText basicwidget.Text
+
+	lastCommand  string
+	sidebarShown bool
+	zoom         int
 
 	simplePopup        basicwidget.Popup
 	simplePopupContent simplePopupContent
 
-	contextMenuPopup basicwidget.PopupMenu[int]
+	dialogStack         *basicwidget.DialogStack
+	alertText           basicwidget.Text
+	alertButton         basicwidget.Button
+	confirmText         basicwidget.Text
+	confirmButton       basicwidget.Button
+	promptText          basicwidget.Text
+	promptButton        basicwidget.Button
+	nestedDialogText    basicwidget.Text
+	nestedDialogButton  basicwidget.Button
+	dialogResultText    basicwidget.Text
+	dialogResultValue   basicwidget.Text
+	nestedDialog        basicwidget.Dialog
+	nestedDialogContent basicwidget.Button
+
+	dialogResult string
+}
+
+func (p *Popups) SetDialogStack(dialogStack *basicwidget.DialogStack) {
+	p.dialogStack = dialogStack
 }
 
 func (p *Popups) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
@@ -56,15 +81,30 @@ func (p *Popups) Build(context *guigui.Context, appender *guigui.ChildWidgetAppe
 	})
 
 	p.contextMenuPopupText.SetValue("Context menu")
-	p.contextMenuPopupClickHereText.SetValue("Click here by the right button")
+	p.contextMenuArea.text.SetValue("Click here by the right button")
+
+	p.menuBarText.SetValue("Menu bar")
+	p.menuBar.SetMenus(p.menus())
+	p.lastCommandText.SetValue("Last command")
+	p.lastCommandValueText.SetValue(p.lastCommand)
 
 	p.forms[1].SetItems([]basicwidget.FormItem{
 		{
 			PrimaryWidget:   &p.contextMenuPopupText,
-			SecondaryWidget: &p.contextMenuPopupClickHereText,
+			SecondaryWidget: &p.contextMenuArea,
+		},
+		{
+			PrimaryWidget:   &p.menuBarText,
+			SecondaryWidget: &p.menuBar,
+		},
+		{
+			PrimaryWidget:   &p.lastCommandText,
+			SecondaryWidget: &p.lastCommandValueText,
 		},
 	})
 
+	p.buildDialogs(context)
+
 	u := basicwidget.UnitSize(context)
 	gl := layout.GridLayout{
 		Bounds: context.Bounds(p).Inset(u / 2),
@@ -101,23 +141,252 @@ func (p *Popups) Build(context *guigui.Context, appender *guigui.ChildWidgetAppe
 	context.SetSize(&p.simplePopupContent, simplePopupBounds.Size())
 	appender.AppendChildWidgetWithBounds(&p.simplePopup, simplePopupBounds)
 
-	p.contextMenuPopup.SetItemsByStrings([]string{"Item 1", "Item 2", "Item 3"})
-	// A context menu's position is updated at HandlePointingInput.
-	appender.AppendChildWidget(&p.contextMenuPopup)
+	return nil
+}
 
+func (p *Popups) buildDialogs(context *guigui.Context) {
+	p.alertText.SetValue("Alert")
+	p.alertButton.SetText("Show")
+	p.alertButton.SetOnUp(func() {
+		p.dialogStack.Alert(context, "Saved", "The document has been saved.", func() {
+			p.dialogResult = "Alert closed"
+		})
+	})
+	p.confirmText.SetValue("Confirm")
+	p.confirmButton.SetText("Show")
+	p.confirmButton.SetOnUp(func() {
+		p.dialogStack.Confirm(context, "Delete", "Do you want to delete the file? This cannot be undone.", func(ok bool) {
+			if ok {
+				p.dialogResult = "Confirmed"
+			} else {
+				p.dialogResult = "Canceled"
+			}
+		})
+	})
+	p.promptText.SetValue("Prompt")
+	p.promptButton.SetText("Show")
+	p.promptButton.SetOnUp(func() {
+		p.dialogStack.Prompt(context, "Rename", "Enter a new name.", "Untitled", func(value string, ok bool) {
+			if ok {
+				p.dialogResult = "Renamed to " + value
+			} else {
+				p.dialogResult = "Canceled"
+			}
+		})
+	})
+
+	p.nestedDialogText.SetValue("Nested dialogs")
+	p.nestedDialogButton.SetText("Show")
+	p.nestedDialogButton.SetOnUp(func() {
+		p.dialogStack.Open(context, &p.nestedDialog)
+	})
+	p.nestedDialog.SetTitle("Settings")
+	p.nestedDialog.SetMessage("A dialog can open another dialog on top of it. Drag the title to move the dialog.")
+	p.nestedDialog.SetMovable(true)
+	p.nestedDialogContent.SetText("Reset")
+	p.nestedDialogContent.SetOnUp(func() {
+		p.dialogStack.Confirm(context, "Reset", "Do you want to reset the settings?", func(ok bool) {
+			if ok {
+				p.dialogResult = "Reset"
+			}
+		})
+	})
+	p.nestedDialog.SetContent(&p.nestedDialogContent)
+	p.nestedDialog.SetButtons([]basicwidget.DialogButton{
+		{
+			Text:    "Close",
+			Default: true,
+			Cancel:  true,
+		},
+	})
+
+	p.dialogResultText.SetValue("Result")
+	p.dialogResultValue.SetValue(p.dialogResult)
+
+	p.forms[2].SetItems([]basicwidget.FormItem{
+		{
+			PrimaryWidget:   &p.alertText,
+			SecondaryWidget: &p.alertButton,
+		},
+		{
+			PrimaryWidget:   &p.confirmText,
+			SecondaryWidget: &p.confirmButton,
+		},
+		{
+			PrimaryWidget:   &p.promptText,
+			SecondaryWidget: &p.promptButton,
+		},
+		{
+			PrimaryWidget:   &p.nestedDialogText,
+			SecondaryWidget: &p.nestedDialogButton,
+		},
+		{
+			PrimaryWidget:   &p.dialogResultText,
+			SecondaryWidget: &p.dialogResultValue,
+		},
+	})
+}
+
+func (p *Popups) command(name string) func() {
+	return func() {
+		p.lastCommand = name
+	}
+}
+
+func (p *Popups) zoomItem(text string, zoom int) basicwidget.MenuItem {
+	return basicwidget.MenuItem{
+		Text:    text,
+		Checked: p.zoom == zoom,
+		Radio:   true,
+		OnSelected: func() {
+			p.zoom = zoom
+			p.lastCommand = text
+		},
+	}
+}
+
+func (p *Popups) menus() []basicwidget.MenuBarMenu {
+	return []basicwidget.MenuBarMenu{
+		{
+			Title: "&File",
+			Items: []basicwidget.MenuItem{
+				{
+					Text:       "New",
+					Shortcut:   &basicwidget.Shortcut{Key: ebiten.KeyN, Modifiers: basicwidget.ShortcutModifierCommand},
+					OnSelected: p.command("New"),
+				},
+				{
+					Text:       "Open…",
+					Shortcut:   &basicwidget.Shortcut{Key: ebiten.KeyO, Modifiers: basicwidget.ShortcutModifierCommand},
+					OnSelected: p.command("Open"),
+				},
+				{
+					Text: "Open Recent",
+					Submenu: []basicwidget.MenuItem{
+						{
+							Text:       "notes.txt",
+							OnSelected: p.command("Open notes.txt"),
+						},
+						{
+							Text:       "todo.txt",
+							OnSelected: p.command("Open todo.txt"),
+						},
+					},
+				},
+				{
+					Border: true,
+				},
+				{
+					Text:       "Save As…",
+					Shortcut:   &basicwidget.Shortcut{Key: ebiten.KeyS, Modifiers: basicwidget.ShortcutModifierCommand | basicwidget.ShortcutModifierShift},
+					OnSelected: p.command("Save As"),
+				},
+			},
+		},
+		{
+			Title: "&View",
+			Items: []basicwidget.MenuItem{
+				{
+					Text:    "Show Sidebar",
+					Checked: p.sidebarShown,
+					OnSelected: func() {
+						p.sidebarShown = !p.sidebarShown
+						p.lastCommand = "Show Sidebar"
+					},
+				},
+				{
+					Border: true,
+				},
+				{
+					Text:   "Zoom",
+					Header: true,
+				},
+				p.zoomItem("Small", 0),
+				p.zoomItem("Medium", 1),
+				p.zoomItem("Large", 2),
+			},
+		},
+		{
+			Title: "&Help",
+			Items: []basicwidget.MenuItem{
+				{
+					Text:       "About",
+					OnSelected: p.command("About"),
+				},
+			},
+		},
+	}
+}
+
+// contextMenuArea is a text with a context menu.
+type contextMenuArea struct {
+	guigui.DefaultWidget
+
+	text basicwidget.Text
+
+	checked bool
+	size    int
+}
+
+func (c *contextMenuArea) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	appender.AppendChildWidgetWithBounds(&c.text, context.Bounds(c))
 	return nil
 }
 
-func (p *Popups) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-		// Use IsWidgetOrBackgroundHitAtCursor. context.IsWidgetHitAtCursor doesn't work when a popup's transparent background exists.
-		if p.contextMenuPopup.IsWidgetOrBackgroundHitAtCursor(context, &p.contextMenuPopupClickHereText) {
-			pt := image.Pt(ebiten.CursorPosition())
-			context.SetPosition(&p.contextMenuPopup, pt)
-			p.contextMenuPopup.Open(context)
-		}
+func (c *contextMenuArea) AppendContextMenuItems(context *guigui.Context, items []basicwidget.ContextMenuItem) []basicwidget.ContextMenuItem {
+	return append(items,
+		basicwidget.ContextMenuItem{
+			Text:       "Item 1",
+			Shortcut:   "Ctrl+1",
+			OnSelected: func() {},
+		},
+		basicwidget.ContextMenuItem{
+			Text:    "Item 2",
+			Checked: c.checked,
+			OnSelected: func() {
+				c.checked = !c.checked
+			},
+		},
+		basicwidget.ContextMenuItem{
+			Text: "Size",
+			Submenu: []basicwidget.ContextMenuItem{
+				c.sizeItem("Small", 0),
+				c.sizeItem("Medium", 1),
+				c.sizeItem("Large", 2),
+				{
+					Border: true,
+				},
+				{
+					Text: "More",
+					Submenu: []basicwidget.ContextMenuItem{
+						c.sizeItem("Extra large", 3),
+					},
+				},
+			},
+		},
+		basicwidget.ContextMenuItem{
+			Border: true,
+		},
+		basicwidget.ContextMenuItem{
+			Text:     "Item 3",
+			Disabled: true,
+		},
+	)
+}
+
+func (c *contextMenuArea) sizeItem(text string, size int) basicwidget.ContextMenuItem {
+	return basicwidget.ContextMenuItem{
+		Text:    text,
+		Checked: c.size == size,
+		Radio:   true,
+		OnSelected: func() {
+			c.size = size
+		},
 	}
-	return guigui.HandleInputResult{}
+}
+
+func (c *contextMenuArea) DefaultSize(context *guigui.Context) image.Point {
+	return c.text.DefaultSize(context)
 }
 
 type simplePopupContent struct {
@@ -76,10 +76,18 @@ func (s *sidebarContent) Build(context *guigui.Context, appender *guigui.ChildWi
 			Text: "Lists",
 			ID:   "lists",
 		},
+		{
+			Text: "Tables",
+			ID:   "tables",
+		},
 		{
 			Text: "Popups",
 			ID:   "popups",
 		},
+		{
+			Text: "Tabs",
+			ID:   "tabs",
+		},
 	}
 
 	s.list.SetItems(items)
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package main
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+	"github.com/hajimehoshi/guigui/layout"
+)
+
+type Tables struct {
+	guigui.DefaultWidget
+
+	form      basicwidget.Form
+	tableText basicwidget.Text
+	table     basicwidget.Table[int]
+
+	dataSource tableDataSource
+}
+
+func (t *Tables) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	u := basicwidget.UnitSize(context)
+
+	t.tableText.SetValue("Table")
+	t.table.SetColumns([]basicwidget.TableColumn{
+		{
+			Title:           "#",
+			Width:           layout.FixedSize(3 * u),
+			HorizontalAlign: basicwidget.HorizontalAlignEnd,
+			Sortable:        true,
+		},
+		{
+			Title:    "Name",
+			Width:    layout.FlexibleSize(2),
+			MinWidth: 4 * u,
+			Sortable: true,
+		},
+		{
+			Title:    "Value",
+			Width:    layout.FlexibleSize(1),
+			MinWidth: 3 * u,
+		},
+	})
+	t.table.SetSelectionMode(basicwidget.ListSelectionModeMultiple)
+	t.table.SetDataSource(&t.dataSource)
+	t.table.SetOnSortChanged(func(column int, order basicwidget.TableSortOrder) {
+		t.dataSource.descending = order == basicwidget.TableSortOrderDescending
+	})
+	context.SetSize(&t.table, image.Pt(guigui.DefaultSize, 12*u))
+
+	t.form.SetItems([]basicwidget.FormItem{
+		{
+			PrimaryWidget:   &t.tableText,
+			SecondaryWidget: &t.table,
+		},
+	})
+
+	gl := layout.GridLayout{
+		Bounds: context.Bounds(t).Inset(u / 2),
+		Heights: []layout.Size{
+			layout.FixedSize(t.form.DefaultSize(context).Y),
+			layout.FlexibleSize(1),
+		},
+	}
+	appender.AppendChildWidgetWithBounds(&t.form, gl.CellBounds(0, 0))
+
+	return nil
+}
+
+// tableDataSource is a data source of many rows, which are created only when they are visible.
+type tableDataSource struct {
+	descending bool
+}
+
+func (t *tableDataSource) RowCount() int {
+	return 10000
+}
+
+func (t *tableDataSource) RowByIndex(index int) basicwidget.TableRow[int] {
+	n := index
+	if t.descending {
+		n = t.RowCount() - index - 1
+	}
+	return basicwidget.TableRow[int]{
+		Cells: []basicwidget.TableCell{
+			{Text: fmt.Sprintf("%d", n+1)},
+			{Text: fmt.Sprintf("Item %d", n+1)},
+			{Text: fmt.Sprintf("%d", (n*7919)%1000)},
+		},
+		ID: n,
+	}
+}
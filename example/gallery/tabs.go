@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+	"github.com/hajimehoshi/guigui/layout"
+)
+
+type tabDocument struct {
+	id        int
+	title     string
+	saved     string
+	textInput basicwidget.TextInput
+}
+
+func (t *tabDocument) isDirty() bool {
+	return t.textInput.Value() != t.saved
+}
+
+type Tabs struct {
+	guigui.DefaultWidget
+
+	newButton  basicwidget.Button
+	saveButton basicwidget.Button
+	tabView    basicwidget.TabView[int]
+
+	dialogStack *basicwidget.DialogStack
+	documents   []*tabDocument
+	nextID      int
+	items       []basicwidget.TabViewItem[int]
+}
+
+func (t *Tabs) SetDialogStack(dialogStack *basicwidget.DialogStack) {
+	t.dialogStack = dialogStack
+}
+
+func (t *Tabs) addDocument() *tabDocument {
+	t.nextID++
+	d := &tabDocument{
+		id:    t.nextID,
+		title: fmt.Sprintf("Document %d", t.nextID),
+	}
+	d.textInput.SetMultiline(true)
+	d.textInput.SetAutoWrap(true)
+	t.documents = append(t.documents, d)
+	return d
+}
+
+func (t *Tabs) closeDocument(index int) {
+	if index < 0 || index >= len(t.documents) {
+		return
+	}
+	t.documents = slices.Delete(t.documents, index, index+1)
+}
+
+func (t *Tabs) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	if t.nextID == 0 {
+		for range 3 {
+			t.addDocument()
+		}
+	}
+
+	t.newButton.SetText("New tab")
+	t.newButton.SetOnUp(func() {
+		d := t.addDocument()
+		t.updateItems()
+		t.tabView.SelectItemByID(d.id)
+	})
+	t.saveButton.SetText("Save")
+	t.saveButton.SetOnUp(func() {
+		if index := t.tabView.SelectedItemIndex(); index >= 0 && index < len(t.documents) {
+			d := t.documents[index]
+			d.saved = d.textInput.Value()
+		}
+	})
+	if index := t.tabView.SelectedItemIndex(); index >= 0 && index < len(t.documents) {
+		context.SetEnabled(&t.saveButton, t.documents[index].isDirty())
+	} else {
+		context.SetEnabled(&t.saveButton, false)
+	}
+
+	t.tabView.SetOnItemCloseRequested(func(index int) {
+		if index < 0 || index >= len(t.documents) {
+			return
+		}
+		d := t.documents[index]
+		if !d.isDirty() {
+			t.closeDocument(index)
+			return
+		}
+		t.dialogStack.Confirm(context, "Close", fmt.Sprintf("%s has unsaved changes. Do you want to discard them?", d.title), func(ok bool) {
+			if !ok {
+				return
+			}
+			// The index might be changed while the dialog is open.
+			if i := slices.Index(t.documents, d); i >= 0 {
+				t.closeDocument(i)
+			}
+		})
+	})
+	t.tabView.SetOnItemsMoved(func(from, count, to int) {
+		basicwidget.MoveItemsInSlice(t.documents, from, count, to)
+	})
+	t.updateItems()
+
+	u := basicwidget.UnitSize(context)
+	gl := layout.GridLayout{
+		Bounds: context.Bounds(t).Inset(u / 2),
+		Heights: []layout.Size{
+			layout.FixedSize(t.newButton.DefaultSize(context).Y),
+			layout.FlexibleSize(1),
+		},
+		RowGap: u / 2,
+	}
+	{
+		b := gl.CellBounds(0, 0)
+		w := t.newButton.DefaultSize(context).X
+		b.Max.X = b.Min.X + w
+		appender.AppendChildWidgetWithBounds(&t.newButton, b)
+		b.Min.X = b.Max.X + u/4
+		b.Max.X = b.Min.X + t.saveButton.DefaultSize(context).X
+		appender.AppendChildWidgetWithBounds(&t.saveButton, b)
+	}
+	appender.AppendChildWidgetWithBounds(&t.tabView, gl.CellBounds(0, 1))
+
+	return nil
+}
+
+func (t *Tabs) updateItems() {
+	t.items = slices.Delete(t.items, 0, len(t.items))
+	for _, d := range t.documents {
+		t.items = append(t.items, basicwidget.TabViewItem[int]{
+			Text:     d.title,
+			Content:  &d.textInput,
+			Closable: true,
+			Dirty:    d.isDirty(),
+			Movable:  true,
+			ID:       d.id,
+		})
+	}
+	t.tabView.SetItems(t.items)
+}
@@ -23,6 +23,8 @@ type TextInputs struct {
 	multilineTextInput          basicwidget.TextInput
 	inlineText                  basicwidget.Text
 	inlineTextInput             inlineTextInputContainer
+	comboBoxText                basicwidget.Text
+	comboBox                    basicwidget.ComboBox[string]
 
 	configForm                      basicwidget.Form
 	horizontalAlignText             basicwidget.Text
@@ -122,6 +124,18 @@ func (t *TextInputs) Build(context *guigui.Context, appender *guigui.ChildWidget
 	context.SetEnabled(&t.inlineTextInput, t.model.TextInputs().Enabled())
 	context.SetSize(&t.inlineTextInput, image.Pt(width, guigui.DefaultSize))
 
+	t.comboBoxText.SetValue("Combo box")
+	var comboBoxItems []basicwidget.ComboBoxItem[string]
+	for _, fruit := range []string{"Apple", "Apricot", "Banana", "Blueberry", "Cherry", "Grape", "Lemon", "Orange", "Peach", "Pineapple"} {
+		comboBoxItems = append(comboBoxItems, basicwidget.ComboBoxItem[string]{
+			Text: fruit,
+			ID:   fruit,
+		})
+	}
+	t.comboBox.SetItems(comboBoxItems)
+	context.SetEnabled(&t.comboBox, t.model.TextInputs().Enabled())
+	context.SetSize(&t.comboBox, image.Pt(width, guigui.DefaultSize))
+
 	t.textInputForm.SetItems([]basicwidget.FormItem{
 		{
 			PrimaryWidget:   &t.singleLineText,
@@ -139,6 +153,10 @@ func (t *TextInputs) Build(context *guigui.Context, appender *guigui.ChildWidget
 			PrimaryWidget:   &t.inlineText,
 			SecondaryWidget: &t.inlineTextInput,
 		},
+		{
+			PrimaryWidget:   &t.comboBoxText,
+			SecondaryWidget: &t.comboBox,
+		},
 	})
 
 	// Configurations
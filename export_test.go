@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func FindFocusCandidate(current image.Rectangle, candidates []image.Rectangle, direction FocusDirection) int {
+	return findFocusCandidate(current, candidates, direction)
+}
+
+func WrapFocusBounds(bounds image.Rectangle, container image.Rectangle, direction FocusDirection) image.Rectangle {
+	return wrapFocusBounds(bounds, container, direction)
+}
+
+// TestApp runs the phases of an app one by one without the game loop.
+type TestApp struct {
+	app *app
+}
+
+func NewTestApp(root Widget, size image.Point) *TestApp {
+	a := &app{
+		root:         root,
+		deviceScale:  1,
+		screenWidth:  float64(size.X),
+		screenHeight: float64(size.Y),
+	}
+	a.root.widgetState().root = true
+	a.focusedWidget = root
+	a.context.app = a
+	a.context.SetSize(root, size)
+	return &TestApp{app: a}
+}
+
+func (t *TestApp) Context() *Context {
+	return &t.app.context
+}
+
+func (t *TestApp) Build() error {
+	t.app.context.inBuild = true
+	defer func() {
+		t.app.context.inBuild = false
+	}()
+	return t.app.build()
+}
+
+func (t *TestApp) HandlePointingInput() {
+	t.app.handleInputWidget(handleInputTypePointing)
+}
+
+func (t *TestApp) Tick() error {
+	return t.app.updateWidget(t.app.root)
+}
+
+func (t *TestApp) Draw(dst *ebiten.Image) {
+	t.app.requestRedraw(t.app.bounds())
+	t.app.Draw(dst)
+}
+
+func (t *TestApp) RunTasks() {
+	t.app.runTasks()
+}
+
+// TakeRedrawRequest reports whether widget is requested to redraw, and clears the request.
+func (t *TestApp) TakeRedrawRequest(widget Widget) bool {
+	widgetState := widget.widgetState()
+	dirty := widgetState.dirty
+	widgetState.dirty = false
+	widgetState.dirtyAt = ""
+	return dirty
+}
+
+func (t *TestApp) RunPostedTasks() {
+	t.app.runPostedTasks()
+}
+
+// NotifyPointerEvents delivers the hover notifications as Update does.
+func (t *TestApp) NotifyPointerEvents() {
+	t.app.validatePointerCapture()
+	t.app.notifyPointerEvents()
+}
+
+// PressPointer delivers the press notifications as if button were pressed.
+func (t *TestApp) PressPointer(button ebiten.MouseButton) {
+	t.app.notifyPointerPress(button)
+}
+
+// ReleasePointer delivers the release notifications as if button were released.
+func (t *TestApp) ReleasePointer(button ebiten.MouseButton) {
+	t.app.notifyPointerRelease(button)
+}
+
+func (t *TestApp) ReleasePointerCaptureIfNeeded() {
+	t.app.releasePointerCaptureIfNeeded()
+}
+
+// HandleButtonInput handles the button inputs as Update does.
+func (t *TestApp) HandleButtonInput() {
+	t.app.handleButtonInput()
+}
+
+// SetOverlayFuncs replaces the registered overlay functions, and returns the previous ones.
+func SetOverlayFuncs(funcs []func() Widget) []func() Widget {
+	old := theOverlayFuncs
+	theOverlayFuncs = funcs
+	return old
+}
+
+func ScreenImagePoint(point image.Point, appBounds image.Rectangle, screenBounds image.Rectangle) image.Point {
+	return screenImagePoint(point, appBounds, screenBounds)
+}
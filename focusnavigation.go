@@ -0,0 +1,366 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/hajimehoshi/guigui/internal/inputrepeat"
+)
+
+type FocusDirection int
+
+const (
+	FocusDirectionUp FocusDirection = iota
+	FocusDirectionDown
+	FocusDirectionLeft
+	FocusDirectionRight
+)
+
+// FocusNavigationMode specifies how directional focus navigation behaves in a container widget.
+type FocusNavigationMode int
+
+const (
+	// FocusNavigationModeDefault lets the navigation leave the container.
+	FocusNavigationModeDefault FocusNavigationMode = iota
+
+	// FocusNavigationModeContain keeps the navigation in the container.
+	// The focus doesn't move at the edges of the container.
+	FocusNavigationModeContain
+
+	// FocusNavigationModeWrap keeps the navigation in the container,
+	// and moves the focus to the opposite edge at the edges of the container.
+	FocusNavigationModeWrap
+)
+
+// ActivateHandler is implemented by widgets that can be activated while focused,
+// e.g. by the Enter key, the space key or the primary button of a gamepad.
+//
+// guigui calls HandleActivate only when [RunOptions.FocusNavigationEnabled] is true.
+type ActivateHandler interface {
+	HandleActivate(context *Context)
+}
+
+// BackHandler is implemented by widgets that handle a back action,
+// e.g. by the Escape key or the secondary button of a gamepad.
+//
+// HandleBack is called for the focused widget and its ancestors in order until one returns true.
+// guigui calls HandleBack only when [RunOptions.FocusNavigationEnabled] is true.
+type BackHandler interface {
+	HandleBack(context *Context) bool
+}
+
+// SetFocusable sets whether widget can be focused by directional focus navigation.
+// A widget can be focused by SetFocused regardless of this.
+func (c *Context) SetFocusable(widget Widget, focusable bool) {
+	widget.widgetState().focusable = focusable
+}
+
+func (c *Context) IsFocusable(widget Widget) bool {
+	return widget.widgetState().focusable
+}
+
+// SetFocusNavigationMode sets how directional focus navigation behaves in widget's descendants.
+func (c *Context) SetFocusNavigationMode(widget Widget, mode FocusNavigationMode) {
+	widget.widgetState().focusNavigationMode = mode
+}
+
+// IsFocusVisible reports whether widget is focused and the focus should be indicated visually.
+// This is true when the focus was moved by directional focus navigation, and false when the focus was moved by e.g. a click.
+func (c *Context) IsFocusVisible(widget Widget) bool {
+	return c.app.focusVisible && c.IsFocused(widget)
+}
+
+// MoveFocus moves the focus to the nearest focusable widget in the direction, and reports whether the focus is moved.
+// The distance is measured by the visible bounds of the widgets.
+//
+// If no focusable widget is focused, MoveFocus focuses the top-left focusable widget.
+// MoveFocus doesn't move the focus outside of the nearest container whose FocusNavigationMode is not FocusNavigationModeDefault.
+func (c *Context) MoveFocus(direction FocusDirection) bool {
+	current := c.FocusedWidget()
+	ws := current.widgetState()
+
+	scope := c.app.root
+	for w := current; w != nil; w = w.widgetState().parent {
+		if w.widgetState().focusNavigationMode != FocusNavigationModeDefault {
+			scope = w
+			break
+		}
+	}
+
+	// If a container like a popup is focused, focus its first widget.
+	if !ws.focusable || !ws.isInTree() || c.VisibleBounds(current).Empty() {
+		return c.focusFirst(scope)
+	}
+
+	c.app.tmpFocusCandidates = c.appendFocusCandidates(c.app.tmpFocusCandidates[:0], scope, ws.z)
+	c.app.tmpFocusCandidateBounds = c.app.tmpFocusCandidateBounds[:0]
+	for _, w := range c.app.tmpFocusCandidates {
+		c.app.tmpFocusCandidateBounds = append(c.app.tmpFocusCandidateBounds, c.VisibleBounds(w))
+	}
+
+	bounds := c.VisibleBounds(current)
+	idx := findFocusCandidate(bounds, c.app.tmpFocusCandidateBounds, direction)
+	if idx < 0 && scope.widgetState().focusNavigationMode == FocusNavigationModeWrap {
+		idx = findFocusCandidate(wrapFocusBounds(bounds, c.VisibleBounds(scope), direction), c.app.tmpFocusCandidateBounds, direction)
+	}
+	if idx < 0 {
+		return false
+	}
+	next := c.app.tmpFocusCandidates[idx]
+	if next.widgetState() == ws {
+		return false
+	}
+	c.focusByNavigation(next)
+	return true
+}
+
+// focusByNavigation focuses widget, indicates the focus visually, and scrolls widget into view.
+func (c *Context) focusByNavigation(widget Widget) {
+	c.focus(widget)
+	c.app.focusVisible = true
+	c.ScrollIntoView(widget, ScrollAlignmentNearest)
+}
+
+// focusFirst focuses the top-left focusable widget with the highest z under scope.
+func (c *Context) focusFirst(scope Widget) bool {
+	c.app.tmpFocusCandidates = c.appendFocusCandidates(c.app.tmpFocusCandidates[:0], scope, math.MinInt)
+	var first Widget
+	var firstBounds image.Rectangle
+	for _, w := range c.app.tmpFocusCandidates {
+		b := c.VisibleBounds(w)
+		if first != nil {
+			if w.widgetState().z < first.widgetState().z {
+				continue
+			}
+			if w.widgetState().z == first.widgetState().z && (b.Min.Y > firstBounds.Min.Y || b.Min.Y == firstBounds.Min.Y && b.Min.X >= firstBounds.Min.X) {
+				continue
+			}
+		}
+		first = w
+		firstBounds = b
+	}
+	if first == nil {
+		return false
+	}
+	c.focusByNavigation(first)
+	return true
+}
+
+// appendFocusCandidates appends the focusable widgets under widget whose z is equal to or greater than minZ.
+func (c *Context) appendFocusCandidates(widgets []Widget, widget Widget, minZ int) []Widget {
+	ws := widget.widgetState()
+	if ws.hidden || ws.disabled {
+		return widgets
+	}
+	if ws.focusable && ws.z >= minZ && !c.VisibleBounds(widget).Empty() {
+		widgets = append(widgets, widget)
+	}
+	for _, child := range ws.children {
+		widgets = c.appendFocusCandidates(widgets, child, minZ)
+	}
+	return widgets
+}
+
+// findFocusCandidate returns the index of the best candidate in the direction from current, or -1 if there is none.
+//
+// A candidate must be beyond current in the direction.
+// The best candidate has the smallest sum of the distance in the direction and the weighted distance in the orthogonal direction.
+func findFocusCandidate(current image.Rectangle, candidates []image.Rectangle, direction FocusDirection) int {
+	// Prefer candidates aligned with the current bounds.
+	const orthogonalWeight = 2
+
+	bestIdx := -1
+	var bestScore, bestCenterDistance float64
+	for i, b := range candidates {
+		if b == current {
+			continue
+		}
+
+		var primary, orthogonal, centerDistance int
+		switch direction {
+		case FocusDirectionUp:
+			if b.Min.Y >= current.Min.Y || b.Max.Y > current.Max.Y {
+				continue
+			}
+			primary = max(current.Min.Y-b.Max.Y, 0)
+			orthogonal = rangeGap(b.Min.X, b.Max.X, current.Min.X, current.Max.X)
+			centerDistance = (b.Min.X + b.Max.X) - (current.Min.X + current.Max.X)
+		case FocusDirectionDown:
+			if b.Max.Y <= current.Max.Y || b.Min.Y < current.Min.Y {
+				continue
+			}
+			primary = max(b.Min.Y-current.Max.Y, 0)
+			orthogonal = rangeGap(b.Min.X, b.Max.X, current.Min.X, current.Max.X)
+			centerDistance = (b.Min.X + b.Max.X) - (current.Min.X + current.Max.X)
+		case FocusDirectionLeft:
+			if b.Min.X >= current.Min.X || b.Max.X > current.Max.X {
+				continue
+			}
+			primary = max(current.Min.X-b.Max.X, 0)
+			orthogonal = rangeGap(b.Min.Y, b.Max.Y, current.Min.Y, current.Max.Y)
+			centerDistance = (b.Min.Y + b.Max.Y) - (current.Min.Y + current.Max.Y)
+		case FocusDirectionRight:
+			if b.Max.X <= current.Max.X || b.Min.X < current.Min.X {
+				continue
+			}
+			primary = max(b.Min.X-current.Max.X, 0)
+			orthogonal = rangeGap(b.Min.Y, b.Max.Y, current.Min.Y, current.Max.Y)
+			centerDistance = (b.Min.Y + b.Max.Y) - (current.Min.Y + current.Max.Y)
+		}
+
+		score := float64(primary) + orthogonalWeight*float64(orthogonal)
+		cd := math.Abs(float64(centerDistance))
+		if bestIdx >= 0 && (score > bestScore || score == bestScore && cd >= bestCenterDistance) {
+			continue
+		}
+		bestIdx = i
+		bestScore = score
+		bestCenterDistance = cd
+	}
+	return bestIdx
+}
+
+// rangeGap returns the gap between the ranges [min0, max0) and [min1, max1), or 0 if they overlap.
+func rangeGap(min0, max0, min1, max1 int) int {
+	if max0 <= min1 {
+		return min1 - max0
+	}
+	if max1 <= min0 {
+		return min0 - max1
+	}
+	return 0
+}
+
+// wrapFocusBounds returns the bounds moved just outside of the opposite edge of the container,
+// so that the navigation from there finds the widgets at the opposite edge.
+func wrapFocusBounds(bounds image.Rectangle, container image.Rectangle, direction FocusDirection) image.Rectangle {
+	switch direction {
+	case FocusDirectionUp:
+		return bounds.Add(image.Pt(0, container.Max.Y-bounds.Min.Y))
+	case FocusDirectionDown:
+		return bounds.Add(image.Pt(0, container.Min.Y-bounds.Max.Y))
+	case FocusDirectionLeft:
+		return bounds.Add(image.Pt(container.Max.X-bounds.Min.X, 0))
+	case FocusDirectionRight:
+		return bounds.Add(image.Pt(container.Min.X-bounds.Max.X, 0))
+	}
+	return bounds
+}
+
+// gamepadAxisThreshold is the threshold of a gamepad stick to be regarded as a direction.
+const gamepadAxisThreshold = 0.5
+
+// focusNavigationDirection returns the direction of the directional focus navigation input in this tick.
+func (a *app) focusNavigationDirection() (FocusDirection, bool) {
+	switch {
+	case inputrepeat.IsKeyRepeating(ebiten.KeyArrowUp):
+		return FocusDirectionUp, true
+	case inputrepeat.IsKeyRepeating(ebiten.KeyArrowDown):
+		return FocusDirectionDown, true
+	case inputrepeat.IsKeyRepeating(ebiten.KeyArrowLeft):
+		return FocusDirectionLeft, true
+	case inputrepeat.IsKeyRepeating(ebiten.KeyArrowRight):
+		return FocusDirectionRight, true
+	}
+
+	a.gamepadIDs = ebiten.AppendGamepadIDs(a.gamepadIDs[:0])
+	for _, id := range a.gamepadIDs {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		switch {
+		case inputrepeat.IsStandardGamepadButtonRepeating(id, ebiten.StandardGamepadButtonLeftTop):
+			return FocusDirectionUp, true
+		case inputrepeat.IsStandardGamepadButtonRepeating(id, ebiten.StandardGamepadButtonLeftBottom):
+			return FocusDirectionDown, true
+		case inputrepeat.IsStandardGamepadButtonRepeating(id, ebiten.StandardGamepadButtonLeftLeft):
+			return FocusDirectionLeft, true
+		case inputrepeat.IsStandardGamepadButtonRepeating(id, ebiten.StandardGamepadButtonLeftRight):
+			return FocusDirectionRight, true
+		}
+	}
+
+	// Treat the left stick as a D-pad. The stick must return to the neutral position before the next navigation.
+	var dir FocusDirection
+	var tilted bool
+	for _, id := range a.gamepadIDs {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		x := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+		y := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+		switch {
+		case math.Abs(x) < gamepadAxisThreshold && math.Abs(y) < gamepadAxisThreshold:
+			continue
+		case math.Abs(x) >= math.Abs(y) && x < 0:
+			dir = FocusDirectionLeft
+		case math.Abs(x) >= math.Abs(y):
+			dir = FocusDirectionRight
+		case y < 0:
+			dir = FocusDirectionUp
+		default:
+			dir = FocusDirectionDown
+		}
+		tilted = true
+		break
+	}
+	if !tilted {
+		a.gamepadStickTilted = false
+		return 0, false
+	}
+	if a.gamepadStickTilted {
+		return 0, false
+	}
+	a.gamepadStickTilted = true
+	return dir, true
+}
+
+func (a *app) isStandardGamepadButtonJustPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range a.gamepadIDs {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleFocusNavigationInput handles the directional focus navigation, activation and back actions.
+// This is called when no widget handles the button inputs.
+func (a *app) handleFocusNavigationInput() {
+	if dir, ok := a.focusNavigationDirection(); ok {
+		a.context.MoveFocus(dir)
+		return
+	}
+
+	focused := a.context.FocusedWidget()
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) || a.isStandardGamepadButtonJustPressed(ebiten.StandardGamepadButtonRightBottom) {
+		if h, ok := focused.(ActivateHandler); ok {
+			a.callForWidget(focused, func() {
+				h.HandleActivate(&a.context)
+			})
+		}
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || a.isStandardGamepadButtonJustPressed(ebiten.StandardGamepadButtonRightRight) {
+		for w := focused; w != nil; w = w.widgetState().parent {
+			h, ok := w.(BackHandler)
+			if !ok {
+				continue
+			}
+			var handled bool
+			a.callForWidget(w, func() {
+				handled = h.HandleBack(&a.context)
+			})
+			if handled {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+func TestFindFocusCandidate(t *testing.T) {
+	// A 3x3 grid of 10x10 cells with 10-pixel gaps, and a wide widget below the grid.
+	var candidates []image.Rectangle
+	for j := range 3 {
+		for i := range 3 {
+			candidates = append(candidates, image.Rect(20*i, 20*j, 20*i+10, 20*j+10))
+		}
+	}
+	candidates = append(candidates, image.Rect(0, 60, 50, 70))
+
+	testCases := []struct {
+		name      string
+		current   int
+		direction guigui.FocusDirection
+		want      int
+	}{
+		{"center up", 4, guigui.FocusDirectionUp, 1},
+		{"center down", 4, guigui.FocusDirectionDown, 7},
+		{"center left", 4, guigui.FocusDirectionLeft, 3},
+		{"center right", 4, guigui.FocusDirectionRight, 5},
+		{"top-left up", 0, guigui.FocusDirectionUp, -1},
+		{"top-left left", 0, guigui.FocusDirectionLeft, -1},
+		{"bottom-right down", 8, guigui.FocusDirectionDown, 9},
+		{"wide up", 9, guigui.FocusDirectionUp, 7},
+		{"top-right right", 2, guigui.FocusDirectionRight, -1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := guigui.FindFocusCandidate(candidates[tc.current], candidates, tc.direction); got != tc.want {
+				t.Errorf("got: %d, want: %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindFocusCandidatePrefersAlignedWidgets(t *testing.T) {
+	current := image.Rect(0, 0, 10, 10)
+	candidates := []image.Rectangle{
+		// Near but not aligned.
+		image.Rect(15, 30, 25, 40),
+		// Far but aligned.
+		image.Rect(40, 0, 50, 10),
+	}
+	if got, want := guigui.FindFocusCandidate(current, candidates, guigui.FocusDirectionRight), 1; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestWrapFocusBounds(t *testing.T) {
+	container := image.Rect(0, 0, 100, 100)
+	candidates := []image.Rectangle{
+		image.Rect(0, 0, 10, 10),
+		image.Rect(90, 0, 100, 10),
+		image.Rect(0, 90, 10, 100),
+	}
+
+	// Moving right from the right edge wraps to the left edge.
+	b := guigui.WrapFocusBounds(candidates[1], container, guigui.FocusDirectionRight)
+	if got, want := guigui.FindFocusCandidate(b, candidates, guigui.FocusDirectionRight), 0; got != want {
+		t.Errorf("right: got: %d, want: %d", got, want)
+	}
+
+	// Moving up from the top edge wraps to the bottom edge.
+	b = guigui.WrapFocusBounds(candidates[0], container, guigui.FocusDirectionUp)
+	if got, want := guigui.FindFocusCandidate(b, candidates, guigui.FocusDirectionUp), 2; got != want {
+		t.Errorf("up: got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,447 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+// Package gesture provides a recognizer of touch gestures: tap, double-tap, long-press, pan, swipe and pinch.
+package gesture
+
+import (
+	"image"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// Touch is a touch state in a tick.
+type Touch struct {
+	ID       ebiten.TouchID
+	Position image.Point
+}
+
+type SwipeDirection int
+
+const (
+	SwipeDirectionLeft SwipeDirection = iota
+	SwipeDirectionRight
+	SwipeDirectionUp
+	SwipeDirectionDown
+)
+
+const (
+	// defaultTouchSlop is the distance in pixels a touch can move before it is regarded as a pan.
+	defaultTouchSlop = 8
+
+	// defaultSwipeVelocity is the minimum velocity in pixels per second to regard a pan as a swipe.
+	defaultSwipeVelocity = 600
+
+	// velocitySampleCount is the number of ticks to calculate the velocity of a pan.
+	velocitySampleCount = 5
+)
+
+func longPressTicks() int {
+	return ebiten.TPS() / 2
+}
+
+func doubleTapTicks() int {
+	return ebiten.TPS() * 3 / 10
+}
+
+type state int
+
+const (
+	stateIdle state = iota
+	statePressed
+	statePanning
+	stateLongPressed
+	statePinching
+
+	// stateDone ignores touches until all the touches are released.
+	stateDone
+)
+
+type sample struct {
+	tick     int64
+	position image.Point
+}
+
+type trackedTouch struct {
+	id        ebiten.TouchID
+	start     image.Point
+	position  image.Point
+	startTick int64
+	samples   [velocitySampleCount]sample
+	sampleNum int
+}
+
+func (t *trackedTouch) addSample(tick int64, position image.Point) {
+	t.samples[t.sampleNum%velocitySampleCount] = sample{
+		tick:     tick,
+		position: position,
+	}
+	t.sampleNum++
+}
+
+// velocity returns the velocity in pixels per tick.
+func (t *trackedTouch) velocity() (float64, float64) {
+	if t.sampleNum < 2 {
+		return 0, 0
+	}
+	n := min(t.sampleNum, velocitySampleCount)
+	first := t.samples[(t.sampleNum-n)%velocitySampleCount]
+	last := t.samples[(t.sampleNum-1)%velocitySampleCount]
+	dt := float64(last.tick - first.tick)
+	if dt == 0 {
+		return 0, 0
+	}
+	return float64(last.position.X-first.position.X) / dt, float64(last.position.Y-first.position.Y) / dt
+}
+
+// Recognizer recognizes gestures from touches.
+//
+// Recognizer can be used in two ways.
+// A widget can call HandleTouchInput at every HandlePointingInput to recognize the touches started on the widget.
+// Or, Update can be called at every tick with arbitrary touches, e.g. synthetic touches in tests.
+//
+// A tap is reported immediately when the touch is released.
+// A second tap within a short time is reported as a double-tap instead of a tap.
+type Recognizer struct {
+	scale float64
+
+	onTap       func(position image.Point)
+	onDoubleTap func(position image.Point)
+	onLongPress func(position image.Point)
+	onPanStart  func(position image.Point)
+	onPan       func(dx, dy float64)
+	onPanEnd    func(velocityX, velocityY float64)
+	onSwipe     func(direction SwipeDirection)
+	onPinch     func(center image.Point, scale, rotation float64)
+
+	tick    int64
+	state   state
+	touches []trackedTouch
+
+	lastPanPosition image.Point
+
+	hasLastTap      bool
+	lastTapTick     int64
+	lastTapPosition image.Point
+
+	pinchDistance float64
+	pinchAngle    float64
+
+	acceptedIDs []ebiten.TouchID
+	tmpIDs      []ebiten.TouchID
+	tmpTouches  []Touch
+	tmpReleased []trackedTouch
+}
+
+func (r *Recognizer) SetOnTap(f func(position image.Point)) {
+	r.onTap = f
+}
+
+func (r *Recognizer) SetOnDoubleTap(f func(position image.Point)) {
+	r.onDoubleTap = f
+}
+
+func (r *Recognizer) SetOnLongPress(f func(position image.Point)) {
+	r.onLongPress = f
+}
+
+// SetOnPanStart sets the function called when a single touch starts moving.
+// position is the position where the touch started.
+func (r *Recognizer) SetOnPanStart(f func(position image.Point)) {
+	r.onPanStart = f
+}
+
+// SetOnPan sets the function called when a panning touch moves.
+func (r *Recognizer) SetOnPan(f func(dx, dy float64)) {
+	r.onPan = f
+}
+
+// SetOnPanEnd sets the function called when a pan ends.
+// The velocity is in pixels per second, and is zero if the pan is interrupted by another touch.
+func (r *Recognizer) SetOnPanEnd(f func(velocityX, velocityY float64)) {
+	r.onPanEnd = f
+}
+
+// SetOnSwipe sets the function called when a pan ends quickly.
+// A swipe is reported after the pan end.
+func (r *Recognizer) SetOnSwipe(f func(direction SwipeDirection)) {
+	r.onSwipe = f
+}
+
+// SetOnPinch sets the function called when two touches move.
+// scale is the ratio of the distance between the touches to the previous distance,
+// and rotation is the difference of the angle from the previous angle in radians.
+func (r *Recognizer) SetOnPinch(f func(center image.Point, scale, rotation float64)) {
+	r.onPinch = f
+}
+
+// SetScale sets the scale of the thresholds in pixels, e.g. the device scale factor.
+// The default scale is 1.
+func (r *Recognizer) SetScale(scale float64) {
+	r.scale = scale
+}
+
+func (r *Recognizer) touchSlop() float64 {
+	if r.scale > 0 {
+		return defaultTouchSlop * r.scale
+	}
+	return defaultTouchSlop
+}
+
+func (r *Recognizer) swipeVelocity() float64 {
+	if r.scale > 0 {
+		return defaultSwipeVelocity * r.scale
+	}
+	return defaultSwipeVelocity
+}
+
+// IsActive reports whether a pan or a pinch is in progress.
+func (r *Recognizer) IsActive() bool {
+	return r.state == statePanning || r.state == statePinching
+}
+
+// Reset cancels the current gestures.
+// The current touches are ignored until they are released.
+func (r *Recognizer) Reset() {
+	if len(r.touches) > 0 {
+		r.state = stateDone
+	} else {
+		r.state = stateIdle
+	}
+	r.hasLastTap = false
+}
+
+// HandleTouchInput recognizes the touches that started on widget.
+// HandleTouchInput should be called at every HandlePointingInput of widget.
+//
+// HandleTouchInput returns the result that widget handled the input while a pan or a pinch is in progress.
+func (r *Recognizer) HandleTouchInput(context *guigui.Context, widget guigui.Widget) guigui.HandleInputResult {
+	r.scale = context.Scale()
+
+	r.tmpIDs = inpututil.AppendJustPressedTouchIDs(r.tmpIDs[:0])
+	for _, id := range r.tmpIDs {
+		if slices.Contains(r.acceptedIDs, id) {
+			continue
+		}
+		if !context.IsWidgetHitAtTouch(widget, id) {
+			continue
+		}
+		r.acceptedIDs = append(r.acceptedIDs, id)
+	}
+
+	r.tmpIDs = ebiten.AppendTouchIDs(r.tmpIDs[:0])
+	r.acceptedIDs = slices.DeleteFunc(r.acceptedIDs, func(id ebiten.TouchID) bool {
+		return !slices.Contains(r.tmpIDs, id)
+	})
+
+	r.tmpTouches = slices.Delete(r.tmpTouches, 0, len(r.tmpTouches))
+	for _, id := range r.acceptedIDs {
+		r.tmpTouches = append(r.tmpTouches, Touch{
+			ID:       id,
+			Position: image.Pt(ebiten.TouchPosition(id)),
+		})
+	}
+	r.Update(r.tmpTouches)
+
+	if r.IsActive() {
+		return guigui.HandleInputByWidget(widget)
+	}
+	return guigui.HandleInputResult{}
+}
+
+// Update advances the recognizer by one tick with the current touches.
+func (r *Recognizer) Update(touches []Touch) {
+	r.tick++
+
+	// Update the tracked touches.
+	r.tmpReleased = slices.Delete(r.tmpReleased, 0, len(r.tmpReleased))
+	r.touches = slices.DeleteFunc(r.touches, func(t trackedTouch) bool {
+		idx := slices.IndexFunc(touches, func(touch Touch) bool {
+			return touch.ID == t.id
+		})
+		if idx < 0 {
+			r.tmpReleased = append(r.tmpReleased, t)
+			return true
+		}
+		return false
+	})
+	for i := range r.touches {
+		t := &r.touches[i]
+		idx := slices.IndexFunc(touches, func(touch Touch) bool {
+			return touch.ID == t.id
+		})
+		t.position = touches[idx].Position
+		t.addSample(r.tick, t.position)
+	}
+	var added bool
+	for _, touch := range touches {
+		if slices.ContainsFunc(r.touches, func(t trackedTouch) bool {
+			return t.id == touch.ID
+		}) {
+			continue
+		}
+		t := trackedTouch{
+			id:        touch.ID,
+			start:     touch.Position,
+			position:  touch.Position,
+			startTick: r.tick,
+		}
+		t.addSample(r.tick, touch.Position)
+		r.touches = append(r.touches, t)
+		added = true
+	}
+
+	switch {
+	case len(r.touches) == 0:
+		if len(r.tmpReleased) == 1 {
+			r.release(&r.tmpReleased[0])
+		}
+		r.state = stateIdle
+
+	case len(r.touches) == 1:
+		switch r.state {
+		case stateIdle:
+			if added {
+				r.state = statePressed
+			}
+		case statePressed, statePanning:
+			r.updateSingle(&r.touches[0])
+		case statePinching:
+			// Lifting one of two fingers ends the pinch.
+			r.state = stateDone
+		}
+
+	default:
+		switch r.state {
+		case stateIdle, statePressed, statePanning, stateLongPressed:
+			if r.state == statePanning && r.onPanEnd != nil {
+				r.onPanEnd(0, 0)
+			}
+			r.hasLastTap = false
+			r.state = statePinching
+			r.pinchDistance, r.pinchAngle = pinchMetrics(&r.touches[0], &r.touches[1])
+		case statePinching:
+			r.updatePinch()
+		}
+	}
+}
+
+func (r *Recognizer) updateSingle(t *trackedTouch) {
+	switch r.state {
+	case statePressed:
+		d := t.position.Sub(t.start)
+		if math.Hypot(float64(d.X), float64(d.Y)) > r.touchSlop() {
+			r.state = statePanning
+			r.hasLastTap = false
+			if r.onPanStart != nil {
+				r.onPanStart(t.start)
+			}
+			r.lastPanPosition = t.start
+			r.pan(t)
+			return
+		}
+		if r.tick-t.startTick >= int64(longPressTicks()) {
+			r.state = stateLongPressed
+			r.hasLastTap = false
+			if r.onLongPress != nil {
+				r.onLongPress(t.position)
+			}
+		}
+	case statePanning:
+		r.pan(t)
+	}
+}
+
+func (r *Recognizer) pan(t *trackedTouch) {
+	d := t.position.Sub(r.lastPanPosition)
+	r.lastPanPosition = t.position
+	if d == (image.Point{}) {
+		return
+	}
+	if r.onPan != nil {
+		r.onPan(float64(d.X), float64(d.Y))
+	}
+}
+
+func (r *Recognizer) release(t *trackedTouch) {
+	switch r.state {
+	case statePressed:
+		if r.hasLastTap && r.tick-r.lastTapTick <= int64(doubleTapTicks()) {
+			d := t.position.Sub(r.lastTapPosition)
+			if math.Hypot(float64(d.X), float64(d.Y)) <= 2*r.touchSlop() {
+				r.hasLastTap = false
+				if r.onDoubleTap != nil {
+					r.onDoubleTap(t.position)
+				}
+				return
+			}
+		}
+		r.hasLastTap = true
+		r.lastTapTick = r.tick
+		r.lastTapPosition = t.position
+		if r.onTap != nil {
+			r.onTap(t.position)
+		}
+
+	case statePanning:
+		vx, vy := t.velocity()
+		tps := float64(ebiten.TPS())
+		vx *= tps
+		vy *= tps
+		if r.onPanEnd != nil {
+			r.onPanEnd(vx, vy)
+		}
+		if math.Hypot(vx, vy) < r.swipeVelocity() {
+			return
+		}
+		if r.onSwipe == nil {
+			return
+		}
+		switch {
+		case math.Abs(vx) >= math.Abs(vy) && vx < 0:
+			r.onSwipe(SwipeDirectionLeft)
+		case math.Abs(vx) >= math.Abs(vy):
+			r.onSwipe(SwipeDirectionRight)
+		case vy < 0:
+			r.onSwipe(SwipeDirectionUp)
+		default:
+			r.onSwipe(SwipeDirectionDown)
+		}
+	}
+}
+
+func pinchMetrics(t0, t1 *trackedTouch) (distance, angle float64) {
+	d := t1.position.Sub(t0.position)
+	return math.Hypot(float64(d.X), float64(d.Y)), math.Atan2(float64(d.Y), float64(d.X))
+}
+
+func (r *Recognizer) updatePinch() {
+	t0, t1 := &r.touches[0], &r.touches[1]
+	distance, angle := pinchMetrics(t0, t1)
+	if r.pinchDistance == 0 {
+		r.pinchDistance, r.pinchAngle = distance, angle
+		return
+	}
+	scale := distance / r.pinchDistance
+	rotation := angle - r.pinchAngle
+	// Normalize the rotation to [-π, π].
+	for rotation > math.Pi {
+		rotation -= 2 * math.Pi
+	}
+	for rotation < -math.Pi {
+		rotation += 2 * math.Pi
+	}
+	r.pinchDistance, r.pinchAngle = distance, angle
+	if scale == 1 && rotation == 0 {
+		return
+	}
+	if r.onPinch != nil {
+		center := t0.position.Add(t1.position).Div(2)
+		r.onPinch(center, scale, rotation)
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package gesture_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/guigui/gesture"
+)
+
+type recorder struct {
+	taps       []image.Point
+	doubleTaps []image.Point
+	longPress  []image.Point
+	panStarts  []image.Point
+	panX       float64
+	panY       float64
+	panEnds    int
+	velocityX  float64
+	velocityY  float64
+	swipes     []gesture.SwipeDirection
+	scale      float64
+	rotation   float64
+}
+
+func newRecognizer(r *recorder) *gesture.Recognizer {
+	r.scale = 1
+	var g gesture.Recognizer
+	g.SetOnTap(func(position image.Point) {
+		r.taps = append(r.taps, position)
+	})
+	g.SetOnDoubleTap(func(position image.Point) {
+		r.doubleTaps = append(r.doubleTaps, position)
+	})
+	g.SetOnLongPress(func(position image.Point) {
+		r.longPress = append(r.longPress, position)
+	})
+	g.SetOnPanStart(func(position image.Point) {
+		r.panStarts = append(r.panStarts, position)
+	})
+	g.SetOnPan(func(dx, dy float64) {
+		r.panX += dx
+		r.panY += dy
+	})
+	g.SetOnPanEnd(func(vx, vy float64) {
+		r.panEnds++
+		r.velocityX = vx
+		r.velocityY = vy
+	})
+	g.SetOnSwipe(func(direction gesture.SwipeDirection) {
+		r.swipes = append(r.swipes, direction)
+	})
+	g.SetOnPinch(func(center image.Point, scale, rotation float64) {
+		r.scale *= scale
+		r.rotation += rotation
+	})
+	return &g
+}
+
+func touchAt(id ebiten.TouchID, x, y int) gesture.Touch {
+	return gesture.Touch{
+		ID:       id,
+		Position: image.Pt(x, y),
+	}
+}
+
+func TestTap(t *testing.T) {
+	var r recorder
+	g := newRecognizer(&r)
+
+	g.Update([]gesture.Touch{touchAt(1, 10, 10)})
+	g.Update([]gesture.Touch{touchAt(1, 12, 11)})
+	g.Update(nil)
+
+	if got, want := len(r.taps), 1; got != want {
+		t.Fatalf("len(taps): got: %d, want: %d", got, want)
+	}
+	if got, want := r.taps[0], image.Pt(12, 11); got != want {
+		t.Errorf("taps[0]: got: %v, want: %v", got, want)
+	}
+	if len(r.panStarts) != 0 || len(r.doubleTaps) != 0 || len(r.longPress) != 0 {
+		t.Errorf("a tap must not be recognized as other gestures: %+v", r)
+	}
+}
+
+func TestDoubleTap(t *testing.T) {
+	var r recorder
+	g := newRecognizer(&r)
+
+	g.Update([]gesture.Touch{touchAt(1, 10, 10)})
+	g.Update(nil)
+	g.Update(nil)
+	g.Update([]gesture.Touch{touchAt(2, 11, 10)})
+	g.Update(nil)
+
+	if got, want := len(r.taps), 1; got != want {
+		t.Errorf("len(taps): got: %d, want: %d", got, want)
+	}
+	if got, want := len(r.doubleTaps), 1; got != want {
+		t.Errorf("len(doubleTaps): got: %d, want: %d", got, want)
+	}
+
+	// Taps separated by a long interval are not a double-tap.
+	for range ebiten.TPS() {
+		g.Update(nil)
+	}
+	g.Update([]gesture.Touch{touchAt(3, 10, 10)})
+	g.Update(nil)
+	if got, want := len(r.taps), 2; got != want {
+		t.Errorf("len(taps): got: %d, want: %d", got, want)
+	}
+	if got, want := len(r.doubleTaps), 1; got != want {
+		t.Errorf("len(doubleTaps): got: %d, want: %d", got, want)
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	var r recorder
+	g := newRecognizer(&r)
+
+	for range ebiten.TPS() {
+		g.Update([]gesture.Touch{touchAt(1, 10, 10)})
+	}
+	g.Update(nil)
+
+	if got, want := len(r.longPress), 1; got != want {
+		t.Errorf("len(longPress): got: %d, want: %d", got, want)
+	}
+	if got, want := len(r.taps), 0; got != want {
+		t.Errorf("len(taps): got: %d, want: %d", got, want)
+	}
+}
+
+func TestPanAndSwipe(t *testing.T) {
+	var r recorder
+	g := newRecognizer(&r)
+
+	// Slow pan: 1 pixel per tick.
+	for i := range 40 {
+		g.Update([]gesture.Touch{touchAt(1, 100, 100+i)})
+	}
+	if !g.IsActive() {
+		t.Errorf("g.IsActive() during a pan: got: false, want: true")
+	}
+	g.Update(nil)
+	if g.IsActive() {
+		t.Errorf("g.IsActive() after a pan: got: true, want: false")
+	}
+
+	if got, want := len(r.panStarts), 1; got != want {
+		t.Fatalf("len(panStarts): got: %d, want: %d", got, want)
+	}
+	if got, want := r.panStarts[0], image.Pt(100, 100); got != want {
+		t.Errorf("panStarts[0]: got: %v, want: %v", got, want)
+	}
+	if got, want := r.panY, 39.0; got != want {
+		t.Errorf("panY: got: %v, want: %v", got, want)
+	}
+	if got, want := r.panEnds, 1; got != want {
+		t.Errorf("panEnds: got: %d, want: %d", got, want)
+	}
+	if got, want := r.velocityY, float64(ebiten.TPS()); got != want {
+		t.Errorf("velocityY: got: %v, want: %v", got, want)
+	}
+	if len(r.swipes) != 0 {
+		t.Errorf("a slow pan must not be a swipe: %v", r.swipes)
+	}
+	if len(r.taps) != 0 {
+		t.Errorf("a pan must not be a tap: %v", r.taps)
+	}
+
+	// Fast pan to the left: 30 pixels per tick.
+	for i := range 5 {
+		g.Update([]gesture.Touch{touchAt(2, 300-30*i, 100)})
+	}
+	g.Update(nil)
+	if got, want := r.swipes, []gesture.SwipeDirection{gesture.SwipeDirectionLeft}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("swipes: got: %v, want: %v", got, want)
+	}
+	if r.velocityX >= 0 {
+		t.Errorf("velocityX: got: %v, want: < 0", r.velocityX)
+	}
+}
+
+func TestPinch(t *testing.T) {
+	var r recorder
+	g := newRecognizer(&r)
+
+	g.Update([]gesture.Touch{touchAt(1, 100, 100)})
+	g.Update([]gesture.Touch{touchAt(1, 100, 100), touchAt(2, 200, 100)})
+	if !g.IsActive() {
+		t.Errorf("g.IsActive() during a pinch: got: false, want: true")
+	}
+	// Spread the fingers to double the distance.
+	g.Update([]gesture.Touch{touchAt(1, 50, 100), touchAt(2, 250, 100)})
+	// Rotate by 90 degrees.
+	g.Update([]gesture.Touch{touchAt(1, 150, 0), touchAt(2, 150, 200)})
+	g.Update([]gesture.Touch{touchAt(2, 150, 200)})
+	g.Update(nil)
+
+	if got, want := r.scale, 2.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("scale: got: %v, want: %v", got, want)
+	}
+	if got, want := r.rotation, math.Pi/2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("rotation: got: %v, want: %v", got, want)
+	}
+	if len(r.taps) != 0 || len(r.panStarts) != 0 {
+		t.Errorf("a pinch must not be recognized as a tap or a pan: %+v", r)
+	}
+}
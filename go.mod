@@ -3,11 +3,11 @@ module github.com/hajimehoshi/guigui
 go 1.23.0
 
 require (
-	github.com/atotto/clipboard v0.1.4
 	github.com/ebitengine/purego v0.9.0-alpha.5
 	github.com/hajimehoshi/ebiten/v2 v2.9.0-alpha.5.0.20250518103147-cd31850015bb
 	github.com/hajimehoshi/oklab v0.1.0
 	github.com/jeandeaual/go-locale v0.0.0-20250421151639-a9d6ed1b3d45
+	github.com/jezek/xgb v1.1.1
 	github.com/kisielk/errcheck v1.9.0
 	github.com/rivo/uniseg v0.4.7
 	golang.org/x/sys v0.33.0
@@ -20,7 +20,6 @@ require (
 	github.com/ebitengine/gomobile v0.0.0-20250329061421-6d0a8e981e4c // indirect
 	github.com/ebitengine/hideconsole v1.0.0 // indirect
 	github.com/go-text/typesetting v0.3.0 // indirect
-	github.com/jezek/xgb v1.1.1 // indirect
 	golang.org/x/image v0.27.0 // indirect
 	golang.org/x/mod v0.24.0 // indirect
 	golang.org/x/sync v0.14.0 // indirect
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+// Package inputrepeat provides the key repeat behavior shared by guigui and its widgets.
+package inputrepeat
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// IsKeyRepeating reports whether the key is just pressed or repeated by holding it in this tick.
+func IsKeyRepeating(key ebiten.Key) bool {
+	return isRepeating(inpututil.KeyPressDuration(key))
+}
+
+// IsMouseButtonRepeating reports whether the mouse button is just pressed or repeated by holding it in this tick.
+func IsMouseButtonRepeating(button ebiten.MouseButton) bool {
+	return isRepeating(inpututil.MouseButtonPressDuration(button))
+}
+
+// IsStandardGamepadButtonRepeating reports whether the gamepad button is just pressed or repeated by holding it in this tick.
+func IsStandardGamepadButtonRepeating(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool {
+	return isRepeating(inpututil.StandardGamepadButtonPressDuration(id, button))
+}
+
+func isRepeating(duration int) bool {
+	if duration == 1 {
+		return true
+	}
+	delay := ebiten.TPS() * 24 / 60
+	if duration < delay {
+		return false
+	}
+	return (duration-delay)%4 == 0
+}
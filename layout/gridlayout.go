@@ -43,6 +43,15 @@ func LazySize(f func(rowOrColumn int) Size) Size {
 	}
 }
 
+// Equal reports whether s and other are the same size.
+// Lazy sizes are not equal to any sizes, as their functions cannot be compared.
+func (s Size) Equal(other Size) bool {
+	if s.typ == sizeTypeLazy || other.typ == sizeTypeLazy {
+		return false
+	}
+	return s.typ == other.typ && s.value == other.value
+}
+
 var (
 	defaultWidths  = []Size{FlexibleSize(1)}
 	defaultHeights = []Size{FlexibleSize(1)}
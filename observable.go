@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"slices"
+	"sync"
+)
+
+// observable tracks the widgets that read a value and requests to redraw them when the value is changed.
+type observable struct {
+	m       sync.Mutex
+	app     *app
+	readers map[*widgetState]struct{}
+	version uint64
+
+	invalidating bool
+}
+
+// addReader must be called with o.m locked.
+func (o *observable) addReader(context *Context) {
+	if context == nil || context.app == nil {
+		return
+	}
+	o.app = context.app
+	widget := context.app.widgetInProgress
+	if widget == nil {
+		return
+	}
+	if o.readers == nil {
+		o.readers = map[*widgetState]struct{}{}
+	}
+	o.readers[widget.widgetState()] = struct{}{}
+}
+
+// notify must be called with o.m locked.
+//
+// The readers are invalidated on the UI thread, so notify can be called from any goroutine.
+func (o *observable) notify() {
+	o.version++
+	if o.app == nil || o.invalidating {
+		return
+	}
+	o.invalidating = true
+	o.app.enqueueTask(o.invalidateReaders)
+}
+
+func (o *observable) invalidateReaders() {
+	o.m.Lock()
+	readers := o.readers
+	o.readers = nil
+	o.invalidating = false
+	o.m.Unlock()
+
+	// The readers are registered again when they read the value next time.
+	for widgetState := range readers {
+		requestRedraw(widgetState)
+	}
+}
+
+// Observable is a value that requests to redraw the widgets reading it when the value is changed.
+//
+// The zero value is an observable with the zero value of T.
+// Observable is safe for concurrent use. SetValue can be called from any goroutine.
+type Observable[T comparable] struct {
+	observable
+	value T
+}
+
+// Value returns the current value.
+//
+// If Value is called from a widget's method like Build or Draw, the widget is redrawn when the value is changed.
+// context can be nil, e.g. when Value is called from a goroutine other than the UI thread.
+func (o *Observable[T]) Value(context *Context) T {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.addReader(context)
+	return o.value
+}
+
+// Version returns a number that is incremented whenever the value is changed.
+//
+// Version is useful to apply the value only when it is changed.
+// context can be nil. See also [Observable.Value].
+func (o *Observable[T]) Version(context *Context) uint64 {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.addReader(context)
+	return o.version
+}
+
+func (o *Observable[T]) SetValue(value T) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.value == value {
+		return
+	}
+	o.value = value
+	o.notify()
+}
+
+// ObservableList is a list of values that requests to redraw the widgets reading it when the list is changed.
+//
+// The zero value is an empty list.
+// ObservableList is safe for concurrent use. Its modifying methods can be called from any goroutine.
+type ObservableList[T any] struct {
+	observable
+	values []T
+}
+
+// Len returns the number of the values.
+//
+// context can be nil. See also [Observable.Value].
+func (o *ObservableList[T]) Len(context *Context) int {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.addReader(context)
+	return len(o.values)
+}
+
+// At returns the value at index.
+//
+// context can be nil. See also [Observable.Value].
+func (o *ObservableList[T]) At(context *Context, index int) T {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.addReader(context)
+	return o.values[index]
+}
+
+// AppendValues appends the values to values and returns the result.
+//
+// context can be nil. See also [Observable.Value].
+func (o *ObservableList[T]) AppendValues(context *Context, values []T) []T {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.addReader(context)
+	return append(values, o.values...)
+}
+
+// Version returns a number that is incremented whenever the list is changed.
+//
+// context can be nil. See also [Observable.Value].
+func (o *ObservableList[T]) Version(context *Context) uint64 {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.addReader(context)
+	return o.version
+}
+
+func (o *ObservableList[T]) SetValues(values []T) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.values = slices.Delete(o.values, 0, len(o.values))
+	o.values = append(o.values, values...)
+	o.notify()
+}
+
+func (o *ObservableList[T]) Set(index int, value T) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.values[index] = value
+	o.notify()
+}
+
+func (o *ObservableList[T]) Append(values ...T) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.values = append(o.values, values...)
+	o.notify()
+}
+
+func (o *ObservableList[T]) Insert(index int, values ...T) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.values = slices.Insert(o.values, index, values...)
+	o.notify()
+}
+
+// Delete removes the values in [start, end).
+func (o *ObservableList[T]) Delete(start, end int) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.values = slices.Delete(o.values, start, end)
+	o.notify()
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"sync"
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// readerWidget reads the observable value in Build if read is true.
+type readerWidget struct {
+	guigui.DefaultWidget
+
+	value *guigui.Observable[int]
+	read  bool
+}
+
+func (r *readerWidget) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	if r.read {
+		r.value.Value(context)
+	}
+	return nil
+}
+
+func TestObservableVersion(t *testing.T) {
+	var o guigui.Observable[int]
+	if got, want := o.Version(nil), uint64(0); got != want {
+		t.Errorf("Version: got: %d, want: %d", got, want)
+	}
+	o.SetValue(1)
+	if got, want := o.Version(nil), uint64(1); got != want {
+		t.Errorf("Version: got: %d, want: %d", got, want)
+	}
+	// Setting the same value doesn't change the version.
+	o.SetValue(1)
+	if got, want := o.Version(nil), uint64(1); got != want {
+		t.Errorf("Version: got: %d, want: %d", got, want)
+	}
+	o.SetValue(2)
+	if got, want := o.Version(nil), uint64(2); got != want {
+		t.Errorf("Version: got: %d, want: %d", got, want)
+	}
+
+	var l guigui.ObservableList[int]
+	l.Append(1, 2)
+	l.Set(0, 3)
+	l.Delete(0, 1)
+	if got, want := l.Version(nil), uint64(3); got != want {
+		t.Errorf("Version: got: %d, want: %d", got, want)
+	}
+}
+
+func TestObservableInvalidatesReaders(t *testing.T) {
+	var o guigui.Observable[int]
+	r := &readerWidget{
+		value: &o,
+		read:  true,
+	}
+	a := guigui.NewTestApp(r, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	a.TakeRedrawRequest(r)
+
+	// The reader is invalidated on the UI thread.
+	o.SetValue(1)
+	if a.TakeRedrawRequest(r) {
+		t.Errorf("redraw is requested before running the tasks")
+	}
+	a.RunTasks()
+	if !a.TakeRedrawRequest(r) {
+		t.Errorf("redraw is not requested after the value is changed")
+	}
+
+	// Setting the same value doesn't invalidate the reader.
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	o.SetValue(1)
+	a.RunTasks()
+	if a.TakeRedrawRequest(r) {
+		t.Errorf("redraw is requested after the same value is set")
+	}
+
+	// The reader is unregistered after the invalidation, and is not invalidated unless it reads the value again.
+	o.SetValue(2)
+	a.RunTasks()
+	a.TakeRedrawRequest(r)
+	r.read = false
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	o.SetValue(3)
+	a.RunTasks()
+	if a.TakeRedrawRequest(r) {
+		t.Errorf("redraw is requested for a widget that no longer reads the value")
+	}
+}
+
+func TestObservableConcurrentUpdates(t *testing.T) {
+	var o guigui.Observable[int]
+	var l guigui.ObservableList[int]
+	r := &readerWidget{
+		value: &o,
+		read:  true,
+	}
+	a := guigui.NewTestApp(r, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	a.TakeRedrawRequest(r)
+
+	const n = 8
+	const m = 100
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range m {
+				o.SetValue(i*m + j + 1)
+				l.Append(j)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, want := l.Len(nil), n*m; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+	if got, want := l.Version(nil), uint64(n*m); got != want {
+		t.Errorf("Version: got: %d, want: %d", got, want)
+	}
+	a.RunTasks()
+	if !a.TakeRedrawRequest(r) {
+		t.Errorf("redraw is not requested after the value is changed from other goroutines")
+	}
+}
+
+func TestObservableReadAfterCaughtError(t *testing.T) {
+	var o guigui.Observable[int]
+	content := panicWidget{panicIn: "Tick", value: errTest}
+	var b guigui.ErrorBoundary
+	b.SetContent(&content)
+	a := guigui.NewTestApp(&b, image.Pt(100, 100))
+	// The value read in the error callback belongs to the boundary, not to the failed widget.
+	b.SetOnError(func(err error) {
+		o.Value(a.Context())
+	})
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Tick(); err != nil {
+		t.Fatal(err)
+	}
+	a.TakeRedrawRequest(&b)
+	a.TakeRedrawRequest(&content)
+
+	o.SetValue(1)
+	a.RunTasks()
+	if !a.TakeRedrawRequest(&b) {
+		t.Errorf("redraw is not requested for the error boundary")
+	}
+	if a.TakeRedrawRequest(&content) {
+		t.Errorf("redraw is requested for the failed widget")
+	}
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+var theOverlayFuncs []func() Widget
+
+// RegisterOverlay registers a function that creates an overlay widget.
+//
+// An overlay implements an app-wide feature that doesn't belong to a particular widget, e.g. a context menu.
+// Every app creates its overlay widgets once, and appends them to the root widget after the root widget's own children.
+// The positions and the sizes of the overlays are not set.
+//
+// RegisterOverlay is not concurrent-safe. RegisterOverlay is intended to be called from an init function of a widget package.
+func RegisterOverlay(newWidget func() Widget) {
+	theOverlayFuncs = append(theOverlayFuncs, newWidget)
+}
+
+// appendOverlays appends the overlays to the root widget, creating them at the first call.
+func (a *app) appendOverlays(appender *ChildWidgetAppender) {
+	if !a.overlaysCreated {
+		for _, f := range theOverlayFuncs {
+			a.overlays = append(a.overlays, f())
+		}
+		a.overlaysCreated = true
+	}
+	for _, overlay := range a.overlays {
+		appender.AppendChildWidget(overlay)
+	}
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+type overlayWidget struct {
+	guigui.DefaultWidget
+
+	built int
+}
+
+func (o *overlayWidget) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	o.built++
+	return nil
+}
+
+func TestOverlay(t *testing.T) {
+	var overlays []*overlayWidget
+	defer guigui.SetOverlayFuncs(guigui.SetOverlayFuncs(nil))
+	guigui.RegisterOverlay(func() guigui.Widget {
+		o := &overlayWidget{}
+		overlays = append(overlays, o)
+		return o
+	})
+
+	var root overlayWidget
+	a := guigui.NewTestApp(&root, image.Pt(100, 100))
+	for range 2 {
+		if err := a.Build(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The overlay is created once per app.
+	if got, want := len(overlays), 1; got != want {
+		t.Fatalf("created overlays: got: %d, want: %d", got, want)
+	}
+	o := overlays[0]
+	if got, want := o.built, 2; got != want {
+		t.Errorf("overlay built: got: %d, want: %d", got, want)
+	}
+	if got := a.Context().Parent(o); got != &root {
+		t.Errorf("overlay's parent: got: %v, want: the root", got)
+	}
+}
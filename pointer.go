@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// PointerHoverHandler is implemented by widgets that are notified when the pointer enters or leaves them.
+//
+// A widget is hovered while IsWidgetHitAtCursor reports true for the widget.
+// The notifications are delivered after the widget tree is built and before pointing inputs are handled.
+type PointerHoverHandler interface {
+	HandlePointerEnter(context *Context)
+	HandlePointerLeave(context *Context)
+}
+
+// PointerPressHandler is implemented by widgets that are notified when a mouse button is pressed on them and released.
+//
+// HandlePointerPress is called for the hovered widgets when a mouse button is pressed.
+// HandlePointerRelease is called for the same widgets when the button is released, even if the pointer is no longer on them.
+type PointerPressHandler interface {
+	HandlePointerPress(context *Context, button ebiten.MouseButton)
+	HandlePointerRelease(context *Context, button ebiten.MouseButton)
+}
+
+// CapturePointer makes widget receive all the pointing inputs, even outside its bounds or under higher-z widgets.
+// While widget holds the capture, HandlePointingInput is called only for widget,
+// and IsWidgetHitAtCursor reports true only for widget.
+//
+// The capture is released by ReleasePointer, when all the mouse buttons and touches are released,
+// or when widget is no longer visible, enabled or in the widget tree.
+func (c *Context) CapturePointer(widget Widget) {
+	ws := widget.widgetState()
+	if !ws.isInTree() || !ws.isVisible() || !ws.isEnabled() {
+		return
+	}
+	c.app.pointerCapture = widget
+}
+
+// ReleasePointer releases the pointer capture if widget holds it.
+func (c *Context) ReleasePointer(widget Widget) {
+	if c.HasPointerCapture(widget) {
+		c.app.pointerCapture = nil
+	}
+}
+
+// HasPointerCapture reports whether widget holds the pointer capture.
+func (c *Context) HasPointerCapture(widget Widget) bool {
+	return c.app.pointerCapture != nil && c.app.pointerCapture.widgetState() == widget.widgetState()
+}
+
+func (a *app) isPointerPressed() bool {
+	for b := ebiten.MouseButton0; b <= ebiten.MouseButtonMax; b++ {
+		if ebiten.IsMouseButtonPressed(b) {
+			return true
+		}
+	}
+	a.tmpTouchIDs = ebiten.AppendTouchIDs(a.tmpTouchIDs[:0])
+	return len(a.tmpTouchIDs) > 0
+}
+
+func (a *app) validatePointerCapture() {
+	if a.pointerCapture == nil {
+		return
+	}
+	ws := a.pointerCapture.widgetState()
+	if !ws.isInTree() || !ws.isVisible() || !ws.isEnabled() {
+		a.pointerCapture = nil
+	}
+}
+
+// releasePointerCaptureIfNeeded releases the pointer capture after the pointing inputs are handled,
+// so that the capturing widget can handle the release of the buttons.
+func (a *app) releasePointerCaptureIfNeeded() {
+	if a.pointerCapture == nil {
+		return
+	}
+	if !a.isPointerPressed() {
+		a.pointerCapture = nil
+	}
+}
+
+func (a *app) handleCapturedPointingInput() HandleInputResult {
+	widget := a.pointerCapture
+	var r HandleInputResult
+	a.callForWidget(widget, func() {
+		r = widget.HandlePointingInput(&a.context)
+	})
+	return r
+}
+
+// appendHoveredWidgets appends the widgets for which isWidgetHitAt reports true.
+func (a *app) appendHoveredWidgets(widgets []Widget) []Widget {
+	if a.pointerCapture != nil {
+		return append(widgets, a.pointerCapture)
+	}
+	for i, widget := range a.hitWidgets {
+		if i > 0 && widget.widgetState().z < a.hitWidgets[0].widgetState().z {
+			break
+		}
+		widgets = append(widgets, widget)
+	}
+	return widgets
+}
+
+func containsWidget(widgets []Widget, widget Widget) bool {
+	return slices.ContainsFunc(widgets, func(w Widget) bool {
+		return w.widgetState() == widget.widgetState()
+	})
+}
+
+// notifyPointerEvents delivers hover and press notifications by comparing the hovered widgets with the previous frame's.
+func (a *app) notifyPointerEvents() {
+	a.tmpHoveredWidgets = a.appendHoveredWidgets(a.tmpHoveredWidgets[:0])
+
+	for _, widget := range a.hoveredWidgets {
+		if containsWidget(a.tmpHoveredWidgets, widget) {
+			continue
+		}
+		if h, ok := widget.(PointerHoverHandler); ok {
+			a.callForWidget(widget, func() {
+				h.HandlePointerLeave(&a.context)
+			})
+		}
+	}
+	for _, widget := range a.tmpHoveredWidgets {
+		if containsWidget(a.hoveredWidgets, widget) {
+			continue
+		}
+		if h, ok := widget.(PointerHoverHandler); ok {
+			a.callForWidget(widget, func() {
+				h.HandlePointerEnter(&a.context)
+			})
+		}
+	}
+	a.hoveredWidgets, a.tmpHoveredWidgets = a.tmpHoveredWidgets, a.hoveredWidgets
+
+	for b := ebiten.MouseButton0; b <= ebiten.MouseButtonMax; b++ {
+		if inpututil.IsMouseButtonJustReleased(b) {
+			a.notifyPointerRelease(b)
+		}
+		if inpututil.IsMouseButtonJustPressed(b) {
+			a.notifyPointerPress(b)
+		}
+	}
+}
+
+// notifyPointerPress notifies the hovered widgets that button is pressed.
+func (a *app) notifyPointerPress(button ebiten.MouseButton) {
+	for _, widget := range a.hoveredWidgets {
+		h, ok := widget.(PointerPressHandler)
+		if !ok {
+			continue
+		}
+		a.pressedWidgets[button] = append(a.pressedWidgets[button], widget)
+		a.callForWidget(widget, func() {
+			h.HandlePointerPress(&a.context, button)
+		})
+	}
+}
+
+// notifyPointerRelease notifies the widgets notified of the press that button is released.
+func (a *app) notifyPointerRelease(button ebiten.MouseButton) {
+	for _, widget := range a.pressedWidgets[button] {
+		h := widget.(PointerPressHandler)
+		a.callForWidget(widget, func() {
+			h.HandlePointerRelease(&a.context, button)
+		})
+	}
+	a.pressedWidgets[button] = slices.Delete(a.pressedWidgets[button], 0, len(a.pressedWidgets[button]))
+}
@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// pointerWidget records the pointer notifications.
+type pointerWidget struct {
+	guigui.DefaultWidget
+
+	name   string
+	events *[]string
+	inputs int
+}
+
+func (p *pointerWidget) HandlePointingInput(context *guigui.Context) guigui.HandleInputResult {
+	p.inputs++
+	return guigui.HandleInputResult{}
+}
+
+func (p *pointerWidget) HandlePointerEnter(context *guigui.Context) {
+	*p.events = append(*p.events, p.name+" enter")
+}
+
+func (p *pointerWidget) HandlePointerLeave(context *guigui.Context) {
+	*p.events = append(*p.events, p.name+" leave")
+}
+
+func (p *pointerWidget) HandlePointerPress(context *guigui.Context, button ebiten.MouseButton) {
+	*p.events = append(*p.events, p.name+" press")
+}
+
+func (p *pointerWidget) HandlePointerRelease(context *guigui.Context, button ebiten.MouseButton) {
+	*p.events = append(*p.events, p.name+" release")
+}
+
+// pointerRoot has two children. The cursor is at (0, 0) in tests, so a child at the origin is hovered.
+type pointerRoot struct {
+	guigui.DefaultWidget
+
+	a       pointerWidget
+	b       pointerWidget
+	boundsA image.Rectangle
+	boundsB image.Rectangle
+	hasB    bool
+}
+
+func (p *pointerRoot) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	appender.AppendChildWidgetWithBounds(&p.a, p.boundsA)
+	if p.hasB {
+		appender.AppendChildWidgetWithBounds(&p.b, p.boundsB)
+	}
+	return nil
+}
+
+func newPointerRoot(events *[]string) *pointerRoot {
+	r := &pointerRoot{
+		boundsA: image.Rect(0, 0, 10, 10),
+		boundsB: image.Rect(50, 50, 60, 60),
+		hasB:    true,
+	}
+	r.a.name = "a"
+	r.a.events = events
+	r.b.name = "b"
+	r.b.events = events
+	return r
+}
+
+func TestPointerHover(t *testing.T) {
+	var events []string
+	r := newPointerRoot(&events)
+	a := guigui.NewTestApp(r, image.Pt(100, 100))
+
+	steps := []struct {
+		boundsA image.Rectangle
+		boundsB image.Rectangle
+		want    []string
+	}{
+		{
+			boundsA: image.Rect(0, 0, 10, 10),
+			boundsB: image.Rect(50, 50, 60, 60),
+			want:    []string{"a enter"},
+		},
+		{
+			// Nothing changes.
+			boundsA: image.Rect(0, 0, 10, 10),
+			boundsB: image.Rect(50, 50, 60, 60),
+			want:    nil,
+		},
+		{
+			// The leave is delivered before the enter.
+			boundsA: image.Rect(50, 50, 60, 60),
+			boundsB: image.Rect(0, 0, 10, 10),
+			want:    []string{"a leave", "b enter"},
+		},
+		{
+			boundsA: image.Rect(50, 50, 60, 60),
+			boundsB: image.Rect(50, 50, 60, 60),
+			want:    []string{"b leave"},
+		},
+	}
+	for i, s := range steps {
+		events = nil
+		r.boundsA = s.boundsA
+		r.boundsB = s.boundsB
+		if err := a.Build(); err != nil {
+			t.Fatal(err)
+		}
+		a.NotifyPointerEvents()
+		if !slices.Equal(events, s.want) {
+			t.Errorf("step %d: got: %v, want: %v", i, events, s.want)
+		}
+	}
+
+	// A removed widget is no longer hovered.
+	r.boundsB = image.Rect(0, 0, 10, 10)
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	a.NotifyPointerEvents()
+	events = nil
+	r.hasB = false
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	a.NotifyPointerEvents()
+	if want := []string{"b leave"}; !slices.Equal(events, want) {
+		t.Errorf("got: %v, want: %v", events, want)
+	}
+}
+
+func TestPointerPress(t *testing.T) {
+	var events []string
+	r := newPointerRoot(&events)
+	a := guigui.NewTestApp(r, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	a.NotifyPointerEvents()
+
+	events = nil
+	a.PressPointer(ebiten.MouseButtonLeft)
+	if want := []string{"a press"}; !slices.Equal(events, want) {
+		t.Errorf("got: %v, want: %v", events, want)
+	}
+
+	// The release is delivered to the pressed widget even after the pointer leaves it.
+	r.boundsA = image.Rect(50, 50, 60, 60)
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	a.NotifyPointerEvents()
+	events = nil
+	a.ReleasePointer(ebiten.MouseButtonRight)
+	if len(events) != 0 {
+		t.Errorf("got: %v, want: []", events)
+	}
+	a.ReleasePointer(ebiten.MouseButtonLeft)
+	if want := []string{"a release"}; !slices.Equal(events, want) {
+		t.Errorf("got: %v, want: %v", events, want)
+	}
+	events = nil
+	a.ReleasePointer(ebiten.MouseButtonLeft)
+	if len(events) != 0 {
+		t.Errorf("got: %v, want: []", events)
+	}
+}
+
+func TestPointerCapture(t *testing.T) {
+	var events []string
+	r := newPointerRoot(&events)
+	a := guigui.NewTestApp(r, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	a.NotifyPointerEvents()
+	context := a.Context()
+
+	// The captured widget is the only widget hit at the cursor, even outside its bounds.
+	events = nil
+	context.CapturePointer(&r.b)
+	if !context.HasPointerCapture(&r.b) {
+		t.Errorf("HasPointerCapture: got: false, want: true")
+	}
+	if context.HasPointerCapture(&r.a) {
+		t.Errorf("HasPointerCapture: got: true, want: false")
+	}
+	if context.IsWidgetHitAtCursor(&r.a) {
+		t.Errorf("IsWidgetHitAtCursor(a): got: true, want: false")
+	}
+	if !context.IsWidgetHitAtCursor(&r.b) {
+		t.Errorf("IsWidgetHitAtCursor(b): got: false, want: true")
+	}
+	a.NotifyPointerEvents()
+	if want := []string{"a leave", "b enter"}; !slices.Equal(events, want) {
+		t.Errorf("got: %v, want: %v", events, want)
+	}
+
+	// Only the captured widget handles the pointing input.
+	a.HandlePointingInput()
+	if got, want := r.a.inputs, 0; got != want {
+		t.Errorf("a.inputs: got: %d, want: %d", got, want)
+	}
+	if got, want := r.b.inputs, 1; got != want {
+		t.Errorf("b.inputs: got: %d, want: %d", got, want)
+	}
+
+	// ReleasePointer by another widget doesn't release the capture.
+	context.ReleasePointer(&r.a)
+	if !context.HasPointerCapture(&r.b) {
+		t.Errorf("HasPointerCapture: got: false, want: true")
+	}
+	context.ReleasePointer(&r.b)
+	if context.HasPointerCapture(&r.b) {
+		t.Errorf("HasPointerCapture: got: true, want: false")
+	}
+
+	// The capture is released when no buttons are pressed.
+	context.CapturePointer(&r.b)
+	a.ReleasePointerCaptureIfNeeded()
+	if context.HasPointerCapture(&r.b) {
+		t.Errorf("HasPointerCapture after the buttons are released: got: true, want: false")
+	}
+}
+
+func TestPointerCaptureInvalidated(t *testing.T) {
+	for _, name := range []string{"hidden", "disabled", "removed"} {
+		t.Run(name, func(t *testing.T) {
+			var events []string
+			r := newPointerRoot(&events)
+			a := guigui.NewTestApp(r, image.Pt(100, 100))
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+			context := a.Context()
+			context.CapturePointer(&r.b)
+
+			switch name {
+			case "hidden":
+				context.SetVisible(&r.b, false)
+			case "disabled":
+				context.SetEnabled(&r.b, false)
+			case "removed":
+				r.hasB = false
+			}
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+			a.NotifyPointerEvents()
+			if context.HasPointerCapture(&r.b) {
+				t.Errorf("HasPointerCapture: got: true, want: false")
+			}
+			if !context.IsWidgetHitAtCursor(&r.a) {
+				t.Errorf("IsWidgetHitAtCursor(a): got: false, want: true")
+			}
+
+			// Such a widget cannot capture the pointer.
+			context.CapturePointer(&r.b)
+			if context.HasPointerCapture(&r.b) {
+				t.Errorf("HasPointerCapture after CapturePointer: got: true, want: false")
+			}
+		})
+	}
+}
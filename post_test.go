@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"errors"
+	"image"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// parentWidget has child as its child if hasChild is true.
+type parentWidget struct {
+	guigui.DefaultWidget
+
+	child    guigui.Widget
+	hasChild bool
+}
+
+func (p *parentWidget) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	if p.hasChild {
+		appender.AppendChildWidget(p.child)
+	}
+	return nil
+}
+
+func TestPostOrder(t *testing.T) {
+	var child panicWidget
+	p := &parentWidget{
+		child:    &child,
+		hasChild: true,
+	}
+	a := guigui.NewTestApp(p, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	context := a.Context()
+	context.Post(&child, func(context *guigui.Context) {
+		got = append(got, 0)
+		// A task posted by a task runs at the next time.
+		context.Post(nil, func(context *guigui.Context) {
+			got = append(got, 3)
+		})
+	})
+	context.Post(nil, func(context *guigui.Context) {
+		got = append(got, 1)
+	})
+	context.Post(p, func(context *guigui.Context) {
+		got = append(got, 2)
+	})
+	a.RunPostedTasks()
+	if want := []int{0, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	a.RunPostedTasks()
+	if want := []int{0, 1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestPostFromGoroutines(t *testing.T) {
+	var root panicWidget
+	a := guigui.NewTestApp(&root, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 8
+	const m = 100
+	var results [n][]int
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range m {
+				a.Context().Post(&root, func(context *guigui.Context) {
+					results[i] = append(results[i], j)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	a.RunPostedTasks()
+
+	// The tasks from one goroutine run in the posted order.
+	for i, r := range results {
+		if len(r) != m {
+			t.Errorf("len(results[%d]): got: %d, want: %d", i, len(r), m)
+			continue
+		}
+		for j, v := range r {
+			if v != j {
+				t.Errorf("results[%d][%d]: got: %d, want: %d", i, j, v, j)
+				break
+			}
+		}
+	}
+}
+
+func TestPostDropsTasksOfRemovedWidgets(t *testing.T) {
+	var child panicWidget
+	p := &parentWidget{
+		child:    &child,
+		hasChild: true,
+	}
+	a := guigui.NewTestApp(p, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	var childCalled, parentCalled, nilCalled bool
+	context := a.Context()
+	context.Post(&child, func(context *guigui.Context) {
+		childCalled = true
+	})
+	context.Post(p, func(context *guigui.Context) {
+		parentCalled = true
+	})
+	context.Post(nil, func(context *guigui.Context) {
+		nilCalled = true
+	})
+
+	// Remove the child before the tasks run.
+	p.hasChild = false
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	a.RunPostedTasks()
+	if childCalled {
+		t.Errorf("the task of the removed widget is called")
+	}
+	if !parentCalled {
+		t.Errorf("the task of the widget in the tree is not called")
+	}
+	if !nilCalled {
+		t.Errorf("the task without a widget is not called")
+	}
+
+	// The widget that has never been in the tree doesn't run the task either.
+	var orphan panicWidget
+	var orphanCalled bool
+	context.Post(&orphan, func(context *guigui.Context) {
+		orphanCalled = true
+	})
+	a.RunPostedTasks()
+	if orphanCalled {
+		t.Errorf("the task of the widget not in the tree is called")
+	}
+}
+
+func TestPostCatchesPanic(t *testing.T) {
+	var content, fallback panicWidget
+	var b guigui.ErrorBoundary
+	b.SetContent(&content)
+	b.SetFallback(&fallback)
+	var gotErr error
+	b.SetOnError(func(err error) {
+		gotErr = err
+	})
+
+	a := guigui.NewTestApp(&b, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	var afterCalled bool
+	a.Context().Post(&content, func(context *guigui.Context) {
+		panic(errTest)
+	})
+	a.Context().Post(nil, func(context *guigui.Context) {
+		afterCalled = true
+	})
+	a.RunPostedTasks()
+	if !errors.Is(gotErr, errTest) {
+		t.Errorf("OnError: got: %v, want: %v", gotErr, errTest)
+	}
+	if !afterCalled {
+		t.Errorf("the task after the panicking task is not called")
+	}
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+	if fallback.built == 0 {
+		t.Errorf("the fallback is not built")
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+)
+
+// ScrollAlignment specifies where a widget is placed when the widget is scrolled into view.
+type ScrollAlignment int
+
+const (
+	// ScrollAlignmentNearest scrolls as little as possible.
+	// A widget already in view is not scrolled.
+	ScrollAlignmentNearest ScrollAlignment = iota
+	ScrollAlignmentStart
+	ScrollAlignmentCenter
+	ScrollAlignmentEnd
+)
+
+// ScrollIntoViewHandler is implemented by widgets that scroll their descendants, e.g. basicwidget.Panel.
+type ScrollIntoViewHandler interface {
+	// HandleScrollIntoView scrolls the descendants so that bounds is visible with the alignment.
+	// bounds is in the current coordinates of the descendants.
+	// HandleScrollIntoView returns the amount by which the descendants are moved.
+	HandleScrollIntoView(context *Context, bounds image.Rectangle, alignment ScrollAlignment, animated bool) image.Point
+}
+
+// ScrollIntoView scrolls all the ancestors implementing ScrollIntoViewHandler so that widget is visible.
+//
+// ScrollIntoView is called with ScrollAlignmentNearest automatically when the focus is moved by directional focus navigation.
+// The focus moved by e.g. a click or SetFocused doesn't scroll.
+func (c *Context) ScrollIntoView(widget Widget, alignment ScrollAlignment) {
+	c.scrollIntoView(widget, alignment, false)
+}
+
+// ScrollIntoViewAnimated is like ScrollIntoView, but scrolls smoothly.
+func (c *Context) ScrollIntoViewAnimated(widget Widget, alignment ScrollAlignment) {
+	c.scrollIntoView(widget, alignment, true)
+}
+
+func (c *Context) scrollIntoView(widget Widget, alignment ScrollAlignment, animated bool) {
+	if !widget.widgetState().isInTree() {
+		return
+	}
+
+	bounds := c.Bounds(widget)
+	for w := widget; ; {
+		// A widget with a different z, like a popup, is positioned independently of its ancestors' scroll.
+		if w.ZDelta() != 0 {
+			return
+		}
+		parent := w.widgetState().parent
+		if parent == nil {
+			return
+		}
+		if h, ok := parent.(ScrollIntoViewHandler); ok {
+			var delta image.Point
+			c.app.callForWidget(parent, func() {
+				delta = h.HandleScrollIntoView(c, bounds, alignment, animated)
+			})
+			bounds = bounds.Add(delta)
+		} else if b := bounds.Intersect(c.Bounds(parent)); !b.Empty() {
+			// Reveal only the part shown in the parent, e.g. a part of a long text in a text input.
+			bounds = b
+		}
+		w = parent
+	}
+}
+
+// ScrollDeltaToReveal returns the amount to move the range [min, max) so that it is in the viewport [viewportMin, viewportMax) with the alignment.
+// ScrollDeltaToReveal is a helper to implement ScrollIntoViewHandler.
+func ScrollDeltaToReveal(viewportMin, viewportMax, min, max int, alignment ScrollAlignment) int {
+	switch alignment {
+	case ScrollAlignmentStart:
+		return viewportMin - min
+	case ScrollAlignmentCenter:
+		return (viewportMin + viewportMax - min - max) / 2
+	case ScrollAlignmentEnd:
+		return viewportMax - max
+	}
+
+	// ScrollAlignmentNearest
+	if min >= viewportMin && max <= viewportMax {
+		return 0
+	}
+	if max-min > viewportMax-viewportMin {
+		// The range is larger than the viewport.
+		if min <= viewportMin && max >= viewportMax {
+			return 0
+		}
+		return viewportMin - min
+	}
+	if min < viewportMin {
+		return viewportMin - min
+	}
+	return viewportMax - max
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+func TestScrollDeltaToReveal(t *testing.T) {
+	testCases := []struct {
+		min       int
+		max       int
+		alignment guigui.ScrollAlignment
+		want      int
+	}{
+		// The viewport is [100, 200).
+		{min: 120, max: 140, alignment: guigui.ScrollAlignmentNearest, want: 0},
+		{min: 80, max: 100, alignment: guigui.ScrollAlignmentNearest, want: 20},
+		{min: 190, max: 230, alignment: guigui.ScrollAlignmentNearest, want: -30},
+		{min: 300, max: 350, alignment: guigui.ScrollAlignmentNearest, want: -150},
+		{min: 50, max: 250, alignment: guigui.ScrollAlignmentNearest, want: 0},
+		{min: 150, max: 400, alignment: guigui.ScrollAlignmentNearest, want: -50},
+		{min: 120, max: 140, alignment: guigui.ScrollAlignmentStart, want: -20},
+		{min: 120, max: 140, alignment: guigui.ScrollAlignmentCenter, want: 20},
+		{min: 120, max: 140, alignment: guigui.ScrollAlignmentEnd, want: 60},
+	}
+	for _, tc := range testCases {
+		if got := guigui.ScrollDeltaToReveal(100, 200, tc.min, tc.max, tc.alignment); got != tc.want {
+			t.Errorf("ScrollDeltaToReveal(100, 200, %d, %d, %d): got: %d, want: %d", tc.min, tc.max, tc.alignment, got, tc.want)
+		}
+	}
+}
+
+type focusableWidget struct {
+	guigui.DefaultWidget
+}
+
+func (f *focusableWidget) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	context.SetFocusable(f, true)
+	return nil
+}
+
+// scrollerWidget records the calls of HandleScrollIntoView without scrolling.
+type scrollerWidget struct {
+	guigui.DefaultWidget
+
+	item1 focusableWidget
+	item2 focusableWidget
+
+	scrollCount int
+}
+
+func (s *scrollerWidget) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	appender.AppendChildWidgetWithBounds(&s.item1, image.Rect(0, 0, 100, 10))
+	appender.AppendChildWidgetWithBounds(&s.item2, image.Rect(0, 10, 100, 20))
+	return nil
+}
+
+func (s *scrollerWidget) HandleScrollIntoView(context *guigui.Context, bounds image.Rectangle, alignment guigui.ScrollAlignment, animated bool) image.Point {
+	s.scrollCount++
+	return image.Point{}
+}
+
+func TestScrollIntoViewOnFocus(t *testing.T) {
+	var s scrollerWidget
+	a := guigui.NewTestApp(&s, image.Pt(100, 100))
+	if err := a.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Focusing by SetFocused, e.g. by a click, doesn't scroll.
+	a.Context().SetFocused(&s.item1, true)
+	if got, want := s.scrollCount, 0; got != want {
+		t.Errorf("scroll count after SetFocused: got: %d, want: %d", got, want)
+	}
+
+	// Focusing by directional focus navigation scrolls.
+	if !a.Context().MoveFocus(guigui.FocusDirectionDown) {
+		t.Fatal("MoveFocus didn't move the focus")
+	}
+	if !a.Context().IsFocused(&s.item2) {
+		t.Errorf("item2 is not focused")
+	}
+	if got, want := s.scrollCount, 1; got != want {
+		t.Errorf("scroll count after MoveFocus: got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+// ShortcutHandler is implemented by widgets that handle keyboard shortcuts regardless of the focus, e.g. a menu bar.
+//
+// HandleShortcut is called only when neither the focused widget nor its ancestors handle the button inputs,
+// so a focused widget can take precedence over a shortcut with the same keys.
+// HandleShortcut is called for the visible and enabled widgets in the reverse order of rendering until one returns true.
+type ShortcutHandler interface {
+	HandleShortcut(context *Context) bool
+}
+
+// handleShortcutInput calls HandleShortcut of widget and its descendants until one returns true.
+func (a *app) handleShortcutInput(widget Widget) bool {
+	widgetState := widget.widgetState()
+	if widgetState.hidden || widgetState.disabled {
+		return false
+	}
+	for i := len(widgetState.children) - 1; i >= 0; i-- {
+		if a.handleShortcutInput(widgetState.children[i]) {
+			return true
+		}
+	}
+	h, ok := widget.(ShortcutHandler)
+	if !ok {
+		return false
+	}
+	var handled bool
+	a.callForWidget(widget, func() {
+		handled = h.HandleShortcut(&a.context)
+	})
+	return handled
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+// shortcutWidget records the calls of HandleButtonInput and HandleShortcut.
+type shortcutWidget struct {
+	guigui.DefaultWidget
+
+	name    string
+	handles bool
+	calls   *[]string
+}
+
+func (s *shortcutWidget) HandleButtonInput(context *guigui.Context) guigui.HandleInputResult {
+	*s.calls = append(*s.calls, s.name+" button")
+	if s.handles {
+		return guigui.HandleInputByWidget(s)
+	}
+	return guigui.HandleInputResult{}
+}
+
+func (s *shortcutWidget) HandleShortcut(context *guigui.Context) bool {
+	*s.calls = append(*s.calls, s.name+" shortcut")
+	return s.handles
+}
+
+type shortcutRoot struct {
+	guigui.DefaultWidget
+
+	focused   shortcutWidget
+	shortcut1 shortcutWidget
+	shortcut2 shortcutWidget
+}
+
+func (s *shortcutRoot) Build(context *guigui.Context, appender *guigui.ChildWidgetAppender) error {
+	appender.AppendChildWidgetWithBounds(&s.shortcut1, image.Rect(0, 0, 10, 10))
+	appender.AppendChildWidgetWithBounds(&s.shortcut2, image.Rect(10, 0, 20, 10))
+	appender.AppendChildWidgetWithBounds(&s.focused, image.Rect(20, 0, 30, 10))
+	return nil
+}
+
+func TestShortcutHandler(t *testing.T) {
+	testCases := []struct {
+		name            string
+		focusedHandles  bool
+		shortcut1       bool
+		shortcut2       bool
+		shortcut2Hidden bool
+		want            []string
+	}{
+		{
+			name:           "focused widget handles",
+			focusedHandles: true,
+			shortcut1:      true,
+			shortcut2:      true,
+			want:           []string{"focused button"},
+		},
+		{
+			name:      "shortcut in the reverse order",
+			shortcut1: true,
+			shortcut2: true,
+			want:      []string{"focused button", "focused shortcut", "shortcut2 shortcut"},
+		},
+		{
+			name:      "no widget handles shortcuts",
+			shortcut1: false,
+			shortcut2: false,
+			want:      []string{"focused button", "focused shortcut", "shortcut2 shortcut", "shortcut1 shortcut"},
+		},
+		{
+			name:            "hidden widget",
+			shortcut1:       true,
+			shortcut2:       true,
+			shortcut2Hidden: true,
+			want:            []string{"focused button", "focused shortcut", "shortcut1 shortcut"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			var r shortcutRoot
+			r.focused = shortcutWidget{name: "focused", handles: tc.focusedHandles, calls: &calls}
+			r.shortcut1 = shortcutWidget{name: "shortcut1", handles: tc.shortcut1, calls: &calls}
+			r.shortcut2 = shortcutWidget{name: "shortcut2", handles: tc.shortcut2, calls: &calls}
+
+			a := guigui.NewTestApp(&r, image.Pt(100, 100))
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+			a.Context().SetVisible(&r.shortcut2, !tc.shortcut2Hidden)
+			a.Context().SetFocused(&r.focused, true)
+			if err := a.Build(); err != nil {
+				t.Fatal(err)
+			}
+
+			a.HandleButtonInput()
+			if !slices.Equal(calls, tc.want) {
+				t.Errorf("got: %v, want: %v", calls, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+	"maps"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// updateTouchHitWidgets updates the widgets at each touch, in the same way as hitWidgets for the cursor.
+func (a *app) updateTouchHitWidgets() {
+	if a.touchHitWidgets == nil {
+		a.touchHitWidgets = map[ebiten.TouchID][]Widget{}
+	}
+
+	a.tmpTouchIDs = ebiten.AppendTouchIDs(a.tmpTouchIDs[:0])
+	maps.DeleteFunc(a.touchHitWidgets, func(id ebiten.TouchID, _ []Widget) bool {
+		return !slices.Contains(a.tmpTouchIDs, id)
+	})
+	for _, id := range a.tmpTouchIDs {
+		widgets := slices.Delete(a.touchHitWidgets[id], 0, len(a.touchHitWidgets[id]))
+		widgets = a.appendWidgetsAt(widgets, image.Pt(ebiten.TouchPosition(id)), a.root, true)
+		slices.SortStableFunc(widgets, func(a, b Widget) int {
+			return b.widgetState().z - a.widgetState().z
+		})
+		a.touchHitWidgets[id] = widgets
+	}
+}
+
+// IsWidgetHitAtTouch reports whether widget is hit at the touch, in the same way as IsWidgetHitAtCursor.
+// IsWidgetHitAtTouch returns false if the touch is not pressed.
+func (c *Context) IsWidgetHitAtTouch(widget Widget, id ebiten.TouchID) bool {
+	widgets, ok := c.app.touchHitWidgets[id]
+	if !ok {
+		return false
+	}
+	return c.app.isWidgetHitIn(widget, widgets)
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui
+
+import (
+	"slices"
+)
+
+// UndoCommand is an undoable operation.
+type UndoCommand interface {
+	Do()
+	Undo()
+}
+
+// UndoMerger is implemented by an UndoCommand that can absorb the next command, e.g. consecutive typing.
+type UndoMerger interface {
+	// MergeUndoCommand merges next, which is already done, into the command and reports whether the merge succeeded.
+	MergeUndoCommand(next UndoCommand) bool
+}
+
+// UndoManager manages undo and redo stacks of UndoCommands.
+//
+// The zero value is an empty manager without a maximum depth.
+type UndoManager struct {
+	undoEntries [][]UndoCommand
+	redoEntries [][]UndoCommand
+
+	maxDepth     int
+	groupDepth   int
+	groupStarted bool
+	mergeBroken  bool
+}
+
+// SetMaxDepth sets the maximum number of entries that can be undone.
+// 0 means no limit.
+func (u *UndoManager) SetMaxDepth(depth int) {
+	u.maxDepth = depth
+	u.trim()
+}
+
+// Do does command and pushes it to the undo stack.
+func (u *UndoManager) Do(command UndoCommand) {
+	command.Do()
+	u.Push(command)
+}
+
+// Push pushes command, which is already done, to the undo stack.
+//
+// Push clears the redo stack.
+// If the previous entry implements UndoMerger and can absorb command, command is merged into the entry.
+func (u *UndoManager) Push(command UndoCommand) {
+	clear(u.redoEntries)
+	u.redoEntries = u.redoEntries[:0]
+
+	if u.groupDepth > 0 {
+		if u.groupStarted {
+			u.undoEntries[len(u.undoEntries)-1] = append(u.undoEntries[len(u.undoEntries)-1], command)
+			return
+		}
+		u.groupStarted = true
+	} else if !u.mergeBroken && len(u.undoEntries) > 0 {
+		if last := u.undoEntries[len(u.undoEntries)-1]; len(last) == 1 {
+			if m, ok := last[0].(UndoMerger); ok && m.MergeUndoCommand(command) {
+				return
+			}
+		}
+	}
+	u.mergeBroken = false
+
+	u.undoEntries = append(u.undoEntries, []UndoCommand{command})
+	u.trim()
+}
+
+func (u *UndoManager) trim() {
+	if u.maxDepth <= 0 || len(u.undoEntries) <= u.maxDepth {
+		return
+	}
+	u.undoEntries = slices.Delete(u.undoEntries, 0, len(u.undoEntries)-u.maxDepth)
+}
+
+// BeginGroup starts a group. The commands pushed until the corresponding EndGroup are undone and redone at once.
+//
+// Groups can be nested.
+func (u *UndoManager) BeginGroup() {
+	if u.groupDepth == 0 {
+		u.groupStarted = false
+	}
+	u.groupDepth++
+}
+
+func (u *UndoManager) EndGroup() {
+	if u.groupDepth == 0 {
+		panic("guigui: EndGroup is called without BeginGroup")
+	}
+	u.groupDepth--
+	if u.groupDepth == 0 && u.groupStarted {
+		u.mergeBroken = true
+	}
+}
+
+// BreakMerge prevents the next pushed command from being merged into the current entry.
+func (u *UndoManager) BreakMerge() {
+	u.mergeBroken = true
+}
+
+func (u *UndoManager) CanUndo() bool {
+	return len(u.undoEntries) > 0
+}
+
+func (u *UndoManager) CanRedo() bool {
+	return len(u.redoEntries) > 0
+}
+
+// Undo undoes the last entry and reports whether there was an entry to undo.
+func (u *UndoManager) Undo() bool {
+	if u.groupDepth > 0 {
+		panic("guigui: Undo cannot be called in a group")
+	}
+	if len(u.undoEntries) == 0 {
+		return false
+	}
+	entry := u.undoEntries[len(u.undoEntries)-1]
+	u.undoEntries[len(u.undoEntries)-1] = nil
+	u.undoEntries = u.undoEntries[:len(u.undoEntries)-1]
+	for i := len(entry) - 1; i >= 0; i-- {
+		entry[i].Undo()
+	}
+	u.redoEntries = append(u.redoEntries, entry)
+	u.mergeBroken = true
+	return true
+}
+
+// Redo redoes the last undone entry and reports whether there was an entry to redo.
+func (u *UndoManager) Redo() bool {
+	if u.groupDepth > 0 {
+		panic("guigui: Redo cannot be called in a group")
+	}
+	if len(u.redoEntries) == 0 {
+		return false
+	}
+	entry := u.redoEntries[len(u.redoEntries)-1]
+	u.redoEntries[len(u.redoEntries)-1] = nil
+	u.redoEntries = u.redoEntries[:len(u.redoEntries)-1]
+	for _, command := range entry {
+		command.Do()
+	}
+	u.undoEntries = append(u.undoEntries, entry)
+	u.mergeBroken = true
+	return true
+}
+
+// Clear removes all the entries.
+func (u *UndoManager) Clear() {
+	clear(u.undoEntries)
+	u.undoEntries = u.undoEntries[:0]
+	clear(u.redoEntries)
+	u.redoEntries = u.redoEntries[:0]
+	u.mergeBroken = false
+}
+
+// UndoFunc returns an UndoCommand calling doFunc and undoFunc.
+func UndoFunc(doFunc, undoFunc func()) UndoCommand {
+	return &funcUndoCommand{
+		do:   doFunc,
+		undo: undoFunc,
+	}
+}
+
+type funcUndoCommand struct {
+	do   func()
+	undo func()
+}
+
+func (u *funcUndoCommand) Do() {
+	u.do()
+}
+
+func (u *funcUndoCommand) Undo() {
+	u.undo()
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Guigui Authors
+
+package guigui_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/guigui"
+)
+
+type appendCommand struct {
+	values *[]int
+	added  []int
+}
+
+func (a *appendCommand) Do() {
+	*a.values = append(*a.values, a.added...)
+}
+
+func (a *appendCommand) Undo() {
+	*a.values = (*a.values)[:len(*a.values)-len(a.added)]
+}
+
+func (a *appendCommand) MergeUndoCommand(next guigui.UndoCommand) bool {
+	n, ok := next.(*appendCommand)
+	if !ok || n.values != a.values {
+		return false
+	}
+	a.added = append(a.added, n.added...)
+	return true
+}
+
+func TestUndoManager(t *testing.T) {
+	var values []int
+	var u guigui.UndoManager
+
+	u.Do(&appendCommand{values: &values, added: []int{1}})
+	u.BreakMerge()
+	u.Do(&appendCommand{values: &values, added: []int{2}})
+	if got, want := values, []int{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	if !u.Undo() {
+		t.Fatal("Undo failed")
+	}
+	if got, want := values, []int{1}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if !u.Redo() {
+		t.Fatal("Redo failed")
+	}
+	if got, want := values, []int{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	u.Undo()
+	u.Do(&appendCommand{values: &values, added: []int{3}})
+	if u.CanRedo() {
+		t.Errorf("CanRedo must be false after a new command")
+	}
+}
+
+func TestUndoManagerMerge(t *testing.T) {
+	var values []int
+	var u guigui.UndoManager
+
+	// Consecutive commands are merged into one entry.
+	u.Do(&appendCommand{values: &values, added: []int{1}})
+	u.Do(&appendCommand{values: &values, added: []int{2}})
+	u.Do(&appendCommand{values: &values, added: []int{3}})
+	u.Undo()
+	if got, want := values, []int{}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if u.CanUndo() {
+		t.Errorf("CanUndo must be false")
+	}
+
+	// A command after undoing is not merged into the redone entry.
+	u.Redo()
+	u.Do(&appendCommand{values: &values, added: []int{4}})
+	u.Undo()
+	if got, want := values, []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestUndoManagerGroup(t *testing.T) {
+	var values []int
+	var u guigui.UndoManager
+
+	u.Do(&appendCommand{values: &values, added: []int{1}})
+	u.BeginGroup()
+	u.Do(guigui.UndoFunc(func() {
+		values = append(values, 2)
+	}, func() {
+		values = values[:len(values)-1]
+	}))
+	u.BeginGroup()
+	u.Do(guigui.UndoFunc(func() {
+		values = append(values, 3)
+	}, func() {
+		values = values[:len(values)-1]
+	}))
+	u.EndGroup()
+	u.EndGroup()
+
+	u.Undo()
+	if got, want := values, []int{1}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	u.Redo()
+	if got, want := values, []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestUndoManagerMaxDepth(t *testing.T) {
+	var values []int
+	var u guigui.UndoManager
+	u.SetMaxDepth(2)
+
+	for i := range 5 {
+		u.BreakMerge()
+		u.Do(&appendCommand{values: &values, added: []int{i}})
+	}
+	var count int
+	for u.Undo() {
+		count++
+	}
+	if got, want := count, 2; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+	if got, want := values, []int{0, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
@@ -96,6 +96,9 @@ type widgetState struct {
 	transparency float64
 	customDraw   CustomDrawFunc
 
+	focusable           bool
+	focusNavigationMode FocusNavigationMode
+
 	offscreen *ebiten.Image
 
 	dirty                 bool